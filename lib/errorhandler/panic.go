@@ -2,7 +2,6 @@ package errorhandler
 
 import (
 	"context"
-	"runtime/debug"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -18,6 +17,7 @@ func PanicUnaryInterceptor(log *zap.Logger) grpc.UnaryServerInterceptor {
 			if r := recover(); r != nil || panicked {
 				message := "handler panic recovery"
 				fields := []zap.Field{
+					zap.String("method", info.FullMethod),
 					zap.StackSkip("stacktrace", 4),
 				}
 				if err, ok := r.(error); ok {
@@ -26,7 +26,8 @@ func PanicUnaryInterceptor(log *zap.Logger) grpc.UnaryServerInterceptor {
 					fields = append(fields, zap.Any("panic", r))
 				}
 				log.Error(message, fields...)
-				err = status.Errorf(codes.Internal, "%v", r)
+				// the panic value can hold internals, the client only learns that the request failed
+				err = status.Error(codes.Internal, "internal error")
 			}
 		}()
 
@@ -42,9 +43,18 @@ func PanicStreamInterceptor(log *zap.Logger) grpc.StreamServerInterceptor {
 
 		defer func() {
 			if r := recover(); r != nil || panicked {
-				debug.PrintStack()
-				log.Error(string(debug.Stack()), zap.Error(err))
-				err = status.Errorf(codes.Internal, "%v", r)
+				message := "handler panic recovery"
+				fields := []zap.Field{
+					zap.String("method", info.FullMethod),
+					zap.StackSkip("stacktrace", 4),
+				}
+				if err, ok := r.(error); ok {
+					message = err.Error()
+				} else {
+					fields = append(fields, zap.Any("panic", r))
+				}
+				log.Error(message, fields...)
+				err = status.Error(codes.Internal, "internal error")
 			}
 		}()
 
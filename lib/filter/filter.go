@@ -0,0 +1,389 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Filters follow the AIP-160 grammar, restricted to comparisons of a field against a literal:
+//
+//	billing_account_id = "abc" AND (status = active OR status = complete) AND NOT create_time < "2024-01-01"
+//
+// As in AIP-160, OR binds tighter than AND. A filter is checked against the Schema of the listing so only fields
+// that have been whitelisted can be used, and values are always passed to postgres as query arguments.
+
+type Type int
+
+const (
+	String Type = iota
+	Number
+	Bool
+	Time
+)
+
+// Field is a filterable field and the column it maps to, Values restricts the field to an enumerated set.
+type Field struct {
+	Column string
+	Type   Type
+	Values []string
+}
+
+// Schema is the set of fields a listing can be filtered and ordered by, keyed by their API name.
+type Schema map[string]Field
+
+// Args collects the positional arguments of a query as it is built.
+type Args []interface{}
+
+// Add appends an argument and returns its placeholder
+func (a *Args) Add(v interface{}) string {
+	*a = append(*a, v)
+	return "$" + strconv.Itoa(len(*a))
+}
+
+type node interface {
+	sql(args *Args) string
+}
+
+type and []node
+
+func (n and) sql(args *Args) string {
+	parts := make([]string, len(n))
+	for i, child := range n {
+		parts[i] = child.sql(args)
+	}
+	return "(" + strings.Join(parts, " AND ") + ")"
+}
+
+type or []node
+
+func (n or) sql(args *Args) string {
+	parts := make([]string, len(n))
+	for i, child := range n {
+		parts[i] = child.sql(args)
+	}
+	return "(" + strings.Join(parts, " OR ") + ")"
+}
+
+type not struct {
+	node
+}
+
+func (n not) sql(args *Args) string {
+	return "NOT " + n.node.sql(args)
+}
+
+type comparison struct {
+	column string
+	op     string
+	value  interface{}
+}
+
+func (n comparison) sql(args *Args) string {
+	op := n.op
+	if op == "!=" {
+		op = "<>"
+	}
+	return fmt.Sprintf("%q %s %s", n.column, op, args.Add(n.value))
+}
+
+// Filter is a parsed filter expression.
+type Filter struct {
+	root node
+}
+
+// SQL returns the filter as a boolean SQL expression, adding its values to args
+func (f *Filter) SQL(args *Args) string {
+	return f.root.sql(args)
+}
+
+// Parse parses a filter expression and checks it against schema. An empty filter returns nil.
+func Parse(filter string, schema Schema) (*Filter, error) {
+	tokens, err := lex(filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	p := parser{tokens: tokens, schema: schema}
+	root, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.peek().text, p.peek().pos)
+	}
+	return &Filter{root: root}, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	schema Schema
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *parser) peek() token {
+	if p.done() {
+		return token{kind: tokenEOF, text: "end of filter", pos: -1}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *parser) keyword(word string) bool {
+	t := p.peek()
+	return t.kind == tokenText && t.text == word
+}
+
+// expression: sequence {AND sequence}
+func (p *parser) expression() (node, error) {
+	res := and{}
+	for {
+		n, err := p.sequence()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, n)
+		if !p.keyword("AND") {
+			break
+		}
+		p.next()
+	}
+	if len(res) == 1 {
+		return res[0], nil
+	}
+	return res, nil
+}
+
+// sequence: factor {factor}, terms next to each other are implicitly joined with AND
+func (p *parser) sequence() (node, error) {
+	res := and{}
+	for {
+		n, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, n)
+		t := p.peek()
+		if t.kind == tokenEOF || t.kind == tokenRParen || p.keyword("AND") {
+			break
+		}
+	}
+	if len(res) == 1 {
+		return res[0], nil
+	}
+	return res, nil
+}
+
+// factor: term {OR term}
+func (p *parser) factor() (node, error) {
+	res := or{}
+	for {
+		n, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, n)
+		if !p.keyword("OR") {
+			break
+		}
+		p.next()
+	}
+	if len(res) == 1 {
+		return res[0], nil
+	}
+	return res, nil
+}
+
+// term: [NOT | -] simple
+func (p *parser) term() (node, error) {
+	if p.keyword("NOT") || p.peek().kind == tokenMinus {
+		p.next()
+		n, err := p.simple()
+		if err != nil {
+			return nil, err
+		}
+		return not{n}, nil
+	}
+	return p.simple()
+}
+
+// simple: restriction | "(" expression ")"
+func (p *parser) simple() (node, error) {
+	if p.peek().kind == tokenLParen {
+		p.next()
+		n, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != tokenRParen {
+			return nil, fmt.Errorf("expected \")\" at position %d, got %q", t.pos, t.text)
+		}
+		return n, nil
+	}
+	return p.restriction()
+}
+
+// restriction: field comparator value
+func (p *parser) restriction() (node, error) {
+	name := p.next()
+	if name.kind != tokenText || name.text == "AND" || name.text == "OR" || name.text == "NOT" {
+		return nil, fmt.Errorf("expected a field at position %d, got %q", name.pos, name.text)
+	}
+	field, ok := p.schema[name.text]
+	if !ok {
+		return nil, fmt.Errorf("unknown field %q", name.text)
+	}
+
+	op := p.next()
+	if op.kind != tokenComparator {
+		return nil, fmt.Errorf("expected a comparator after %q, got %q", name.text, op.text)
+	}
+
+	value := p.next()
+	if value.kind != tokenText && value.kind != tokenString {
+		return nil, fmt.Errorf("expected a value for %q, got %q", name.text, value.text)
+	}
+
+	v, err := convert(name.text, field, op.text, value)
+	if err != nil {
+		return nil, err
+	}
+	return comparison{column: field.Column, op: op.text, value: v}, nil
+}
+
+func convert(name string, field Field, op string, value token) (interface{}, error) {
+	equality := op == "=" || op == "!="
+
+	switch field.Type {
+	case Bool:
+		if !equality {
+			return nil, fmt.Errorf("field %q only supports = and !=", name)
+		}
+		b, err := strconv.ParseBool(value.text)
+		if err != nil || value.kind != tokenText {
+			return nil, fmt.Errorf("field %q expects true or false, got %q", name, value.text)
+		}
+		return b, nil
+	case Number:
+		if _, err := strconv.ParseFloat(value.text, 64); err != nil || value.kind != tokenText {
+			return nil, fmt.Errorf("field %q expects a number, got %q", name, value.text)
+		}
+		// passed as text so postgres converts it to the type of the column without losing precision
+		return value.text, nil
+	case Time:
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02"} {
+			t, err := time.Parse(layout, value.text)
+			if err == nil {
+				return t, nil
+			}
+		}
+		return nil, fmt.Errorf("field %q expects an RFC 3339 timestamp or a date, got %q", name, value.text)
+	default:
+		if len(field.Values) > 0 {
+			if !equality {
+				return nil, fmt.Errorf("field %q only supports = and !=", name)
+			}
+			for _, allowed := range field.Values {
+				if value.text == allowed {
+					return value.text, nil
+				}
+			}
+			return nil, fmt.Errorf("field %q must be one of %s, got %q", name, strings.Join(field.Values, ", "), value.text)
+		}
+		return value.text, nil
+	}
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenText
+	tokenString
+	tokenComparator
+	tokenLParen
+	tokenRParen
+	tokenMinus
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func isTextChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '.' || c == '-' || c == ':' || c == '+'
+}
+
+func lex(in string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(in); {
+		c := in[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: i})
+			i++
+		case c == '=':
+			tokens = append(tokens, token{kind: tokenComparator, text: "=", pos: i})
+			i++
+		case c == '!' || c == '<' || c == '>':
+			if i+1 < len(in) && in[i+1] == '=' {
+				tokens = append(tokens, token{kind: tokenComparator, text: in[i : i+2], pos: i})
+				i += 2
+				continue
+			}
+			if c == '!' {
+				return nil, fmt.Errorf("unexpected \"!\" at position %d", i)
+			}
+			tokens = append(tokens, token{kind: tokenComparator, text: string(c), pos: i})
+			i++
+		case c == '"' || c == '\'':
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(in) && in[j] != c; j++ {
+				if in[j] == '\\' && j+1 < len(in) {
+					j++
+				}
+				sb.WriteByte(in[j])
+			}
+			if j >= len(in) {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: sb.String(), pos: i})
+			i = j + 1
+		case c == '-' && (i+1 >= len(in) || in[i+1] < '0' || in[i+1] > '9'):
+			// a minus that doesn't start a number negates the following term
+			tokens = append(tokens, token{kind: tokenMinus, text: "-", pos: i})
+			i++
+		case isTextChar(c):
+			j := i
+			for j < len(in) && isTextChar(in[j]) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenText, text: in[i:j], pos: i})
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected %q at position %d", c, i)
+		}
+	}
+	return tokens, nil
+}
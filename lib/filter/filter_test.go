@@ -0,0 +1,124 @@
+package filter
+
+import (
+	"testing"
+	"time"
+)
+
+var schema = Schema{
+	"billing_account_id": {Column: "billing_account_id", Type: String},
+	"create_time":        {Column: "create_time", Type: Time},
+	"demand_enabled":     {Column: "demand_enabled", Type: Bool},
+	"quantity":           {Column: "quantity", Type: Number},
+	"status":             {Column: "status", Type: String, Values: []string{"active", "complete"}},
+}
+
+func TestParse(t *testing.T) {
+	t.Run("should return nil for an empty filter", func(t *testing.T) {
+		f, err := Parse("  ", schema)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if f != nil {
+			t.Errorf("expected nil filter, got %v", f)
+		}
+	})
+	t.Run("should build parameterized sql", func(t *testing.T) {
+		f, err := Parse(`billing_account_id = "abc" AND create_time > "2024-01-01"`, schema)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		var args Args
+		sql := f.SQL(&args)
+		if sql != `("billing_account_id" = $1 AND "create_time" > $2)` {
+			t.Errorf("unexpected sql %s", sql)
+		}
+		if len(args) != 2 || args[0] != "abc" || !args[1].(time.Time).Equal(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("unexpected args %v", args)
+		}
+	})
+	t.Run("should bind OR tighter than AND", func(t *testing.T) {
+		f, err := Parse(`quantity >= 2 AND status = active OR status = complete`, schema)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		args := Args{"existing"}
+		sql := f.SQL(&args)
+		if sql != `("quantity" >= $2 AND ("status" = $3 OR "status" = $4))` {
+			t.Errorf("unexpected sql %s", sql)
+		}
+	})
+	t.Run("should support negation, parentheses and implicit AND", func(t *testing.T) {
+		f, err := Parse(`NOT (demand_enabled = true) -status = active quantity != 1`, schema)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		var args Args
+		sql := f.SQL(&args)
+		if sql != `(NOT "demand_enabled" = $1 AND NOT "status" = $2 AND "quantity" <> $3)` {
+			t.Errorf("unexpected sql %s", sql)
+		}
+	})
+	t.Run("should keep injected sql inside the argument", func(t *testing.T) {
+		f, err := Parse(`billing_account_id = "x' OR 1=1 --"`, schema)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		var args Args
+		sql := f.SQL(&args)
+		if sql != `"billing_account_id" = $1` || args[0] != "x' OR 1=1 --" {
+			t.Errorf("unexpected sql %s with args %v", sql, args)
+		}
+	})
+	t.Run("should reject invalid filters", func(t *testing.T) {
+		for _, in := range []string{
+			`unknown = "a"`,
+			`billing_account_id`,
+			`billing_account_id = `,
+			`status = deleted`,
+			`status > active`,
+			`demand_enabled = yes`,
+			`quantity = "many"`,
+			`create_time > "yesterday"`,
+			`(billing_account_id = "a"`,
+			`billing_account_id = "a" AND`,
+			`billing_account_id = "a`,
+			`billing_account_id ! "a"`,
+			`billing_account_id = "a" )`,
+		} {
+			_, err := Parse(in, schema)
+			if err == nil {
+				t.Errorf("expected error for %q, got nil", in)
+			}
+		}
+	})
+}
+
+func TestParseOrderBy(t *testing.T) {
+	t.Run("should return nil for an empty order", func(t *testing.T) {
+		o, err := ParseOrderBy("", schema)
+		if err != nil || o != nil {
+			t.Errorf("expected nil order and no error, got %v, %v", o, err)
+		}
+	})
+	t.Run("should build the order by list", func(t *testing.T) {
+		o, err := ParseOrderBy("create_time desc, quantity ASC,status", schema)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if o.SQL() != `"create_time" DESC, "quantity", "status"` {
+			t.Errorf("unexpected sql %s", o.SQL())
+		}
+		if !o.Has("quantity") || o.Has("id") {
+			t.Errorf("unexpected columns in %v", o)
+		}
+	})
+	t.Run("should reject invalid orders", func(t *testing.T) {
+		for _, in := range []string{"unknown", "create_time sideways", "create_time, create_time desc", "create_time desc extra", "create_time,"} {
+			_, err := ParseOrderBy(in, schema)
+			if err == nil {
+				t.Errorf("expected error for %q, got nil", in)
+			}
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+)
+
+type OrderField struct {
+	Column string
+	Desc   bool
+}
+
+// OrderBy is a parsed AIP-132 order_by such as "create_time desc, id"
+type OrderBy []OrderField
+
+// ParseOrderBy parses a comma separated list of fields, each optionally followed by asc or desc, and checks them
+// against schema. An empty order_by returns nil.
+func ParseOrderBy(orderBy string, schema Schema) (OrderBy, error) {
+	if strings.TrimSpace(orderBy) == "" {
+		return nil, nil
+	}
+
+	var res OrderBy
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(orderBy, ",") {
+		words := strings.Fields(part)
+		if len(words) == 0 || len(words) > 2 {
+			return nil, fmt.Errorf("invalid order_by %q", strings.TrimSpace(part))
+		}
+		field, ok := schema[words[0]]
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q", words[0])
+		}
+		if seen[words[0]] {
+			return nil, fmt.Errorf("field %q is ordered by more than once", words[0])
+		}
+		seen[words[0]] = true
+
+		orderField := OrderField{Column: field.Column}
+		if len(words) == 2 {
+			switch strings.ToLower(words[1]) {
+			case "asc":
+			case "desc":
+				orderField.Desc = true
+			default:
+				return nil, fmt.Errorf("invalid direction %q for field %q", words[1], words[0])
+			}
+		}
+		res = append(res, orderField)
+	}
+	return res, nil
+}
+
+// SQL returns the ORDER BY list without the keyword
+func (o OrderBy) SQL() string {
+	parts := make([]string, len(o))
+	for i, field := range o {
+		parts[i] = fmt.Sprintf("%q", field.Column)
+		if field.Desc {
+			parts[i] += " DESC"
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Has reports whether the column is already part of the ordering
+func (o OrderBy) Has(column string) bool {
+	for _, field := range o {
+		if field.Column == column {
+			return true
+		}
+	}
+	return false
+}
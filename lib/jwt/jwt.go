@@ -0,0 +1,180 @@
+// Package jwt verifies JSON Web Tokens signed with RS256 or ES256 by the keys of a JSON Web Key Set
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// defaultLeeway allows for clock skew between the issuer and us
+const defaultLeeway = time.Minute
+
+var (
+	ErrMalformed        = errors.New("malformed token")
+	ErrUnsupportedAlg   = errors.New("unsupported signing algorithm")
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrExpired          = errors.New("token has expired")
+	ErrNotValidYet      = errors.New("token is not valid yet")
+	ErrInvalidIssuer    = errors.New("invalid issuer")
+	ErrInvalidAudience  = errors.New("invalid audience")
+)
+
+// Claims are the claims of a token that are checked or used
+type Claims struct {
+	Issuer    string   `json:"iss"`
+	Subject   string   `json:"sub"`
+	Audience  Audience `json:"aud"`
+	ExpiresAt int64    `json:"exp"`
+	NotBefore int64    `json:"nbf"`
+	IssuedAt  int64    `json:"iat"`
+	// Admin is a private claim for principals that may act for the platform
+	Admin bool `json:"admin"`
+}
+
+// Audience is the aud claim, which may be a single string or an array of them
+type Audience []string
+
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if json.Unmarshal(data, &single) == nil {
+		*a = Audience{single}
+		return nil
+	}
+	var many []string
+	err := json.Unmarshal(data, &many)
+	if err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+func (a Audience) contains(audience string) bool {
+	for _, aud := range a {
+		if aud == audience {
+			return true
+		}
+	}
+	return false
+}
+
+// Verifier checks the signature, issuer, audience and lifetime of tokens
+type Verifier struct {
+	Audience string
+	Issuer   string
+	Keys     *KeySet
+	Leeway   time.Duration
+	now      func() time.Time
+}
+
+func NewVerifier(keys *KeySet, issuer string, audience string) *Verifier {
+	return &Verifier{
+		Audience: audience,
+		Issuer:   issuer,
+		Keys:     keys,
+		Leeway:   defaultLeeway,
+		now:      time.Now,
+	}
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Typ string `json:"typ"`
+}
+
+// Verify returns the claims of a token once it is found to be valid
+func (v *Verifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformed
+	}
+	var h header
+	err := decodeSegment(parts[0], &h)
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+
+	key, err := v.Keys.Key(ctx, h.Kid)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	err = verifySignature(h.Alg, key, digest[:], signature)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	err = decodeSegment(parts[1], &claims)
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	now := v.now()
+	if claims.ExpiresAt == 0 || !now.Before(time.Unix(claims.ExpiresAt, 0).Add(v.Leeway)) {
+		return nil, ErrExpired
+	}
+	if claims.NotBefore != 0 && now.Add(v.Leeway).Before(time.Unix(claims.NotBefore, 0)) {
+		return nil, ErrNotValidYet
+	}
+	if claims.Issuer != v.Issuer {
+		return nil, ErrInvalidIssuer
+	}
+	if !claims.Audience.contains(v.Audience) {
+		return nil, ErrInvalidAudience
+	}
+	return &claims, nil
+}
+
+// verifySignature checks the signature with the key, the algorithm must match the type of the key so a token can't
+// choose how it is verified
+func verifySignature(alg string, key crypto.PublicKey, digest []byte, signature []byte) error {
+	switch alg {
+	case "RS256":
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return ErrUnsupportedAlg
+		}
+		if rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, digest, signature) != nil {
+			return ErrInvalidSignature
+		}
+		return nil
+	case "ES256":
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return ErrUnsupportedAlg
+		}
+		// the signature is r and s as 32 byte big endian integers
+		if len(signature) != 64 {
+			return ErrInvalidSignature
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(ecKey, digest, r, s) {
+			return ErrInvalidSignature
+		}
+		return nil
+	}
+	return ErrUnsupportedAlg
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,195 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var (
+	rsaKey, _ = rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _  = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+)
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func testKeySet(t *testing.T) []byte {
+	t.Helper()
+	set := map[string]interface{}{
+		"keys": []map[string]string{
+			{"kty": "RSA", "kid": "rsa", "use": "sig", "n": b64(rsaKey.N.Bytes()), "e": b64(big.NewInt(int64(rsaKey.E)).Bytes())},
+			{"kty": "EC", "kid": "ec", "crv": "P-256", "x": b64(ecKey.X.FillBytes(make([]byte, 32))), "y": b64(ecKey.Y.FillBytes(make([]byte, 32)))},
+			{"kty": "oct", "kid": "ignored", "k": "c2VjcmV0"},
+		},
+	}
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func sign(t *testing.T, alg string, kid string, key crypto.PrivateKey, claims interface{}) string {
+	t.Helper()
+	h, _ := json.Marshal(header{Alg: alg, Kid: kid, Typ: "JWT"})
+	c, _ := json.Marshal(claims)
+	signingInput := b64(h) + "." + b64(c)
+	digest := sha256.Sum256([]byte(signingInput))
+	var signature []byte
+	var err error
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+	case *ecdsa.PrivateKey:
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, k, digest[:])
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signingInput + "." + b64(signature)
+}
+
+func testVerifier(t *testing.T) *Verifier {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	err := os.WriteFile(path, testKeySet(t), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewVerifier(NewKeySet(path), "https://issuer.example", "compute")
+}
+
+func validClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"iss": "https://issuer.example",
+		"sub": "user-1",
+		"aud": []string{"other", "compute"},
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func Test_Verify(t *testing.T) {
+	verifier := testVerifier(t)
+	with := func(key string, value interface{}) map[string]interface{} {
+		claims := validClaims()
+		claims[key] = value
+		return claims
+	}
+	tests := []struct {
+		name  string
+		token string
+		err   error
+	}{
+		{"should accept RS256 tokens", sign(t, "RS256", "rsa", rsaKey, validClaims()), nil},
+		{"should accept ES256 tokens", sign(t, "ES256", "ec", ecKey, validClaims()), nil},
+		{"should accept a single audience", sign(t, "RS256", "rsa", rsaKey, with("aud", "compute")), nil},
+		{"should reject expired tokens", sign(t, "RS256", "rsa", rsaKey, with("exp", time.Now().Add(-2*time.Minute).Unix())), ErrExpired},
+		{"should reject tokens without an expiry", sign(t, "RS256", "rsa", rsaKey, with("exp", 0)), ErrExpired},
+		{"should reject tokens that aren't valid yet", sign(t, "RS256", "rsa", rsaKey, with("nbf", time.Now().Add(time.Hour).Unix())), ErrNotValidYet},
+		{"should reject other issuers", sign(t, "RS256", "rsa", rsaKey, with("iss", "https://evil.example")), ErrInvalidIssuer},
+		{"should reject other audiences", sign(t, "RS256", "rsa", rsaKey, with("aud", "billing")), ErrInvalidAudience},
+		{"should reject unknown keys", sign(t, "RS256", "other", rsaKey, validClaims()), ErrUnknownKey},
+		{"should reject an algorithm that doesn't match the key", sign(t, "ES256", "rsa", ecKey, validClaims()), ErrUnsupportedAlg},
+		{"should reject unsupported algorithms", sign(t, "HS256", "rsa", rsaKey, validClaims()), ErrUnsupportedAlg},
+		{"should reject malformed tokens", "abc.def", ErrMalformed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := verifier.Verify(context.Background(), tt.token)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected: %v, got: %v", tt.err, err)
+			}
+			if err == nil && claims.Subject != "user-1" {
+				t.Errorf("expected: %s, got: %s", "user-1", claims.Subject)
+			}
+		})
+	}
+	t.Run("should reject tokens whose claims were changed", func(t *testing.T) {
+		token := sign(t, "RS256", "rsa", rsaKey, validClaims())
+		parts := strings.Split(token, ".")
+		claims, _ := json.Marshal(with("sub", "admin"))
+		_, err := verifier.Verify(context.Background(), parts[0]+"."+b64(claims)+"."+parts[2])
+		if !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("expected: %v, got: %v", ErrInvalidSignature, err)
+		}
+	})
+}
+
+func Test_KeySet(t *testing.T) {
+	t.Run("should load keys from a url", func(t *testing.T) {
+		data := testKeySet(t)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(data)
+		}))
+		defer srv.Close()
+		keys := NewKeySet(srv.URL)
+		key, err := keys.Key(context.Background(), "ec")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if _, ok := key.(*ecdsa.PublicKey); !ok {
+			t.Errorf("expected an ecdsa key, got: %T", key)
+		}
+	})
+	t.Run("should report a key set that can't be loaded as unavailable", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+		_, err := NewKeySet(srv.URL).Key(context.Background(), "ec")
+		if !errors.Is(err, ErrKeySetUnavailable) {
+			t.Errorf("expected: %v, got: %v", ErrKeySetUnavailable, err)
+		}
+	})
+	t.Run("should keep serving cached keys while they're refreshed", func(t *testing.T) {
+		data := testKeySet(t)
+		release := make(chan struct{})
+		var requests int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&requests, 1) > 1 {
+				<-release
+			}
+			_, _ = w.Write(data)
+		}))
+		defer srv.Close()
+		defer close(release)
+		keys := NewKeySet(srv.URL)
+		_, err := keys.Key(context.Background(), "ec")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		keys.mu.Lock()
+		keys.fetched = keys.fetched.Add(-2 * refreshInterval)
+		keys.attempted = keys.attempted.Add(-2 * refreshInterval)
+		keys.mu.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		for i := 0; i < 3; i++ {
+			_, err = keys.Key(ctx, "ec")
+			if err != nil {
+				t.Fatalf("expected the cached key while the refresh runs, got: %v", err)
+			}
+		}
+	})
+}
@@ -0,0 +1,261 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// keys loaded from a url are fetched again after refreshInterval, or sooner when a token is signed by a key that
+	// isn't known yet but no more often than minRefreshInterval
+	refreshInterval    = 15 * time.Minute
+	minRefreshInterval = time.Minute
+	maxKeySetSize      = 1 << 20
+)
+
+var (
+	ErrUnknownKey = errors.New("unknown signing key")
+	// ErrKeySetUnavailable means the keys couldn't be loaded, tokens may be valid but can't be verified
+	ErrKeySetUnavailable = errors.New("key set unavailable")
+)
+
+// KeySet is a JSON Web Key Set loaded from a file or a url
+type KeySet struct {
+	// attempted is when the keys were last loaded, successfully or not
+	attempted time.Time
+	client    *http.Client
+	fetched   time.Time
+	keys      map[string]crypto.PublicKey
+	// loads makes concurrent callers share a single load of the keys
+	loads singleflight.Group
+	// mu guards attempted, fetched and keys, it isn't held while the keys load so a slow source doesn't hold up
+	// tokens signed by keys that are already known
+	mu     sync.Mutex
+	source string
+}
+
+// NewKeySet loads keys from source, which is read from a url when it starts with http:// or https:// and from a file
+// otherwise
+func NewKeySet(source string) *KeySet {
+	return &KeySet{
+		client: &http.Client{Timeout: 10 * time.Second},
+		source: source,
+	}
+}
+
+func (k *KeySet) fromURL() bool {
+	return strings.HasPrefix(k.source, "https://") || strings.HasPrefix(k.source, "http://")
+}
+
+// Key returns the key with the id, a key set with a single key is used for tokens without a key id. Stale keys are
+// still used while they're refreshed, only a caller with no key to use waits for the load.
+func (k *KeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	k.mu.Lock()
+	keys := k.keys
+	stale := keys == nil || (k.fromURL() && time.Since(k.fetched) > refreshInterval)
+	k.mu.Unlock()
+
+	if stale && k.canLoad() {
+		loaded := k.refresh()
+		if keys == nil {
+			err := wait(ctx, loaded)
+			if err != nil {
+				return nil, err
+			}
+			keys = k.current()
+		}
+	}
+	if keys == nil {
+		return nil, ErrKeySetUnavailable
+	}
+	key, ok := find(keys, kid)
+	if !ok && k.fromURL() && k.canLoad() {
+		// the keys may have been rotated
+		err := wait(ctx, k.refresh())
+		if err != nil {
+			return nil, err
+		}
+		key, ok = find(k.current(), kid)
+	}
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return key, nil
+}
+
+func (k *KeySet) current() map[string]crypto.PublicKey {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.keys
+}
+
+// canLoad reports whether the last load was long enough ago to load the keys again, a load that is still running
+// hasn't been attempted yet so callers join it
+func (k *KeySet) canLoad() bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return time.Since(k.attempted) > minRefreshInterval
+}
+
+// refresh loads the keys unless a load is already running, the load isn't tied to the context of any one caller and
+// is bounded by the client timeout instead
+func (k *KeySet) refresh() <-chan singleflight.Result {
+	return k.loads.DoChan("", func() (interface{}, error) {
+		return nil, k.load(context.Background())
+	})
+}
+
+func wait(ctx context.Context, loaded <-chan singleflight.Result) error {
+	select {
+	case res := <-loaded:
+		return res.Err
+	case <-ctx.Done():
+		return fmt.Errorf("%w: %v", ErrKeySetUnavailable, ctx.Err())
+	}
+}
+
+func find(keys map[string]crypto.PublicKey, kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, true
+		}
+	}
+	key, ok := keys[kid]
+	return key, ok
+}
+
+func (k *KeySet) load(ctx context.Context) error {
+	var data []byte
+	var err error
+	if k.fromURL() {
+		data, err = k.fetch(ctx)
+	} else {
+		data, err = os.ReadFile(k.source)
+	}
+	var keys map[string]crypto.PublicKey
+	if err == nil {
+		keys, err = ParseKeySet(data)
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.attempted = time.Now()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrKeySetUnavailable, err)
+	}
+	k.keys = keys
+	k.fetched = time.Now()
+	return nil
+}
+
+func (k *KeySet) fetch(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, k.source, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := k.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", res.Status)
+	}
+	return io.ReadAll(io.LimitReader(res.Body, maxKeySetSize))
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// ParseKeySet returns the RSA and P-256 signing keys of a JSON Web Key Set by their key id, other keys are ignored
+func ParseKeySet(data []byte) (map[string]crypto.PublicKey, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	err := json.Unmarshal(data, &set)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key set: %w", err)
+	}
+	keys := map[string]crypto.PublicKey{}
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		var key crypto.PublicKey
+		switch jwk.Kty {
+		case "RSA":
+			key, err = parseRSAKey(jwk)
+		case "EC":
+			if jwk.Crv != "P-256" {
+				continue
+			}
+			key, err = parseECKey(jwk)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q: %w", jwk.Kid, err)
+		}
+		keys[jwk.Kid] = key
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("key set has no signing keys")
+	}
+	return keys, nil
+}
+
+func parseRSAKey(jwk jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return nil, err
+	}
+	exponent := new(big.Int).SetBytes(e)
+	if len(n) < 256 || !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+		return nil, errors.New("unsupported rsa key")
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+}
+
+func parseECKey(jwk jsonWebKey) (*ecdsa.PublicKey, error) {
+	x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+	if err != nil {
+		return nil, err
+	}
+	y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+	if err != nil {
+		return nil, err
+	}
+	key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+	if !key.Curve.IsOnCurve(key.X, key.Y) {
+		return nil, errors.New("point is not on the curve")
+	}
+	return key, nil
+}
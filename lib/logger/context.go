@@ -0,0 +1,22 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type contextKey struct{}
+
+// NewContext returns a context carrying log, for logging with the fields of the request or task being handled
+func NewContext(ctx context.Context, log *zap.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, log)
+}
+
+// FromContext returns the logger of the request or task the context belongs to, or fallback when there isn't one
+func FromContext(ctx context.Context, fallback *zap.Logger) *zap.Logger {
+	if log, ok := ctx.Value(contextKey{}).(*zap.Logger); ok && log != nil {
+		return log
+	}
+	return fallback
+}
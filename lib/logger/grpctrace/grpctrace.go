@@ -6,11 +6,20 @@ import (
 	"time"
 
 	"biller/lib/errorhandler"
+	"biller/lib/logger"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 )
 
+// requestLogger returns the logger of the request, which already has the method, or log with the method
+func requestLogger(ctx context.Context, log *zap.Logger, method string) (*zap.Logger, []zap.Field) {
+	if reqLog := logger.FromContext(ctx, nil); reqLog != nil {
+		return reqLog, nil
+	}
+	return log, []zap.Field{zap.String("method", method)}
+}
+
 // UnaryInterceptor wraps a unary GRPC handler to log calls and any resulting server errors
 func UnaryInterceptor(log *zap.Logger) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
@@ -18,10 +27,8 @@ func UnaryInterceptor(log *zap.Logger) grpc.UnaryServerInterceptor {
 		res, err := handler(ctx, req)
 		timeTaken := time.Since(start)
 
-		fields := []zap.Field{
-			zap.String("method", info.FullMethod),
-			zap.Duration("latency", timeTaken),
-		}
+		log, fields := requestLogger(ctx, log, info.FullMethod)
+		fields = append(fields, zap.Duration("latency", timeTaken))
 
 		if err != nil {
 			fields = append(fields, zap.Error(err))
@@ -37,3 +44,32 @@ func UnaryInterceptor(log *zap.Logger) grpc.UnaryServerInterceptor {
 	}
 
 }
+
+// StreamInterceptor wraps a streaming GRPC handler to log calls and any resulting server errors, the latency is the
+// time the stream was open for
+func StreamInterceptor(log *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		timeTaken := time.Since(start)
+
+		log, fields := requestLogger(ss.Context(), log, info.FullMethod)
+		fields = append(fields,
+			zap.Duration("latency", timeTaken),
+			zap.Bool("client_stream", info.IsClientStream),
+			zap.Bool("server_stream", info.IsServerStream),
+		)
+
+		if err != nil {
+			fields = append(fields, zap.Error(err))
+			if !errorhandler.IsClientError(err) {
+				log.Error("stream error", fields...)
+				return err
+			}
+		}
+
+		log.Debug("stream closed", fields...)
+
+		return err
+	}
+}
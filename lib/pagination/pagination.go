@@ -0,0 +1,139 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Page tokens are opaque to callers, they hold the (create_time, id) of the last item on the previous page and are
+// signed together with a fingerprint of the request so they can't be altered or replayed against a different listing.
+// Listings with a caller chosen order can't seek on (create_time, id) so their tokens hold an offset instead.
+
+const (
+	MinPageSize = 10
+	MaxPageSize = 100
+)
+
+var ErrInvalidToken = errors.New("invalid page token")
+
+var (
+	keyMu sync.RWMutex
+	key   = randomKey()
+)
+
+func randomKey() []byte {
+	k := make([]byte, 32)
+	_, err := rand.Read(k)
+	if err != nil {
+		panic(err)
+	}
+	return k
+}
+
+// SetKey sets the key used to sign page tokens. All instances serving the same API must share a key, otherwise a
+// token issued by one will be rejected by another. Without a key tokens are signed with a random per-process key.
+func SetKey(k []byte) {
+	keyMu.Lock()
+	defer keyMu.Unlock()
+	key = k
+}
+
+// Cursor is the position of the last item of a page in a listing ordered by (create_time, id), or the number of
+// items already returned when the listing has a different order
+type Cursor struct {
+	CreateTime time.Time
+	ID         string
+	Offset     int32
+}
+
+// Next returns the cursor for the page after one ending with the item (createTime, id)
+func (c Cursor) Next(keyset bool, createTime time.Time, id string, pageSize int32) Cursor {
+	if keyset {
+		return Cursor{CreateTime: createTime, ID: id}
+	}
+	return Cursor{Offset: c.Offset + pageSize}
+}
+
+// Fingerprint joins everything that identifies a listing request apart from the page
+func Fingerprint(parts ...string) string {
+	return strings.Join(parts, "\x00")
+}
+
+type tokenPayload struct {
+	CreateTime int64  `json:"t,omitempty"`
+	ID         string `json:"i,omitempty"`
+	Offset     int32  `json:"o,omitempty"`
+}
+
+// PageSize clamps the requested page size into the range every list RPC accepts
+func PageSize(size int32) int32 {
+	if size > MaxPageSize {
+		return MaxPageSize
+	}
+	if size < MinPageSize {
+		return MinPageSize
+	}
+	return size
+}
+
+func sign(payload []byte, fingerprint string) []byte {
+	keyMu.RLock()
+	defer keyMu.RUnlock()
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(fingerprint))
+	mac.Write([]byte{0})
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// Encode returns the token for the page that follows cursor
+func Encode(cursor Cursor, fingerprint string) string {
+	p := tokenPayload{ID: cursor.ID, Offset: cursor.Offset}
+	if !cursor.CreateTime.IsZero() {
+		p.CreateTime = cursor.CreateTime.UnixNano()
+	}
+	payload, _ := json.Marshal(p)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(sign(payload, fingerprint))
+}
+
+// Decode verifies a token issued by Encode for the same fingerprint and returns its cursor.
+// An empty token is the first page and decodes to the zero cursor.
+func Decode(token string, fingerprint string) (Cursor, error) {
+	if token == "" {
+		return Cursor{}, nil
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return Cursor{}, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return Cursor{}, ErrInvalidToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return Cursor{}, ErrInvalidToken
+	}
+	if !hmac.Equal(signature, sign(payload, fingerprint)) {
+		return Cursor{}, ErrInvalidToken
+	}
+
+	var p tokenPayload
+	err = json.Unmarshal(payload, &p)
+	if err != nil || p.Offset < 0 {
+		return Cursor{}, ErrInvalidToken
+	}
+	res := Cursor{ID: p.ID, Offset: p.Offset}
+	if p.CreateTime != 0 {
+		res.CreateTime = time.Unix(0, p.CreateTime).UTC()
+	}
+	return res, nil
+}
@@ -0,0 +1,74 @@
+package pagination
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPageSize(t *testing.T) {
+	t.Run("should clamp the page size", func(t *testing.T) {
+		if PageSize(0) != MinPageSize {
+			t.Errorf("expected %d, got %d", MinPageSize, PageSize(0))
+		}
+		if PageSize(25) != 25 {
+			t.Errorf("expected %d, got %d", 25, PageSize(25))
+		}
+		if PageSize(1000) != MaxPageSize {
+			t.Errorf("expected %d, got %d", MaxPageSize, PageSize(1000))
+		}
+	})
+}
+
+func TestToken(t *testing.T) {
+	cursor := Cursor{
+		CreateTime: time.Date(2022, time.January, 21, 10, 30, 0, 123, time.UTC),
+		ID:         "project-1",
+	}
+
+	t.Run("should decode an empty token to the first page", func(t *testing.T) {
+		res, err := Decode("", "ListProjects/")
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if res != (Cursor{}) {
+			t.Errorf("expected zero cursor, got %+v", res)
+		}
+	})
+	t.Run("should decode the cursor it encoded", func(t *testing.T) {
+		res, err := Decode(Encode(cursor, "ListProjects/"), "ListProjects/")
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if !res.CreateTime.Equal(cursor.CreateTime) || res.ID != cursor.ID {
+			t.Errorf("expected %+v, got %+v", cursor, res)
+		}
+	})
+	t.Run("should reject a token issued for a different request", func(t *testing.T) {
+		_, err := Decode(Encode(cursor, "ListProjects/user-1"), "ListProjects/user-2")
+		if err != ErrInvalidToken {
+			t.Errorf("expected %v, got %v", ErrInvalidToken, err)
+		}
+	})
+	t.Run("should reject a tampered token", func(t *testing.T) {
+		token := Encode(cursor, "ListProjects/")
+		other := Encode(Cursor{CreateTime: cursor.CreateTime, ID: "project-2"}, "ListProjects/")
+		tampered := other[:len(other)-len(token[len(token)-43:])] + token[len(token)-43:]
+		_, err := Decode(tampered, "ListProjects/")
+		if err != ErrInvalidToken {
+			t.Errorf("expected %v, got %v", ErrInvalidToken, err)
+		}
+		_, err = Decode("not-a-token", "ListProjects/")
+		if err != ErrInvalidToken {
+			t.Errorf("expected %v, got %v", ErrInvalidToken, err)
+		}
+	})
+	t.Run("should reject tokens signed with another key", func(t *testing.T) {
+		token := Encode(cursor, "ListProjects/")
+		SetKey([]byte("another-key"))
+		defer SetKey(randomKey())
+		_, err := Decode(token, "ListProjects/")
+		if err != ErrInvalidToken {
+			t.Errorf("expected %v, got %v", ErrInvalidToken, err)
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// keepalives make postgresql notice a client that's gone within about 30 seconds rather than the hours the operating
+// system defaults to, ending its session and releasing its locks
+const keepalives = `SET tcp_keepalives_idle = 10; SET tcp_keepalives_interval = 5; SET tcp_keepalives_count = 3`
+
+// AdvisoryLock is a postgresql session advisory lock shared by every process using the same name. It's held on a
+// connection of its own, outside the pool, so it stays held between calls and is released as soon as the session ends,
+// whether the holder unlocks it, exits or can no longer be reached.
+type AdvisoryLock struct {
+	config *pgx.ConnConfig
+	name   string
+
+	mu   sync.Mutex
+	conn *pgx.Conn
+}
+
+// NewAdvisoryLock returns a lock named name on the database pool connects to. Names share a key space with the
+// transaction locks of the service, so they should be prefixed with what they lock.
+func NewAdvisoryLock(pool *pgxpool.Pool, name string) *AdvisoryLock {
+	return &AdvisoryLock{
+		config: pool.Config().ConnConfig.Copy(),
+		name:   name,
+	}
+}
+
+// TryLock takes the lock without waiting and returns whether it's held, it returns true again while it's still held
+func (l *AdvisoryLock) TryLock(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn != nil {
+		// nothing else releases a session lock, it's held while the session is alive
+		err := l.conn.Ping(ctx)
+		if err == nil {
+			return true, nil
+		}
+		l.close()
+	}
+
+	conn, err := pgx.ConnectConfig(ctx, l.config)
+	if err != nil {
+		return false, fmt.Errorf("failed to connect to postgresql: %w", err)
+	}
+	_, err = conn.Exec(ctx, keepalives)
+	if err != nil {
+		conn.Close(context.Background())
+		return false, fmt.Errorf("failed to set keepalives: %w", err)
+	}
+	var locked bool
+	err = conn.QueryRow(ctx, `SELECT pg_try_advisory_lock(hashtext($1))`, l.name).Scan(&locked)
+	if err != nil || !locked {
+		conn.Close(context.Background())
+		if err != nil {
+			return false, fmt.Errorf("failed to take lock %s: %w", l.name, err)
+		}
+		return false, nil
+	}
+	l.conn = conn
+	return true, nil
+}
+
+// Unlock releases the lock if it's held
+func (l *AdvisoryLock) Unlock(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		return nil
+	}
+	// ending the session releases the lock even when unlocking fails
+	defer l.close()
+	_, err := l.conn.Exec(ctx, `SELECT pg_advisory_unlock(hashtext($1))`, l.name)
+	if err != nil {
+		return fmt.Errorf("failed to release lock %s: %w", l.name, err)
+	}
+	return nil
+}
+
+func (l *AdvisoryLock) close() {
+	_ = l.conn.Close(context.Background())
+	l.conn = nil
+}
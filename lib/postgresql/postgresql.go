@@ -6,6 +6,8 @@ import (
 	"crypto/x509"
 	"fmt"
 
+	"biller/lib/logger"
+
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/prometheus/client_golang/prometheus"
@@ -22,7 +24,9 @@ type ClientConfig struct {
 	ClientKey  string
 	ClientCert string
 	CA         string
-	Logger     *zap.Logger
+	// LazyConnect doesn't connect until a connection is needed, so the client can be made while the database is down
+	LazyConnect bool
+	Logger      *zap.Logger
 }
 
 func (c *ClientConfig) SSLEnabled() bool {
@@ -84,6 +88,7 @@ func NewClient(ctx context.Context, cfg *ClientConfig, registry prometheus.Regis
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse pgx config: %w", err)
 	}
+	config.LazyConnect = cfg.LazyConnect
 	if cfg.SSLEnabled() {
 		rootCertPool := x509.NewCertPool()
 		if ok := rootCertPool.AppendCertsFromPEM([]byte(cfg.CA)); !ok {
@@ -119,6 +124,11 @@ type wrappedLogger struct {
 
 // Log copied from pgx / log / zapadapter but the info level is altered to output as debug.
 func (pl *wrappedLogger) Log(ctx context.Context, level pgx.LogLevel, msg string, data map[string]interface{}) {
+	log := pl.logger
+	if reqLog := logger.FromContext(ctx, nil); reqLog != nil {
+		log = reqLog.WithOptions(zap.AddCallerSkip(1))
+	}
+
 	fields := make([]zapcore.Field, len(data))
 	i := 0
 	for k, v := range data {
@@ -128,17 +138,17 @@ func (pl *wrappedLogger) Log(ctx context.Context, level pgx.LogLevel, msg string
 
 	switch level {
 	case pgx.LogLevelTrace:
-		pl.logger.Debug(msg, append(fields, zap.Stringer("PGX_LOG_LEVEL", level))...)
+		log.Debug(msg, append(fields, zap.Stringer("PGX_LOG_LEVEL", level))...)
 	case pgx.LogLevelDebug:
-		pl.logger.Debug(msg, fields...)
+		log.Debug(msg, fields...)
 	case pgx.LogLevelInfo:
-		pl.logger.Debug(msg, fields...)
+		log.Debug(msg, fields...)
 	case pgx.LogLevelWarn:
-		pl.logger.Warn(msg, fields...)
+		log.Warn(msg, fields...)
 	case pgx.LogLevelError:
-		pl.logger.Error(msg, fields...)
+		log.Error(msg, fields...)
 	default:
-		pl.logger.Error(msg, append(fields, zap.Stringer("PGX_LOG_LEVEL", level))...)
+		log.Error(msg, append(fields, zap.Stringer("PGX_LOG_LEVEL", level))...)
 	}
 }
 
@@ -0,0 +1,123 @@
+package postgresql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "biller/lib/postgresql"
+
+// sqlc starts every query with a comment naming it
+const queryNamePrefix = "-- name: "
+
+// DBTX is what sqlc generated queries run on, a pool, a connection or a transaction
+type DBTX interface {
+	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+	QueryRow(context.Context, string, ...interface{}) pgx.Row
+}
+
+type tracedDB struct {
+	db     DBTX
+	tracer trace.Tracer
+}
+
+// Trace starts a span for each query run on db named by the sqlc query name, spans of queries returning rows end
+// when the rows are closed
+func Trace(db DBTX) DBTX {
+	return tracedDB{db: db, tracer: otel.Tracer(tracerName)}
+}
+
+func (t tracedDB) start(ctx context.Context, sql string) (context.Context, trace.Span) {
+	name := queryName(sql)
+	return t.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperationKey.String(name),
+			semconv.DBStatementKey.String(sql),
+		),
+	)
+}
+
+func (t tracedDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	ctx, span := t.start(ctx, sql)
+	defer span.End()
+	tag, err := t.db.Exec(ctx, sql, args...)
+	setError(span, err)
+	if err == nil {
+		span.SetAttributes(attribute.Int64("db.rows_affected", tag.RowsAffected()))
+	}
+	return tag, err
+}
+
+func (t tracedDB) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	ctx, span := t.start(ctx, sql)
+	rows, err := t.db.Query(ctx, sql, args...)
+	if err != nil {
+		setError(span, err)
+		span.End()
+		return rows, err
+	}
+	return &tracedRows{Rows: rows, span: span}, nil
+}
+
+func (t tracedDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	ctx, span := t.start(ctx, sql)
+	return tracedRow{row: t.db.QueryRow(ctx, sql, args...), span: span}
+}
+
+type tracedRows struct {
+	pgx.Rows
+	span trace.Span
+}
+
+func (r *tracedRows) Close() {
+	r.Rows.Close()
+	if r.span.IsRecording() {
+		setError(r.span, r.Rows.Err())
+		r.span.End()
+	}
+}
+
+type tracedRow struct {
+	row  pgx.Row
+	span trace.Span
+}
+
+func (r tracedRow) Scan(dest ...interface{}) error {
+	err := r.row.Scan(dest...)
+	// no rows is an expected outcome of a lookup, not a failed query
+	if err != pgx.ErrNoRows {
+		setError(r.span, err)
+	}
+	r.span.End()
+	return err
+}
+
+func setError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// queryName returns the name sqlc gave a query, or "query" for hand written queries
+func queryName(sql string) string {
+	if !strings.HasPrefix(sql, queryNamePrefix) {
+		return "query"
+	}
+	name := sql[len(queryNamePrefix):]
+	if i := strings.IndexAny(name, " \n"); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
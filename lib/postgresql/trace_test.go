@@ -0,0 +1,84 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type fakeDB struct {
+	err error
+}
+
+func (f fakeDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag("UPDATE 1"), f.err
+}
+
+func (f fakeDB) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return nil, f.err
+}
+
+func (f fakeDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return fakeRow{err: f.err}
+}
+
+type fakeRow struct {
+	err error
+}
+
+func (r fakeRow) Scan(dest ...interface{}) error {
+	return r.err
+}
+
+func Test_queryName(t *testing.T) {
+	tests := []struct {
+		sql  string
+		name string
+	}{
+		{"-- name: GetProject :one\nSELECT 1", "GetProject"},
+		{"-- name: DeleteProject :exec", "DeleteProject"},
+		{"SELECT 1", "query"},
+	}
+	for _, tt := range tests {
+		if name := queryName(tt.sql); name != tt.name {
+			t.Errorf("expected: %s, got: %s", tt.name, name)
+		}
+	}
+}
+
+func Test_Trace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	traced := func(err error) DBTX {
+		return tracedDB{db: fakeDB{err: err}, tracer: provider.Tracer(tracerName)}
+	}
+	ctx := context.Background()
+
+	_, _ = traced(nil).Exec(ctx, "-- name: UpdateProject :exec\nUPDATE project")
+	_ = traced(pgx.ErrNoRows).QueryRow(ctx, "-- name: GetProject :one\nSELECT 1").Scan()
+	_ = traced(errors.New("broken")).QueryRow(ctx, "-- name: GetOrder :one\nSELECT 1").Scan()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("expected: %d spans, got: %d", 3, len(spans))
+	}
+	expected := []struct {
+		name string
+		code codes.Code
+	}{
+		{"UpdateProject", codes.Unset},
+		{"GetProject", codes.Unset},
+		{"GetOrder", codes.Error},
+	}
+	for i, e := range expected {
+		if spans[i].Name() != e.name || spans[i].Status().Code != e.code {
+			t.Errorf("expected span %s with status %s, got: %s with %s", e.name, e.code, spans[i].Name(), spans[i].Status().Code)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package principal
+
+import (
+	"context"
+)
+
+// Principal is the caller of a request, what it has access to is given by its billing account memberships
+type Principal struct {
+	ID string
+	// Admin principals may make decisions on behalf of the platform, e.g. enabling billing accounts, and have access
+	// to every billing account
+	Admin bool
+	// BillingAccountID is set for principals restricted to a single billing account, such as api keys, which have the
+	// permissions of Role on it rather than those of their memberships
+	BillingAccountID string
+	Role             string
+}
+
+type contextKey struct{}
+
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, p)
+}
+
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(contextKey{}).(*Principal)
+	return p, ok && p != nil
+}
+
+// IsAdmin reports whether the request may use admin RPCs, requests not made on behalf of a principal never may
+func IsAdmin(ctx context.Context) bool {
+	p, ok := FromContext(ctx)
+	return ok && p.Admin
+}
+
+// Actor names who made a request for recording decisions, "system" for internal requests
+func Actor(ctx context.Context) string {
+	p, ok := FromContext(ctx)
+	if !ok {
+		return "system"
+	}
+	return p.ID
+}
+
+// Fingerprint identifies the principal for tying state such as page tokens to the caller
+func Fingerprint(ctx context.Context) string {
+	p, ok := FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	return p.ID
+}
@@ -0,0 +1,30 @@
+package principal
+
+import (
+	"context"
+	"testing"
+)
+
+func Test_IsAdmin(t *testing.T) {
+	t.Run("should not be admin without a principal", func(t *testing.T) {
+		if IsAdmin(context.Background()) {
+			t.Fatal("expected a request without a principal not to be admin")
+		}
+	})
+
+	t.Run("should not be admin for a principal that isn't", func(t *testing.T) {
+		if IsAdmin(NewContext(context.Background(), &Principal{ID: "user"})) {
+			t.Fatal("expected a user not to be admin")
+		}
+	})
+
+	t.Run("should be admin for an admin principal", func(t *testing.T) {
+		ctx := NewContext(context.Background(), &Principal{ID: "admin", Admin: true})
+		if !IsAdmin(ctx) {
+			t.Fatal("expected the principal to be admin")
+		}
+		if Actor(ctx) != "admin" {
+			t.Fatalf("expected actor admin, got %s", Actor(ctx))
+		}
+	})
+}
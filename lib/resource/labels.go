@@ -0,0 +1,34 @@
+package resource
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// MaxLabels is the most labels a single resource can carry
+const MaxLabels = 64
+
+var labelKeyRegexp = regexp.MustCompile("^[a-z][a-z0-9_-]{0,62}$")
+
+var labelValueRegexp = regexp.MustCompile("^[a-z0-9_-]{0,63}$")
+
+func ValidLabelKey(in string) bool {
+	return labelKeyRegexp.MatchString(in)
+}
+
+// ValidateLabels checks that label keys start with a lowercase letter and that keys and values only contain
+// lowercase letters, digits, underscores and dashes
+func ValidateLabels(labels map[string]string) error {
+	if len(labels) > MaxLabels {
+		return fmt.Errorf("at most %d labels are allowed", MaxLabels)
+	}
+	for key, value := range labels {
+		if !ValidLabelKey(key) {
+			return fmt.Errorf("invalid label key %q", key)
+		}
+		if !labelValueRegexp.MatchString(value) {
+			return fmt.Errorf("invalid value for label %q", key)
+		}
+	}
+	return nil
+}
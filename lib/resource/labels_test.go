@@ -0,0 +1,37 @@
+package resource
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestValidateLabels(t *testing.T) {
+	t.Run("should accept valid labels", func(t *testing.T) {
+		err := ValidateLabels(map[string]string{"team": "billing", "environment": "prod-1", "cost_center": ""})
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+	t.Run("should reject invalid labels", func(t *testing.T) {
+		for _, labels := range []map[string]string{
+			{"": "value"},
+			{"Team": "billing"},
+			{"1team": "billing"},
+			{"team": "Billing"},
+			{"team": "billing team"},
+		} {
+			if err := ValidateLabels(labels); err == nil {
+				t.Errorf("expected error for %v, got nil", labels)
+			}
+		}
+	})
+	t.Run("should reject too many labels", func(t *testing.T) {
+		labels := make(map[string]string)
+		for i := 0; i <= MaxLabels; i++ {
+			labels[fmt.Sprintf("key-%d", i)] = "value"
+		}
+		if err := ValidateLabels(labels); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
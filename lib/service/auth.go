@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"biller/lib/principal"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// APIKeyHeader is the metadata key, and http header through the gateway, api keys are sent in
+const APIKeyHeader = "x-api-key"
+
+// ErrNoCredentials is returned by an Authenticator when a request has none of the credentials it checks
+var ErrNoCredentials = errors.New("no credentials")
+
+// Authenticator finds the principal making a request from the credentials in its metadata
+type Authenticator interface {
+	Authenticate(ctx context.Context, md metadata.MD) (*principal.Principal, error)
+}
+
+// APIKeyVerifier returns the principal an api key belongs to
+type APIKeyVerifier interface {
+	VerifyAPIKey(ctx context.Context, key string) (*principal.Principal, error)
+}
+
+type apiKeyAuthenticator struct {
+	verifier APIKeyVerifier
+}
+
+// NewAPIKeyAuthenticator authenticates requests by the api key sent directly in metadata or forwarded by the gateway
+func NewAPIKeyAuthenticator(verifier APIKeyVerifier) Authenticator {
+	return apiKeyAuthenticator{verifier: verifier}
+}
+
+func (a apiKeyAuthenticator) Authenticate(ctx context.Context, md metadata.MD) (*principal.Principal, error) {
+	key := firstMetadataValue(md, APIKeyHeader, runtime.MetadataPrefix+APIKeyHeader)
+	if key == "" {
+		return nil, ErrNoCredentials
+	}
+	return a.verifier.VerifyAPIKey(ctx, key)
+}
+
+func firstMetadataValue(md metadata.MD, keys ...string) string {
+	for _, key := range keys {
+		if values := md.Get(key); len(values) > 0 && values[0] != "" {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// defaultPublicMethods can be called without credentials so the service can be health checked and debugged
+var defaultPublicMethods = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.",
+	"/org.cudo.v1.VersionService/",
+}
+
+// AuthUnaryInterceptor puts the principal found by the first authenticator the request has credentials for into the
+// context. Requests without credentials are rejected unless their method starts with one of publicMethods.
+func AuthUnaryInterceptor(authenticators []Authenticator, publicMethods []string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, info.FullMethod, authenticators, publicMethods)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AuthStreamInterceptor is AuthUnaryInterceptor for streams
+func AuthStreamInterceptor(authenticators []Authenticator, publicMethods []string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), info.FullMethod, authenticators, publicMethods)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func authenticate(ctx context.Context, method string, authenticators []Authenticator, publicMethods []string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, authenticator := range authenticators {
+		p, err := authenticator.Authenticate(ctx, md)
+		if err == ErrNoCredentials {
+			continue
+		}
+		if err != nil {
+			// a failure to check the credentials isn't the caller's fault
+			if code := status.Code(err); code == codes.Internal || code == codes.Unavailable {
+				return ctx, err
+			}
+			return ctx, status.Error(codes.Unauthenticated, "invalid credentials")
+		}
+		return principal.NewContext(ctx, p), nil
+	}
+	for _, prefix := range publicMethods {
+		if strings.HasPrefix(method, prefix) {
+			return ctx, nil
+		}
+	}
+	return ctx, status.Error(codes.Unauthenticated, "credentials are required")
+}
+
+// contextServerStream replaces the context of a stream
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// incomingHeaderMatcher forwards the credential and request id headers to the grpc server along with the headers
+// grpc-gateway forwards by default
+func incomingHeaderMatcher(key string) (string, bool) {
+	for _, header := range []string{APIKeyHeader, RequestIDHeader, SessionTokenHeader} {
+		if strings.EqualFold(key, header) {
+			return runtime.MetadataPrefix + header, true
+		}
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
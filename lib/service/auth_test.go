@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"biller/lib/principal"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type fakeAPIKeyVerifier struct {
+	err error
+}
+
+func (f fakeAPIKeyVerifier) VerifyAPIKey(ctx context.Context, key string) (*principal.Principal, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &principal.Principal{ID: "key/" + key}, nil
+}
+
+func Test_AuthUnaryInterceptor(t *testing.T) {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		p, ok := principal.FromContext(ctx)
+		if !ok {
+			return "", nil
+		}
+		return p.ID, nil
+	}
+	tests := []struct {
+		name     string
+		verifier fakeAPIKeyVerifier
+		md       metadata.MD
+		method   string
+		code     codes.Code
+		id       string
+	}{
+		{"should put the principal of an api key in the context", fakeAPIKeyVerifier{}, metadata.Pairs(APIKeyHeader, "abc"), "/svc/Method", codes.OK, "key/abc"},
+		{"should accept the api key forwarded by the gateway", fakeAPIKeyVerifier{}, metadata.Pairs("grpcgateway-x-api-key", "abc"), "/svc/Method", codes.OK, "key/abc"},
+		{"should reject requests without credentials", fakeAPIKeyVerifier{}, metadata.MD{}, "/svc/Method", codes.Unauthenticated, ""},
+		{"should allow public methods without credentials", fakeAPIKeyVerifier{}, metadata.MD{}, "/grpc.health.v1.Health/Check", codes.OK, ""},
+		{"should reject invalid keys", fakeAPIKeyVerifier{err: errors.New("unknown key")}, metadata.Pairs(APIKeyHeader, "abc"), "/svc/Method", codes.Unauthenticated, ""},
+		{"should pass on failures to verify keys", fakeAPIKeyVerifier{err: status.Error(codes.Internal, "down")}, metadata.Pairs(APIKeyHeader, "abc"), "/svc/Method", codes.Internal, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := AuthUnaryInterceptor([]Authenticator{NewAPIKeyAuthenticator(tt.verifier)}, defaultPublicMethods)
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			res, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if status.Code(err) != tt.code {
+				t.Fatalf("expected: %s, got: %s", tt.code, status.Code(err))
+			}
+			if err == nil && res != tt.id {
+				t.Errorf("expected: %q, got: %q", tt.id, res)
+			}
+		})
+	}
+}
+
+func Test_incomingHeaderMatcher(t *testing.T) {
+	key, ok := incomingHeaderMatcher("X-Api-Key")
+	if !ok || key != "grpcgateway-x-api-key" {
+		t.Errorf("expected the api key header to be forwarded, got: %q %v", key, ok)
+	}
+	_, ok = incomingHeaderMatcher("X-Something-Else")
+	if ok {
+		t.Errorf("expected other headers not to be forwarded")
+	}
+}
+
+func Test_sessionToken(t *testing.T) {
+	tests := []struct {
+		name  string
+		md    metadata.MD
+		token string
+	}{
+		{"should read the session token header", metadata.Pairs(SessionTokenHeader, "abc"), "abc"},
+		{"should read the session token forwarded by the gateway", metadata.Pairs("grpcgateway-x-session-token", "abc"), "abc"},
+		{"should read bearer tokens", metadata.Pairs("authorization", "Bearer abc"), "abc"},
+		{"should ignore other authorization schemes", metadata.Pairs("authorization", "Basic abc"), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if token := sessionToken(tt.md); token != tt.token {
+				t.Errorf("expected: %q, got: %q", tt.token, token)
+			}
+		})
+	}
+}
@@ -6,13 +6,20 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os/signal"
+	"sync/atomic"
 	"syscall"
 	"time"
 
+	"biller/lib/logger"
 	"biller/lib/version"
 
+	"github.com/google/uuid"
 	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -20,25 +27,64 @@ type Runner interface {
 	Run(ctx context.Context) error
 }
 
+// Locker is a lock shared by every instance of a background service, such as a postgresql advisory lock
+type Locker interface {
+	// TryLock takes the lock without waiting and returns whether it's held, it returns true again while it's still held
+	TryLock(ctx context.Context) (bool, error)
+	Unlock(ctx context.Context) error
+}
+
 type BackgroundServiceConfig struct {
-	Environment      string
-	Interval         time.Duration
+	// CatchUp is what the task does about runs of its Schedule it missed, they're skipped when not set
+	CatchUp     CatchUp
+	Environment string
+	// HealthChecks must all pass for /readyz on the prometheus server to succeed, along with a check that the task
+	// succeeded recently
+	HealthChecks map[string]HealthCheck
+	// HealthCheckInterval is how often the health checks run, every 10 seconds when not set
+	HealthCheckInterval time.Duration
+	Interval            time.Duration
+	// Jitter delays each run of the Schedule by up to its duration, so tasks scheduled at the same time don't all start
+	// at once
+	Jitter time.Duration
+	// Location is the timezone of the Schedule, UTC when not set
+	Location *time.Location
+	// Lock is taken before each run so only the instance holding it runs the task, the others stand by until it's
+	// released. Every instance runs the task when not set.
+	Lock             Locker
 	Sleep            time.Duration
 	Name             string
 	PrometheusServer *PrometheusServerConfig
-	Task             Runner
-	Timeout          time.Duration
+	// Schedule is a cron expression of the times the task runs at, such as "0 2 * * *" for 2am every day, used instead
+	// of an Interval or Sleep
+	Schedule string
+	Task     Runner
+	Timeout  time.Duration
+	// Tracing exports a span for each run of the task, nothing is exported when not set
+	Tracing *TracingConfig
 }
 
 type BackgroundService struct {
-	config           BackgroundServiceConfig
+	config BackgroundServiceConfig
+	health *healthChecker
+	// leading is whether the instance held the lock at the last run
+	leading bool
+	leader  prometheus.Gauge
+	// lastSuccess is the unix time in nanoseconds the task last succeeded, or the service started
+	lastSuccess      int64
 	log              *zap.Logger
+	nextRun          prometheus.Gauge
 	prometheusServer *PrometheusServer
 	registry         *prometheus.Registry
+	schedule         *schedule
+	// scheduleErr is returned by Run when the Schedule can't be parsed
+	scheduleErr     error
+	shutdownTracing func()
 }
 
 // New returns a new instance of a background service that repeats a run then a sleep for the duration if the sleep parameter is used.
 // If an interval is specified, it returns a new instance of a background service that runs everytime a ticker ticks.
+// If a schedule is specified, it returns a new instance of a background service that runs at the times of the schedule.
 func NewBackground(config BackgroundServiceConfig, registry *prometheus.Registry, log *zap.Logger) *BackgroundService {
 	log.Info(
 		"creating background task",
@@ -49,22 +95,80 @@ func NewBackground(config BackgroundServiceConfig, registry *prometheus.Registry
 	)
 
 	s := &BackgroundService{
-		config:   config,
-		log:      log,
+		lastSuccess: time.Now().UnixNano(),
+		leader: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name:        "background_task_leader",
+			Help:        "Whether the instance holds the lock of the task and runs it, always 1 when the task has no lock.",
+			ConstLabels: prometheus.Labels{"task": config.Name},
+		}),
+		log: log,
+		nextRun: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name:        "background_task_next_run_timestamp_seconds",
+			Help:        "The unix time the task is next due to run.",
+			ConstLabels: prometheus.Labels{"task": config.Name},
+		}),
 		registry: registry,
 	}
+	if registry != nil {
+		for _, collector := range []prometheus.Collector{s.leader, s.nextRun} {
+			if err := registry.Register(collector); err != nil {
+				log.Error("could not register background task metrics", zap.Error(err))
+			}
+		}
+	}
+
+	if config.Schedule != "" {
+		s.schedule, s.scheduleErr = newSchedule(config, s.nextRun, log)
+	}
+
+	if config.Timeout == 0 {
+		if config.Interval != 0 {
+			config.Timeout = config.Interval
+		} else if config.Sleep != 0 {
+			config.Timeout = 10 * time.Minute
+		} else if s.schedule != nil {
+			config.Timeout = s.schedule.period(time.Now())
+		}
+	}
+	s.config = config
+
+	checks := map[string]HealthCheck{"task": s.checkTask}
+	for name, check := range s.config.HealthChecks {
+		checks[name] = check
+	}
+	s.health = newHealthChecker(checks, s.config.HealthCheckInterval, registry, log)
 
 	if s.config.PrometheusServer != nil {
-		s.prometheusServer = newPrometheusServer(*s.config.PrometheusServer, registry, log)
+		s.prometheusServer = newPrometheusServer(*s.config.PrometheusServer, registry, s.health, log)
+	}
+
+	if s.config.Tracing != nil {
+		tracingConfig := *s.config.Tracing
+		if tracingConfig.ServiceName == "" {
+			tracingConfig.ServiceName = s.config.Name
+		}
+		var err error
+		s.shutdownTracing, err = setupTracing(context.Background(), tracingConfig, s.config.Environment, log)
+		if err != nil {
+			log.Error("could not set up tracing", zap.Error(err))
+		}
 	}
 
 	return s
 }
 
 func (s *BackgroundService) Run(ctx context.Context) error {
+	if s.scheduleErr != nil {
+		return s.scheduleErr
+	}
+
 	// make a context that is cancelled when the process receives a SIGINT or SIGTERM
 	signalCtx, stopSignalCtx := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
 
+	if s.shutdownTracing != nil {
+		defer s.shutdownTracing()
+	}
+
 	promServerCtx := context.Background()
 	if s.prometheusServer != nil {
 		promServerCtx = s.prometheusServer.start()
@@ -73,24 +177,36 @@ func (s *BackgroundService) Run(ctx context.Context) error {
 
 	s.log.Info("starting background service", zap.String("name", s.config.Name))
 
-	err := errors.New("background service must either have an interval or a sleep duration specified")
+	healthCtx, stopHealthChecks := context.WithCancel(signalCtx)
+	defer stopHealthChecks()
+	go s.health.run(healthCtx, func(bool) {})
+
+	if s.config.Lock != nil {
+		defer s.unlock()
+	}
+
+	err := errors.New("background service must either have an interval, a sleep duration or a schedule specified")
 
 	if s.config.Sleep != 0 {
-		if s.config.Timeout == 0 {
-			s.config.Timeout = 10 * time.Minute
-		}
-		err = s.run(func() <-chan time.Time { return time.After(s.config.Sleep) }, signalCtx, promServerCtx)
+		err = s.run(func() <-chan time.Time {
+			s.nextRun.Set(float64(time.Now().Add(s.config.Sleep).Unix()))
+			return time.After(s.config.Sleep)
+		}, true, signalCtx, promServerCtx)
 	}
 	if s.config.Interval != 0 {
-		if s.config.Timeout == 0 {
-			s.config.Timeout = s.config.Interval
-		}
 		ticker := time.NewTicker(s.config.Interval)
 		defer ticker.Stop()
-		err = s.run(func() <-chan time.Time { return ticker.C }, signalCtx, promServerCtx)
+		err = s.run(func() <-chan time.Time {
+			s.nextRun.Set(float64(time.Now().Add(s.config.Interval).Unix()))
+			return ticker.C
+		}, true, signalCtx, promServerCtx)
+	}
+	if s.config.Schedule != "" {
+		err = s.run(s.schedule.next, s.config.CatchUp == CatchUpOnce, signalCtx, promServerCtx)
 	}
 
-	if err != nil {
+	// the loop ends with the error of the signal context when the service is asked to stop, which isn't a failure
+	if err != nil && err != signalCtx.Err() {
 		return err
 	}
 
@@ -102,18 +218,17 @@ func (s *BackgroundService) Run(ctx context.Context) error {
 }
 
 // Runs the task at every interval period. If not complete in that time, it is
-// cancelled and logged, and we re-run it.
-func (s *BackgroundService) run(next func() <-chan time.Time, signalCtx context.Context, promServerCtx context.Context) error {
-	for {
-		ctx, cancel := context.WithTimeout(signalCtx, s.config.Timeout)
-		s.log.Debug("running task", zap.String("name", s.config.Name))
-		err := s.config.Task.Run(ctx)
-		cancel()
-		if err != nil {
-			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
-				s.log.Warn("task timed out")
-			} else {
-				s.log.Error("task error", zap.Error(err))
+// cancelled and logged, and we re-run it. The first run is straight away when runFirst is set, otherwise when next says.
+func (s *BackgroundService) run(next func() <-chan time.Time, runFirst bool, signalCtx context.Context, promServerCtx context.Context) error {
+	for runNow := runFirst; ; runNow = true {
+		if runNow && s.lead(signalCtx) {
+			err := s.runTask(signalCtx)
+			if err != nil {
+				if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+					s.log.Warn("task timed out")
+				} else {
+					s.log.Error("task error", zap.Error(err))
+				}
 			}
 		}
 
@@ -130,3 +245,84 @@ func (s *BackgroundService) run(next func() <-chan time.Time, signalCtx context.
 		}
 	}
 }
+
+// lead returns whether the instance should run the task, which it should when it holds the lock or there isn't one
+func (s *BackgroundService) lead(ctx context.Context) bool {
+	if s.config.Lock == nil {
+		s.leader.Set(1)
+		return true
+	}
+	leading, err := s.config.Lock.TryLock(ctx)
+	if err != nil {
+		s.log.Error("could not take the task lock", zap.Error(err))
+	} else if !leading {
+		// another instance runs the task, standing by for it is how this one succeeds
+		atomic.StoreInt64(&s.lastSuccess, time.Now().UnixNano())
+	}
+	if leading != s.leading {
+		if leading {
+			s.log.Info("took the task lock, running the task", zap.String("name", s.config.Name))
+		} else {
+			s.log.Info("another instance holds the task lock, standing by", zap.String("name", s.config.Name))
+		}
+		s.leading = leading
+	}
+	if leading {
+		s.leader.Set(1)
+	} else {
+		s.leader.Set(0)
+	}
+	return leading
+}
+
+// unlock releases the lock so another instance can take over without waiting for this one's session to end
+func (s *BackgroundService) unlock() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.config.Lock.Unlock(ctx); err != nil {
+		s.log.Warn("could not release the task lock", zap.Error(err))
+	}
+	s.leading = false
+	s.leader.Set(0)
+}
+
+// runTask runs the task once in its own trace
+func (s *BackgroundService) runTask(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+	ctx, span := otel.Tracer(tracerName).Start(ctx, s.config.Name, trace.WithNewRoot())
+	defer span.End()
+	// tie the logs of the run together like those of a request
+	log := s.log.With(zap.String("task", s.config.Name), zap.String("run_id", uuid.NewString()))
+	if span.SpanContext().HasTraceID() {
+		log = log.With(zap.String("trace_id", span.SpanContext().TraceID().String()))
+	}
+	ctx = logger.NewContext(ctx, log)
+
+	log.Debug("running task")
+	err := s.config.Task.Run(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	atomic.StoreInt64(&s.lastSuccess, time.Now().UnixNano())
+	return nil
+}
+
+// checkTask fails when the task hasn't succeeded for two of its periods, plus the time a run may take
+func (s *BackgroundService) checkTask(context.Context) error {
+	period := s.config.Interval
+	if period == 0 {
+		period = s.config.Sleep
+	}
+	if s.schedule != nil {
+		period = s.schedule.period(time.Now())
+	}
+	maxAge := 2*period + s.config.Timeout + s.config.Jitter
+	age := time.Since(time.Unix(0, atomic.LoadInt64(&s.lastSuccess)))
+	if age > maxAge {
+		return fmt.Errorf("the task hasn't succeeded for %s", age.Round(time.Second))
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"go.uber.org/zap/zaptest"
 	"golang.org/x/sync/errgroup"
 )
@@ -257,3 +258,96 @@ func Test_Background_ParentCancel(t *testing.T) {
 		t.Error("If the parent context is cancelled the task should only be called once")
 	}
 }
+
+// fakeLock is held by the first of the services sharing it to take it
+type fakeLock struct {
+	holder *string
+	name   string
+	err    error
+}
+
+func (l fakeLock) TryLock(context.Context) (bool, error) {
+	if l.err != nil {
+		return false, l.err
+	}
+	if *l.holder == "" {
+		*l.holder = l.name
+	}
+	return *l.holder == l.name, nil
+}
+
+func (l fakeLock) Unlock(context.Context) error {
+	if *l.holder == l.name {
+		*l.holder = ""
+	}
+	return nil
+}
+
+func Test_Background_Lock(t *testing.T) {
+	var holder string
+	newService := func(lock Locker) *BackgroundService {
+		return NewBackground(BackgroundServiceConfig{
+			Interval: time.Minute,
+			Lock:     lock,
+			Name:     "test",
+			Task:     &fakeBackgroundTask{},
+		}, prometheus.NewRegistry(), zaptest.NewLogger(t))
+	}
+	first := newService(fakeLock{holder: &holder, name: "first"})
+	second := newService(fakeLock{holder: &holder, name: "second"})
+
+	t.Run("should only lead with the lock", func(t *testing.T) {
+		if !first.lead(context.Background()) {
+			t.Errorf("expected the first service to lead")
+		}
+		if second.lead(context.Background()) {
+			t.Errorf("expected the second service to stand by")
+		}
+		if got := testutil.ToFloat64(first.leader); got != 1 {
+			t.Errorf("expected the first service to export 1, got: %v", got)
+		}
+		if got := testutil.ToFloat64(second.leader); got != 0 {
+			t.Errorf("expected the second service to export 0, got: %v", got)
+		}
+	})
+	t.Run("should stay healthy standing by", func(t *testing.T) {
+		second.lastSuccess = time.Now().Add(-time.Hour).UnixNano()
+		second.lead(context.Background())
+		if err := second.checkTask(context.Background()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+	t.Run("should take over once the lock is released", func(t *testing.T) {
+		first.unlock()
+		if !second.lead(context.Background()) {
+			t.Errorf("expected the second service to lead")
+		}
+		if got := testutil.ToFloat64(first.leader); got != 0 {
+			t.Errorf("expected the first service to export 0, got: %v", got)
+		}
+	})
+	t.Run("should not lead when the lock can't be taken", func(t *testing.T) {
+		failing := newService(fakeLock{err: errors.New("connection refused")})
+		if failing.lead(context.Background()) {
+			t.Errorf("expected the service not to lead")
+		}
+	})
+	t.Run("should always lead without a lock", func(t *testing.T) {
+		if !newService(nil).lead(context.Background()) {
+			t.Errorf("expected the service to lead")
+		}
+	})
+}
+
+func Test_Background_Stop(t *testing.T) {
+	svc := NewBackground(BackgroundServiceConfig{
+		Interval: time.Minute,
+		Name:     "test",
+		Task:     &fakeBackgroundTask{fakeRun: func(f *fakeBackgroundTask, ctx context.Context) error { return nil }},
+	}, prometheus.NewRegistry(), zaptest.NewLogger(t))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := svc.Run(ctx); err != nil {
+		t.Errorf("expected a service asked to stop to stop cleanly, got: %v", err)
+	}
+}
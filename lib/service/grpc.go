@@ -8,11 +8,13 @@ import (
 	"net"
 	"time"
 
+	"biller/lib/errorhandler"
 	"biller/lib/logger/grpctrace"
 	"biller/lib/service/grpcversion"
 
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -26,13 +28,26 @@ const defaultConnectionTimeout = 30 * time.Second
 const defaultRPCTimeout = 30 * time.Second
 
 type GRPCServiceConfig struct {
+	// Authenticators find the principal of each request, requests are not authenticated when there are none
+	Authenticators    []Authenticator
 	ConnectionTimeout time.Duration
 	ListenAddr        string
 	Name              string
-	RPCTimeout        time.Duration
-	SentryDSN         string
-	TLSCertFile       string
-	TLSKeyFile        string
+	// PublicMethods are method prefixes that can be called without credentials, health checks, reflection and the
+	// version service are public when not set
+	PublicMethods []string
+	// RateLimits limit the requests each caller can make by full method name or method prefix, see NewRateLimiter
+	RateLimits map[string]RateLimit
+	RPCTimeout time.Duration
+	SentryDSN  string
+	// StreamInterceptors run in order after the framework's stream interceptors, closest to the handler
+	StreamInterceptors []grpc.StreamServerInterceptor
+	// StreamTimeout limits how long a stream can stay open, streams have no timeout when not set
+	StreamTimeout time.Duration
+	TLSCertFile   string
+	TLSKeyFile    string
+	// UnaryInterceptors run in order after the framework's unary interceptors, closest to the handler
+	UnaryInterceptors []grpc.UnaryServerInterceptor
 }
 
 type GRPCService struct {
@@ -50,6 +65,9 @@ func newGRPCService(config GRPCServiceConfig, registry *prometheus.Registry, log
 	if config.RPCTimeout == 0 {
 		config.RPCTimeout = defaultRPCTimeout
 	}
+	if config.PublicMethods == nil {
+		config.PublicMethods = defaultPublicMethods
+	}
 
 	s := &GRPCService{
 		config:   config,
@@ -60,6 +78,14 @@ func newGRPCService(config GRPCServiceConfig, registry *prometheus.Registry, log
 	var unaryInterceptors []grpc.UnaryServerInterceptor
 	var streamInterceptors []grpc.StreamServerInterceptor
 
+	// start a span for each call, continuing the trace of the caller, first so the span covers every interceptor
+	unaryInterceptors = append(unaryInterceptors, otelgrpc.UnaryServerInterceptor())
+	streamInterceptors = append(streamInterceptors, otelgrpc.StreamServerInterceptor())
+
+	// tie the logs of each call together with a request id, inside the span so the logs have the trace id
+	unaryInterceptors = append(unaryInterceptors, RequestIDUnaryInterceptor(log))
+	streamInterceptors = append(streamInterceptors, RequestIDStreamInterceptor(log))
+
 	var grpcMetrics *grpc_prometheus.ServerMetrics
 	if s.registry != nil {
 		svcNameLabel := prometheus.Labels{
@@ -74,9 +100,35 @@ func newGRPCService(config GRPCServiceConfig, registry *prometheus.Registry, log
 
 	// debug log the name of the grpc method and the call latency
 	unaryInterceptors = append(unaryInterceptors, grpctrace.UnaryInterceptor(log))
+	streamInterceptors = append(streamInterceptors, grpctrace.StreamInterceptor(log))
+
+	// turn a panic in any later interceptor or the handler into an Internal error, after the metrics and logging so
+	// they see it
+	unaryInterceptors = append(unaryInterceptors, errorhandler.PanicUnaryInterceptor(log))
+	streamInterceptors = append(streamInterceptors, errorhandler.PanicStreamInterceptor(log))
 
 	// add a per rpc timeout
 	unaryInterceptors = append(unaryInterceptors, TimeoutUnaryInterceptor(config.RPCTimeout))
+	if config.StreamTimeout > 0 {
+		streamInterceptors = append(streamInterceptors, TimeoutStreamInterceptor(config.StreamTimeout))
+	}
+
+	// put the principal making the request into the context
+	if len(config.Authenticators) > 0 {
+		unaryInterceptors = append(unaryInterceptors, AuthUnaryInterceptor(config.Authenticators, config.PublicMethods))
+		streamInterceptors = append(streamInterceptors, AuthStreamInterceptor(config.Authenticators, config.PublicMethods))
+	}
+
+	// limit requests per caller, after authentication so callers are identified by their principal
+	var rateLimiter *RateLimiter
+	if len(config.RateLimits) > 0 {
+		rateLimiter = NewRateLimiter(config.RateLimits, prometheus.Labels{"grpc_service_name": s.config.Name})
+		unaryInterceptors = append(unaryInterceptors, rateLimiter.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, rateLimiter.StreamServerInterceptor())
+	}
+
+	unaryInterceptors = append(unaryInterceptors, config.UnaryInterceptors...)
+	streamInterceptors = append(streamInterceptors, config.StreamInterceptors...)
 
 	serverOpts := []grpc.ServerOption{
 		grpc.ChainUnaryInterceptor(unaryInterceptors...),
@@ -103,6 +155,11 @@ func newGRPCService(config GRPCServiceConfig, registry *prometheus.Registry, log
 			log.Error("could not register gRPC server metrics", zap.Error(err))
 		}
 	}
+	if rateLimiter != nil && registry != nil {
+		if err := registry.Register(rateLimiter); err != nil {
+			log.Error("could not register rate limit metrics", zap.Error(err))
+		}
+	}
 	s.health = health.NewServer()
 	// the default is set to SERVING, but we are just starting up so we set it to NOT_SERVING
 	s.health.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
@@ -147,9 +204,13 @@ func (s *GRPCService) notReady() {
 	s.log.Info("service not ready", zap.String("name", s.config.Name))
 }
 
-// Mark service healthy.
-func (s *GRPCService) ready() {
-	s.health.Resume()
+// Mark service healthy or unhealthy depending on the health checks, it stays unhealthy once notReady was called.
+func (s *GRPCService) setServing(serving bool) {
+	status := grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	if serving {
+		status = grpc_health_v1.HealthCheckResponse_SERVING
+	}
+	s.health.SetServingStatus("", status)
 }
 
 func (s *GRPCService) shutdown() {
@@ -164,3 +225,12 @@ func TimeoutUnaryInterceptor(timeout time.Duration) grpc.UnaryServerInterceptor
 		return handler(timeoutCtx, req)
 	}
 }
+
+// TimeoutStreamInterceptor cancels the context of a stream once it has been open for timeout
+func TimeoutStreamInterceptor(timeout time.Duration) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		timeoutCtx, cancel := context.WithTimeout(ss.Context(), timeout)
+		defer cancel()
+		return handler(srv, &contextServerStream{ServerStream: ss, ctx: timeoutCtx})
+	}
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func Test_newGRPCService_interceptors(t *testing.T) {
+	var calls []string
+	record := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			calls = append(calls, name)
+			return handler(ctx, req)
+		}
+	}
+	panics := true
+	s := newGRPCService(GRPCServiceConfig{
+		Name: "test",
+		UnaryInterceptors: []grpc.UnaryServerInterceptor{
+			record("first"),
+			record("second"),
+			func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				if panics {
+					panic("boom")
+				}
+				return handler(ctx, req)
+			},
+		},
+	}, nil, zap.NewNop())
+	s.setServing(true)
+
+	lis := bufconn.Listen(1 << 20)
+	go func() {
+		_ = s.GRPCServer.Serve(lis)
+	}()
+	defer s.GRPCServer.Stop()
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	_, err = client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("expected a panic to be recovered as: %s, got: %v", codes.Internal, err)
+	}
+	if strings.Contains(status.Convert(err).Message(), "boom") {
+		t.Errorf("expected the panic value not to be sent to the client, got: %v", err)
+	}
+	if len(calls) != 2 || calls[0] != "first" || calls[1] != "second" {
+		t.Errorf("expected the interceptors to run in order, got: %v", calls)
+	}
+
+	panics = false
+	_, err = client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		t.Errorf("expected the server to keep serving after a panic, got: %v", err)
+	}
+}
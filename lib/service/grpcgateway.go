@@ -13,6 +13,8 @@ import (
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/rs/cors"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -47,7 +49,7 @@ type GRPCGateway struct {
 }
 
 // newGRPCGateway returns a new instance of service
-func newGRPCGateway(config GRPCGatewayConfig, log *zap.Logger) *GRPCGateway {
+func newGRPCGateway(config GRPCGatewayConfig, health *healthChecker, log *zap.Logger) *GRPCGateway {
 	if config.ListenAddr == "" {
 		config.ListenAddr = defaultGatewayListenAddr
 	}
@@ -73,6 +75,11 @@ func newGRPCGateway(config GRPCGatewayConfig, log *zap.Logger) *GRPCGateway {
 		})
 		dialOpts = []grpc.DialOption{grpc.WithTransportCredentials(creds)}
 	}
+	// pass the trace of the http request on to the grpc server
+	dialOpts = append(dialOpts,
+		grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(otelgrpc.StreamClientInterceptor()),
+	)
 	grpcClientConn, err := grpc.Dial(config.GRPCServerAddr, dialOpts...)
 	if err != nil {
 		if grpcClientConn != nil {
@@ -84,9 +91,10 @@ func newGRPCGateway(config GRPCGatewayConfig, log *zap.Logger) *GRPCGateway {
 	}
 
 	healthCheck := grpc_health_v1.NewHealthClient(grpcClientConn)
+	// options from the config come after the defaults so they can replace them
 	gwmux := runtime.NewServeMux(
 		append(
-			config.ServeMuxOpts,
+			append([]runtime.ServeMuxOption{runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher)}, config.ServeMuxOpts...),
 			runtime.WithHealthzEndpoint(healthCheck),
 		)...,
 	)
@@ -98,7 +106,7 @@ func newGRPCGateway(config GRPCGatewayConfig, log *zap.Logger) *GRPCGateway {
 		GatewayMux:     gwmux,
 		GRPCClientConn: grpcClientConn,
 		log:            log,
-		handler:        http.TimeoutHandler(gwmuxWithCors, config.HandlerTimeout, ""),
+		handler:        withHealthEndpoints(otelhttp.NewHandler(requestIDHandler(http.TimeoutHandler(gwmuxWithCors, config.HandlerTimeout, "")), "grpc-gateway"), health),
 	}
 
 }
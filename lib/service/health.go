@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultHealthCheckInterval = 10 * time.Second
+	healthCheckTimeout         = 5 * time.Second
+)
+
+// HealthCheck returns an error when a dependency the service needs, such as its database, can't be used
+type HealthCheck func(ctx context.Context) error
+
+// healthChecker runs the health checks of a service periodically. The service is ready when every check passed the
+// last time it ran, and is not ready until the checks have run once.
+type healthChecker struct {
+	checks   map[string]HealthCheck
+	interval time.Duration
+	log      *zap.Logger
+	up       *prometheus.GaugeVec
+
+	mu      sync.RWMutex
+	checked bool
+	results map[string]error
+}
+
+func newHealthChecker(checks map[string]HealthCheck, interval time.Duration, registry *prometheus.Registry, log *zap.Logger) *healthChecker {
+	if interval == 0 {
+		interval = defaultHealthCheckInterval
+	}
+	h := &healthChecker{
+		checks:   checks,
+		interval: interval,
+		log:      log,
+		up: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "health_check_up",
+			Help: "Whether a health check passed the last time it ran.",
+		}, []string{"check"}),
+	}
+	if registry != nil {
+		if err := registry.Register(h.up); err != nil {
+			log.Error("could not register health check metrics", zap.Error(err))
+		}
+	}
+	return h
+}
+
+// check runs every check at once and returns whether they all passed
+func (h *healthChecker) check(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	results := make(map[string]error, len(h.checks))
+	var resultsMu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range h.checks {
+		wg.Add(1)
+		go func(name string, check HealthCheck) {
+			defer wg.Done()
+			err := check(ctx)
+			resultsMu.Lock()
+			results[name] = err
+			resultsMu.Unlock()
+		}(name, check)
+	}
+	wg.Wait()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ready := true
+	for name, err := range results {
+		previous, checked := h.results[name]
+		if err != nil {
+			ready = false
+			h.up.WithLabelValues(name).Set(0)
+			// log when a check starts failing or fails differently, not every time it runs
+			if !checked || previous == nil || previous.Error() != err.Error() {
+				h.log.Warn("health check failed", zap.String("check", name), zap.Error(err))
+			}
+		} else {
+			h.up.WithLabelValues(name).Set(1)
+			if checked && previous != nil {
+				h.log.Info("health check passed", zap.String("check", name))
+			}
+		}
+	}
+	h.results = results
+	h.checked = true
+	return ready
+}
+
+// run checks immediately and then every interval until ctx is done, calling update with the result each time
+func (h *healthChecker) run(ctx context.Context, update func(ready bool)) {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+	for {
+		update(h.check(ctx))
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// status returns whether the service is ready and the result of each check
+func (h *healthChecker) status() (bool, map[string]string) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	ready := h.checked
+	checks := make(map[string]string, len(h.checks))
+	for name := range h.checks {
+		err, ok := h.results[name]
+		switch {
+		case !ok:
+			checks[name] = "not checked"
+			ready = false
+		case err != nil:
+			checks[name] = err.Error()
+			ready = false
+		default:
+			checks[name] = "ok"
+		}
+	}
+	return ready, checks
+}
+
+type healthResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// livez answers as long as the process can handle requests, whatever the state of its dependencies, so the process
+// is only restarted when it's stuck
+func livez(w http.ResponseWriter, _ *http.Request) {
+	writeHealth(w, http.StatusOK, healthResponse{Status: "ok"})
+}
+
+// readyz reports whether every health check passed, with the result of each
+func (h *healthChecker) readyz(w http.ResponseWriter, _ *http.Request) {
+	ready, checks := h.status()
+	if !ready {
+		writeHealth(w, http.StatusServiceUnavailable, healthResponse{Status: "unavailable", Checks: checks})
+		return
+	}
+	writeHealth(w, http.StatusOK, healthResponse{Status: "ok", Checks: checks})
+}
+
+func writeHealth(w http.ResponseWriter, code int, res healthResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+// withHealthEndpoints serves /livez and /readyz in front of h
+func withHealthEndpoints(h http.Handler, health *healthChecker) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/livez", livez)
+	mux.HandleFunc("/readyz", health.readyz)
+	mux.Handle("/", h)
+	return mux
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap/zaptest"
+)
+
+func Test_healthChecker(t *testing.T) {
+	dbErr := errors.New("connection refused")
+	var failing error
+	registry := prometheus.NewRegistry()
+	h := newHealthChecker(map[string]HealthCheck{
+		"postgresql": func(ctx context.Context) error { return failing },
+		"schema":     func(ctx context.Context) error { return nil },
+	}, 0, registry, zaptest.NewLogger(t))
+	server := httptest.NewServer(withHealthEndpoints(http.NotFoundHandler(), h))
+	defer server.Close()
+
+	readyz := func(t *testing.T) (int, healthResponse) {
+		t.Helper()
+		res, err := http.Get(server.URL + "/readyz")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer res.Body.Close()
+		var body healthResponse
+		if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+			t.Fatalf("could not decode response: %v", err)
+		}
+		return res.StatusCode, body
+	}
+
+	t.Run("should not be ready before the checks ran", func(t *testing.T) {
+		code, body := readyz(t)
+		if code != http.StatusServiceUnavailable || body.Checks["postgresql"] != "not checked" {
+			t.Errorf("unexpected response: %d %+v", code, body)
+		}
+	})
+	t.Run("should be ready when every check passes", func(t *testing.T) {
+		if !h.check(context.Background()) {
+			t.Errorf("expected the checks to pass")
+		}
+		code, body := readyz(t)
+		if code != http.StatusOK || body.Status != "ok" {
+			t.Errorf("unexpected response: %d %+v", code, body)
+		}
+	})
+	t.Run("should not be ready when a check fails", func(t *testing.T) {
+		failing = dbErr
+		if h.check(context.Background()) {
+			t.Errorf("expected the checks to fail")
+		}
+		code, body := readyz(t)
+		if code != http.StatusServiceUnavailable || body.Checks["postgresql"] != dbErr.Error() || body.Checks["schema"] != "ok" {
+			t.Errorf("unexpected response: %d %+v", code, body)
+		}
+		if up := testutil.ToFloat64(h.up.WithLabelValues("postgresql")); up != 0 {
+			t.Errorf("expected health_check_up to be 0, got: %v", up)
+		}
+	})
+	t.Run("should stay live when a check fails", func(t *testing.T) {
+		res, err := http.Get(server.URL + "/livez")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			t.Errorf("expected: %d, got: %d", http.StatusOK, res.StatusCode)
+		}
+	})
+	t.Run("should pass other paths through", func(t *testing.T) {
+		res, err := http.Get(server.URL + "/v1/projects")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusNotFound {
+			t.Errorf("expected: %d, got: %d", http.StatusNotFound, res.StatusCode)
+		}
+	})
+}
+
+func Test_healthChecker_run(t *testing.T) {
+	h := newHealthChecker(map[string]HealthCheck{
+		"postgresql": func(ctx context.Context) error { return errors.New("connection refused") },
+	}, time.Millisecond, nil, zaptest.NewLogger(t))
+	ctx, cancel := context.WithCancel(context.Background())
+	updates := make(chan bool, 1)
+	go h.run(ctx, func(ready bool) {
+		select {
+		case updates <- ready:
+		default:
+		}
+	})
+	defer cancel()
+	if ready := <-updates; ready {
+		t.Errorf("expected the service not to be ready")
+	}
+}
+
+func TestBackgroundService_checkTask(t *testing.T) {
+	svc := NewBackground(BackgroundServiceConfig{
+		Interval: time.Minute,
+		Name:     "test",
+		Task:     &fakeBackgroundTask{},
+	}, prometheus.NewRegistry(), zaptest.NewLogger(t))
+
+	if err := svc.checkTask(context.Background()); err != nil {
+		t.Errorf("expected a task that just started to be fresh, got: %v", err)
+	}
+	// two intervals plus the timeout, which defaults to the interval
+	svc.lastSuccess = time.Now().Add(-4 * time.Minute).UnixNano()
+	if err := svc.checkTask(context.Background()); err == nil {
+		t.Errorf("expected a task that hasn't succeeded for 4 intervals to be stale")
+	}
+}
@@ -25,9 +25,12 @@ type PrometheusServer struct {
 	log      *zap.Logger
 	server   *http.Server
 	registry *prometheus.Registry
+	health   *healthChecker
 }
 
-func newPrometheusServer(config PrometheusServerConfig, registry *prometheus.Registry, log *zap.Logger) *PrometheusServer {
+// newPrometheusServer returns a server for the metrics in registry, which also serves /livez and /readyz when health
+// is set
+func newPrometheusServer(config PrometheusServerConfig, registry *prometheus.Registry, health *healthChecker, log *zap.Logger) *PrometheusServer {
 	if config.ListenAddr == "" {
 		config.ListenAddr = defaultPrometheusListenAddr
 	}
@@ -40,6 +43,7 @@ func newPrometheusServer(config PrometheusServerConfig, registry *prometheus.Reg
 		config:   config,
 		log:      log,
 		registry: registry,
+		health:   health,
 	}
 
 	return s
@@ -51,9 +55,13 @@ func (s *PrometheusServer) start() context.Context {
 	if err != nil {
 		s.log.Warn("could not attach error logger to prometheus server")
 	}
+	var handler http.Handler = promhttp.HandlerFor(s.registry, promhttp.HandlerOpts{ErrorLog: errorLog})
+	if s.health != nil {
+		handler = withHealthEndpoints(handler, s.health)
+	}
 	s.server = &http.Server{
 		Addr:    s.config.ListenAddr,
-		Handler: promhttp.HandlerFor(s.registry, promhttp.HandlerOpts{ErrorLog: errorLog}),
+		Handler: handler,
 	}
 
 	ctx := startHTTPServer(s.server, s.log)
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"biller/lib/principal"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// idle buckets are swept at most this often, a bucket that has refilled is the same as a new one
+const rateLimitSweepInterval = time.Minute
+
+// RateLimit is the number of requests a caller can make to a method
+type RateLimit struct {
+	// Rate is the sustained number of requests per second, limits without a rate are ignored
+	Rate float64
+	// Burst is the number of requests that can be made at once, the rate rounded up when not set
+	Burst int
+}
+
+func (l RateLimit) burst() float64 {
+	if l.Burst > 0 {
+		return float64(l.Burst)
+	}
+	return math.Max(1, math.Ceil(l.Rate))
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// take removes a token from the bucket, or returns how long until there is one
+func (b *tokenBucket) take(limit RateLimit, now time.Time) (bool, time.Duration) {
+	b.tokens = math.Min(limit.burst(), b.tokens+now.Sub(b.updated).Seconds()*limit.Rate)
+	b.updated = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second))
+}
+
+type bucketKey struct {
+	caller string
+	method string
+}
+
+// RateLimiter limits the requests each caller makes to each method with a token bucket. Callers are identified by
+// their principal, which for api keys is the key, and by their address when they aren't authenticated.
+type RateLimiter struct {
+	buckets  map[bucketKey]*tokenBucket
+	limits   map[string]RateLimit
+	mu       sync.Mutex
+	now      func() time.Time
+	requests *prometheus.CounterVec
+	swept    time.Time
+}
+
+// NewRateLimiter limits methods by limits, keyed by the full method name or a method prefix such as
+// "/org.cudo.compute.v1.ProjectService/". The longest matching key is used and methods without one aren't limited.
+func NewRateLimiter(limits map[string]RateLimit, constLabels prometheus.Labels) *RateLimiter {
+	return &RateLimiter{
+		buckets: map[bucketKey]*tokenBucket{},
+		limits:  limits,
+		now:     time.Now,
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "grpc_rate_limit_requests_total",
+			Help:        "Total number of requests checked against a rate limit, by whether they were allowed or throttled.",
+			ConstLabels: constLabels,
+		}, []string{"grpc_method", "result"}),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (l *RateLimiter) Describe(ch chan<- *prometheus.Desc) {
+	l.requests.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (l *RateLimiter) Collect(ch chan<- prometheus.Metric) {
+	l.requests.Collect(ch)
+}
+
+func (l *RateLimiter) limit(method string) (RateLimit, bool) {
+	var limit RateLimit
+	matched := -1
+	for prefix, candidate := range l.limits {
+		if candidate.Rate > 0 && len(prefix) > matched && strings.HasPrefix(method, prefix) {
+			limit = candidate
+			matched = len(prefix)
+		}
+	}
+	return limit, matched >= 0
+}
+
+// Allow takes a token from the bucket of the caller for the method, or returns how long until the caller can retry
+func (l *RateLimiter) Allow(caller string, method string) (bool, time.Duration) {
+	limit, ok := l.limit(method)
+	if !ok {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.swept) > rateLimitSweepInterval {
+		l.sweep(now)
+	}
+	key := bucketKey{caller: caller, method: method}
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: limit.burst(), updated: now}
+		l.buckets[key] = bucket
+	}
+	allowed, retryAfter := bucket.take(limit, now)
+	if allowed {
+		l.requests.WithLabelValues(method, "allowed").Inc()
+	} else {
+		l.requests.WithLabelValues(method, "throttled").Inc()
+	}
+	return allowed, retryAfter
+}
+
+func (l *RateLimiter) sweep(now time.Time) {
+	l.swept = now
+	for key, bucket := range l.buckets {
+		limit, _ := l.limit(key.method)
+		if bucket.tokens+now.Sub(bucket.updated).Seconds()*limit.Rate >= limit.burst() {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func (l *RateLimiter) check(ctx context.Context, method string) error {
+	allowed, retryAfter := l.Allow(caller(ctx), method)
+	if allowed {
+		return nil
+	}
+	st, err := status.New(codes.ResourceExhausted, "rate limit exceeded").WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(retryAfter),
+	})
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+	return st.Err()
+}
+
+// caller identifies who is making a request for rate limiting
+func caller(ctx context.Context) string {
+	if p, ok := principal.FromContext(ctx); ok {
+		return "principal/" + p.ID
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		addr := p.Addr.String()
+		// requests from the same host share a limit whatever port they come from
+		if i := strings.LastIndex(addr, ":"); i > 0 {
+			addr = addr[:i]
+		}
+		return "addr/" + addr
+	}
+	return ""
+}
+
+// UnaryServerInterceptor rejects requests over the rate limit with ResourceExhausted and a RetryInfo detail
+func (l *RateLimiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		err := l.check(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streams, each stream counts as one request
+func (l *RateLimiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := l.check(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"biller/lib/principal"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_RateLimiter(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(map[string]RateLimit{
+		"/svc/":     {Rate: 10},
+		"/svc/List": {Rate: 1, Burst: 2},
+	}, nil)
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if allowed, _ := limiter.Allow("a", "/svc/List"); !allowed {
+			t.Fatalf("expected request %d to be allowed", i)
+		}
+	}
+	allowed, retryAfter := limiter.Allow("a", "/svc/List")
+	if allowed || retryAfter != time.Second {
+		t.Errorf("expected a throttled request to retry after %s, got: %v %s", time.Second, allowed, retryAfter)
+	}
+	if allowed, _ := limiter.Allow("b", "/svc/List"); !allowed {
+		t.Errorf("expected callers to be limited separately")
+	}
+	if allowed, _ := limiter.Allow("a", "/svc/Get"); !allowed {
+		t.Errorf("expected methods to be limited separately")
+	}
+	if allowed, _ := limiter.Allow("a", "/other/List"); !allowed {
+		t.Errorf("expected methods without a limit to be allowed")
+	}
+	now = now.Add(time.Second)
+	if allowed, _ := limiter.Allow("a", "/svc/List"); !allowed {
+		t.Errorf("expected a request to be allowed once the bucket refilled")
+	}
+	if n := testutil.ToFloat64(limiter.requests.WithLabelValues("/svc/List", "throttled")); n != 1 {
+		t.Errorf("expected: %d throttled requests, got: %v", 1, n)
+	}
+
+	now = now.Add(time.Hour)
+	limiter.Allow("c", "/svc/Get")
+	if len(limiter.buckets) != 1 {
+		t.Errorf("expected idle buckets to be swept, got: %d buckets", len(limiter.buckets))
+	}
+}
+
+func Test_RateLimiter_UnaryServerInterceptor(t *testing.T) {
+	limiter := NewRateLimiter(map[string]RateLimit{"/svc/": {Rate: 1}}, nil)
+	interceptor := limiter.UnaryServerInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/svc/Method"}
+	ctx := principal.NewContext(context.Background(), &principal.Principal{ID: "user"})
+
+	_, err := interceptor(ctx, nil, info, handler)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	_, err = interceptor(ctx, nil, info, handler)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected: %s, got: %s", codes.ResourceExhausted, status.Code(err))
+	}
+	details := status.Convert(err).Details()
+	if len(details) != 1 {
+		t.Fatalf("expected retry info, got: %v", details)
+	}
+	if retryInfo, ok := details[0].(*errdetails.RetryInfo); !ok || retryInfo.RetryDelay.AsDuration() <= 0 {
+		t.Errorf("expected a retry delay, got: %v", details[0])
+	}
+	_, err = interceptor(principal.NewContext(context.Background(), &principal.Principal{ID: "other"}), nil, info, handler)
+	if err != nil {
+		t.Errorf("expected other principals not to be limited, got: %v", err)
+	}
+}
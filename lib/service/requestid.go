@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"biller/lib/logger"
+
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDHeader is the metadata key, and http header through the gateway, of the id that ties together the logs of
+// a request. Callers may send their own id, otherwise one is generated, and it is returned in the response headers.
+const RequestIDHeader = "x-request-id"
+
+const maxRequestIDLength = 128
+
+type requestIDContextKey struct{}
+
+// RequestIDFromContext returns the id of the request being handled
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+	return id
+}
+
+// validRequestID reports whether a request id sent by a caller is safe to log and return as a header
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		if r < '!' || r > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// requestID returns the request id sent by the caller, or forwarded by the gateway, or a new one
+func requestID(md metadata.MD) string {
+	id := firstMetadataValue(md, RequestIDHeader, runtime.MetadataPrefix+RequestIDHeader)
+	if validRequestID(id) {
+		return id
+	}
+	return uuid.NewString()
+}
+
+// withRequest puts the request id and a logger with the request id, method and trace id into the context
+func withRequest(ctx context.Context, id string, method string, log *zap.Logger) context.Context {
+	fields := []zap.Field{
+		zap.String("request_id", id),
+		zap.String("method", method),
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		fields = append(fields, zap.String("trace_id", spanContext.TraceID().String()))
+	}
+	ctx = context.WithValue(ctx, requestIDContextKey{}, id)
+	return logger.NewContext(ctx, log.With(fields...))
+}
+
+// RequestIDUnaryInterceptor gives each call a request id, returned in the response headers, and a logger with it
+func RequestIDUnaryInterceptor(log *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		id := requestID(md)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, id))
+		return handler(withRequest(ctx, id, info.FullMethod, log), req)
+	}
+}
+
+// RequestIDStreamInterceptor is RequestIDUnaryInterceptor for streams
+func RequestIDStreamInterceptor(log *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		md, _ := metadata.FromIncomingContext(ss.Context())
+		id := requestID(md)
+		_ = ss.SetHeader(metadata.Pairs(RequestIDHeader, id))
+		return handler(srv, &contextServerStream{ServerStream: ss, ctx: withRequest(ss.Context(), id, info.FullMethod, log)})
+	}
+}
+
+// requestIDHandler makes sure every gateway request has a request id, which is forwarded to the grpc server and
+// returned in the X-Request-Id response header
+func requestIDHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+			r.Header.Set(RequestIDHeader, id)
+		}
+		w.Header().Set(RequestIDHeader, id)
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"biller/lib/logger"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func Test_RequestIDUnaryInterceptor(t *testing.T) {
+	tests := []struct {
+		name string
+		md   metadata.MD
+		id   string
+	}{
+		{"should use the request id of the caller", metadata.Pairs(RequestIDHeader, "abc"), "abc"},
+		{"should use the request id forwarded by the gateway", metadata.Pairs("grpcgateway-x-request-id", "abc"), "abc"},
+		{"should generate a request id when there isn't one", metadata.MD{}, ""},
+		{"should replace request ids that aren't safe to log", metadata.Pairs(RequestIDHeader, "a\nb"), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zap.InfoLevel)
+			interceptor := RequestIDUnaryInterceptor(zap.New(core))
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			var id string
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/svc/Method"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				id = RequestIDFromContext(ctx)
+				logger.FromContext(ctx, zap.NewNop()).Info("handled")
+				return nil, nil
+			})
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if tt.id != "" && id != tt.id {
+				t.Errorf("expected: %q, got: %q", tt.id, id)
+			}
+			if !validRequestID(id) {
+				t.Errorf("expected a valid request id, got: %q", id)
+			}
+			entries := logs.All()
+			if len(entries) != 1 || entries[0].ContextMap()["request_id"] != id {
+				t.Errorf("expected the handler to log with the request id, got: %v", entries)
+			}
+		})
+	}
+}
+
+func Test_requestIDHandler(t *testing.T) {
+	var forwarded string
+	handler := requestIDHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded = r.Header.Get(RequestIDHeader)
+	}))
+
+	res := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Request-Id", "abc")
+	handler.ServeHTTP(res, req)
+	if forwarded != "abc" || res.Header().Get("X-Request-Id") != "abc" {
+		t.Errorf("expected the request id of the caller to be kept, got: %q %q", forwarded, res.Header().Get("X-Request-Id"))
+	}
+
+	res = httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/", nil))
+	if forwarded == "" || strings.Contains(forwarded, " ") || res.Header().Get("X-Request-Id") != forwarded {
+		t.Errorf("expected a request id to be generated and returned, got: %q %q", forwarded, res.Header().Get("X-Request-Id"))
+	}
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// CatchUp is what a scheduled background service does about runs it missed
+type CatchUp int
+
+const (
+	// CatchUpSkip waits for the next scheduled time, runs missed while the task was still running or the service wasn't
+	// running are skipped
+	CatchUpSkip CatchUp = iota
+	// CatchUpOnce runs the task as soon as it can after missing one or more runs, and when the service starts as a run
+	// may have been missed while it wasn't running. Missed runs are made up by a single run.
+	CatchUpOnce
+)
+
+// cronParser parses standard cron expressions of 5 fields, descriptors such as @daily and a CRON_TZ= prefix
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// schedule times the runs of a background service with a cron expression
+type schedule struct {
+	cron     cron.Schedule
+	catchUp  CatchUp
+	jitter   time.Duration
+	location *time.Location
+	log      *zap.Logger
+	nextRun  prometheus.Gauge
+	// scheduled is the time the last run was scheduled for, the time it would have started without jitter
+	scheduled time.Time
+}
+
+// ValidateSchedule returns an error when schedule isn't a cron expression a background service can run on
+func ValidateSchedule(schedule string) error {
+	_, err := parseSchedule(schedule)
+	return err
+}
+
+func parseSchedule(schedule string) (cron.Schedule, error) {
+	expr, err := cronParser.Parse(schedule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", schedule, err)
+	}
+	return expr, nil
+}
+
+func newSchedule(config BackgroundServiceConfig, nextRun prometheus.Gauge, log *zap.Logger) (*schedule, error) {
+	expr, err := parseSchedule(config.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	location := config.Location
+	if location == nil {
+		location = time.UTC
+	}
+	return &schedule{
+		cron:     expr,
+		catchUp:  config.CatchUp,
+		jitter:   config.Jitter,
+		location: location,
+		log:      log,
+		nextRun:  nextRun,
+	}, nil
+}
+
+// period estimates the time between runs from the next two, runs of schedules such as "0 1 1,15 * *" are further
+// apart at times
+func (s *schedule) period(now time.Time) time.Duration {
+	next := s.cron.Next(now.In(s.location))
+	return s.cron.Next(next).Sub(next)
+}
+
+// next returns a channel that receives when the task should run again
+func (s *schedule) next() <-chan time.Time {
+	now := time.Now()
+	next := s.cron.Next(now.In(s.location))
+	if !s.scheduled.IsZero() {
+		due := s.cron.Next(s.scheduled)
+		if due.Before(now) {
+			missed := 0
+			for ; due.Before(now); due = s.cron.Next(due) {
+				missed++
+				s.scheduled = due
+			}
+			if s.catchUp == CatchUpOnce {
+				s.log.Warn("task missed scheduled runs, running it now", zap.Int("missed", missed), zap.Time("scheduled", s.scheduled))
+				s.nextRun.Set(float64(now.Unix()))
+				return time.After(0)
+			}
+			s.log.Warn("task missed scheduled runs, skipping them", zap.Int("missed", missed), zap.Time("next", next))
+		}
+	}
+	s.scheduled = next
+
+	start := next
+	if s.jitter > 0 {
+		start = start.Add(time.Duration(rand.Int63n(int64(s.jitter))))
+	}
+	s.nextRun.Set(float64(start.Unix()))
+	return time.After(time.Until(start))
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap/zaptest"
+)
+
+func Test_schedule(t *testing.T) {
+	newTestSchedule := func(t *testing.T, config BackgroundServiceConfig) *schedule {
+		t.Helper()
+		s, err := newSchedule(config, prometheus.NewGauge(prometheus.GaugeOpts{Name: "next"}), zaptest.NewLogger(t))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return s
+	}
+	fired := func(c <-chan time.Time) bool {
+		select {
+		case <-c:
+			return true
+		case <-time.After(50 * time.Millisecond):
+			return false
+		}
+	}
+
+	t.Run("should fail on invalid expressions", func(t *testing.T) {
+		_, err := newSchedule(BackgroundServiceConfig{Schedule: "every day"}, nil, zaptest.NewLogger(t))
+		if err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+	t.Run("should schedule the next run in the location", func(t *testing.T) {
+		tokyo, err := time.LoadLocation("Asia/Tokyo")
+		if err != nil {
+			t.Skipf("no timezone database: %v", err)
+		}
+		s := newTestSchedule(t, BackgroundServiceConfig{Schedule: "0 9 * * *", Location: tokyo})
+		s.next()
+		want := s.scheduled.In(tokyo)
+		if want.Hour() != 9 || want.Minute() != 0 || !want.After(time.Now()) {
+			t.Errorf("expected the next 9am in Tokyo, got: %v", want)
+		}
+		if got := testutil.ToFloat64(s.nextRun); got != float64(want.Unix()) {
+			t.Errorf("expected next run %d, got: %v", want.Unix(), got)
+		}
+	})
+	t.Run("should delay runs by up to the jitter", func(t *testing.T) {
+		s := newTestSchedule(t, BackgroundServiceConfig{Schedule: "@hourly", Jitter: time.Minute})
+		s.next()
+		delay := time.Duration(testutil.ToFloat64(s.nextRun))*time.Second - time.Duration(s.scheduled.Unix())*time.Second
+		if delay < 0 || delay > time.Minute {
+			t.Errorf("expected a delay of up to a minute, got: %v", delay)
+		}
+	})
+	t.Run("should run once for missed runs when catching up", func(t *testing.T) {
+		s := newTestSchedule(t, BackgroundServiceConfig{Schedule: "* * * * *", CatchUp: CatchUpOnce})
+		s.scheduled = time.Now().Add(-10 * time.Minute)
+		if !fired(s.next()) {
+			t.Errorf("expected to run straight away")
+		}
+		if !s.scheduled.Before(time.Now()) || time.Since(s.scheduled) > time.Minute {
+			t.Errorf("expected the last missed run to be scheduled, got: %v", s.scheduled)
+		}
+		if fired(s.next()) {
+			t.Errorf("expected to wait for the next run after catching up")
+		}
+	})
+	t.Run("should skip missed runs by default", func(t *testing.T) {
+		s := newTestSchedule(t, BackgroundServiceConfig{Schedule: "@hourly"})
+		s.scheduled = time.Now().Add(-10 * time.Hour)
+		if fired(s.next()) {
+			t.Errorf("expected to wait for the next run")
+		}
+		if !s.scheduled.After(time.Now()) {
+			t.Errorf("expected the next run to be scheduled, got: %v", s.scheduled)
+		}
+	})
+	t.Run("should estimate the period", func(t *testing.T) {
+		s := newTestSchedule(t, BackgroundServiceConfig{Schedule: "5 0 * * *"})
+		if period := s.period(time.Date(2020, time.January, 1, 12, 0, 0, 0, time.UTC)); period != 24*time.Hour {
+			t.Errorf("expected: %v, got: %v", 24*time.Hour, period)
+		}
+	})
+}
+
+func TestBackgroundService_Schedule(t *testing.T) {
+	t.Run("should time out runs after the period by default", func(t *testing.T) {
+		svc := NewBackground(BackgroundServiceConfig{
+			Name:     "test",
+			Schedule: "@daily",
+			Task:     &fakeBackgroundTask{},
+		}, prometheus.NewRegistry(), zaptest.NewLogger(t))
+		if svc.config.Timeout != 24*time.Hour {
+			t.Errorf("expected: %v, got: %v", 24*time.Hour, svc.config.Timeout)
+		}
+	})
+	t.Run("should not run with an invalid schedule", func(t *testing.T) {
+		svc := NewBackground(BackgroundServiceConfig{
+			Name:     "test",
+			Schedule: "every day",
+			Task:     &fakeBackgroundTask{},
+		}, prometheus.NewRegistry(), zaptest.NewLogger(t))
+		if err := svc.Run(context.Background()); err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+}
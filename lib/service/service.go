@@ -20,11 +20,18 @@ import (
 const defaultHTTPServerShutdownGracePeriod = 15 * time.Second
 
 type ServiceConfig struct {
-	Environment         string
-	GRPCGateway         *GRPCGatewayConfig
-	GRPCServices        map[string]*GRPCServiceConfig
+	Environment  string
+	GRPCGateway  *GRPCGatewayConfig
+	GRPCServices map[string]*GRPCServiceConfig
+	// HealthChecks must all pass for the grpc services to report SERVING and for /readyz to succeed, the service is
+	// ready as soon as its listeners start when there are none
+	HealthChecks map[string]HealthCheck
+	// HealthCheckInterval is how often the health checks run, every 10 seconds when not set
+	HealthCheckInterval time.Duration
 	PrometheusServer    *PrometheusServerConfig
 	ShutdownGracePeriod time.Duration
+	// Tracing exports spans of gateway and grpc requests, nothing is exported when not set
+	Tracing *TracingConfig
 }
 
 // Service allows setup for basic dependencies of most listener services e.g health checking
@@ -32,9 +39,11 @@ type Service struct {
 	config           ServiceConfig
 	GRPCGateway      *GRPCGateway
 	GRPCServices     map[string]*GRPCService
+	health           *healthChecker
 	prometheusServer *PrometheusServer
 	log              *zap.Logger
 	registry         *prometheus.Registry
+	shutdownTracing  func()
 }
 
 // New returns a new instance of service
@@ -45,8 +54,18 @@ func New(config ServiceConfig, registry *prometheus.Registry, log *zap.Logger) *
 		registry: registry,
 	}
 
+	s.health = newHealthChecker(s.config.HealthChecks, s.config.HealthCheckInterval, registry, log)
+
 	if s.config.PrometheusServer != nil {
-		s.prometheusServer = newPrometheusServer(*s.config.PrometheusServer, registry, log)
+		s.prometheusServer = newPrometheusServer(*s.config.PrometheusServer, registry, s.health, log)
+	}
+
+	if s.config.Tracing != nil {
+		var err error
+		s.shutdownTracing, err = setupTracing(context.Background(), *s.config.Tracing, s.config.Environment, log)
+		if err != nil {
+			log.Error("could not set up tracing", zap.Error(err))
+		}
 	}
 
 	if len(s.config.GRPCServices) > 0 {
@@ -57,7 +76,7 @@ func New(config ServiceConfig, registry *prometheus.Registry, log *zap.Logger) *
 	}
 
 	if s.config.GRPCGateway != nil {
-		s.GRPCGateway = newGRPCGateway(*s.config.GRPCGateway, log)
+		s.GRPCGateway = newGRPCGateway(*s.config.GRPCGateway, s.health, log)
 	}
 
 	return s
@@ -75,6 +94,10 @@ func (s *Service) Run(ctx context.Context) {
 	// make a context that is cancelled when the process receives a SIGINT or SIGTERM
 	signalCtx, stopSignalCtx := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
 
+	if s.shutdownTracing != nil {
+		defer s.shutdownTracing()
+	}
+
 	promServerCtx := context.Background()
 	if s.prometheusServer != nil {
 		promServerCtx = s.prometheusServer.start()
@@ -101,14 +124,18 @@ func (s *Service) Run(ctx context.Context) {
 		defer s.GRPCGateway.shutdown()
 	}
 
-	// if nothing has caused an error, mark as healthy
+	// if nothing has caused an error, mark as healthy whenever the health checks pass
+	healthCtx, stopHealthChecks := context.WithCancel(ctx)
+	defer stopHealthChecks()
 	if promServerCtx.Err() == nil &&
 		grpcSvcErrGroupCtx.Err() == nil &&
 		gatewayServerCtx.Err() == nil &&
 		signalCtx.Err() == nil {
-		for _, grpcSvc := range s.GRPCServices {
-			grpcSvc.ready()
-		}
+		go s.health.run(healthCtx, func(ready bool) {
+			for _, grpcSvc := range s.GRPCServices {
+				grpcSvc.setServing(ready)
+			}
+		})
 	}
 
 	// wait until any of the listeners stop or a signal is received
@@ -122,6 +149,7 @@ func (s *Service) Run(ctx context.Context) {
 	case <-signalCtx.Done():
 		s.log.Info("termination signal received")
 		// Mark services unhealthy to loadbalancers.
+		stopHealthChecks()
 		for _, grpcSvc := range s.GRPCServices {
 			grpcSvc.notReady()
 		}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"biller/lib/jwt"
+	"biller/lib/principal"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// SessionTokenHeader is the metadata key, and http header through the gateway, session tokens are sent in. Tokens
+// are also accepted as bearer tokens in the authorization header.
+const SessionTokenHeader = "x-session-token"
+
+const bearerPrefix = "bearer "
+
+type jwtAuthenticator struct {
+	verifier *jwt.Verifier
+}
+
+// NewJWTAuthenticator authenticates requests by a signed session token, the principal is the subject of the token
+func NewJWTAuthenticator(verifier *jwt.Verifier) Authenticator {
+	return jwtAuthenticator{verifier: verifier}
+}
+
+func (a jwtAuthenticator) Authenticate(ctx context.Context, md metadata.MD) (*principal.Principal, error) {
+	token := sessionToken(md)
+	if token == "" {
+		return nil, ErrNoCredentials
+	}
+	claims, err := a.verifier.Verify(ctx, token)
+	if errors.Is(err, jwt.ErrKeySetUnavailable) {
+		return nil, status.Error(codes.Unavailable, "could not load session token keys")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("session token has no subject")
+	}
+	return &principal.Principal{ID: claims.Subject, Admin: claims.Admin}, nil
+}
+
+func sessionToken(md metadata.MD) string {
+	token := firstMetadataValue(md, SessionTokenHeader, runtime.MetadataPrefix+SessionTokenHeader)
+	if token != "" {
+		return token
+	}
+	// grpc-gateway forwards the authorization header as is
+	authorization := firstMetadataValue(md, "authorization")
+	if len(authorization) > len(bearerPrefix) && strings.EqualFold(authorization[:len(bearerPrefix)], bearerPrefix) {
+		return authorization[len(bearerPrefix):]
+	}
+	return ""
+}
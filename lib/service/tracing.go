@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"biller/lib/version"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.uber.org/zap"
+)
+
+const (
+	// TraceExporterOTLP sends spans to an OpenTelemetry collector over grpc
+	TraceExporterOTLP = "otlp"
+	// TraceExporterStdout writes spans to stdout, for local debugging
+	TraceExporterStdout = "stdout"
+)
+
+const tracingShutdownTimeout = 5 * time.Second
+
+// tracerName is the instrumentation name of spans started by the service package
+const tracerName = "biller/lib/service"
+
+type TracingConfig struct {
+	// Exporter is where spans are sent, either TraceExporterOTLP or TraceExporterStdout
+	Exporter string
+	// OTLPEndpoint is the host and port of the collector, localhost:4317 when not set. The collector is expected to
+	// run alongside the service so the connection isn't encrypted.
+	OTLPEndpoint string
+	// SampleRatio is the fraction of new traces that are recorded, all of them when not set. Traces started by a
+	// caller are recorded when the caller recorded them.
+	SampleRatio float64
+	// ServiceName is the name spans are reported under, the name of a background service when not set
+	ServiceName string
+}
+
+// setupTracing installs a global tracer provider exporting spans as configured and a w3c trace context propagator.
+// The returned function flushes any spans that haven't been exported yet.
+func setupTracing(ctx context.Context, config TracingConfig, environment string, log *zap.Logger) (func(), error) {
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch config.Exporter {
+	case TraceExporterOTLP:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithInsecure()}
+		if config.OTLPEndpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(config.OTLPEndpoint))
+		}
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	case TraceExporterStdout:
+		exporter, err = stdouttrace.New()
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", config.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s trace exporter: %w", config.Exporter, err)
+	}
+
+	sampler := sdktrace.AlwaysSample()
+	if config.SampleRatio > 0 {
+		sampler = sdktrace.TraceIDRatioBased(config.SampleRatio)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(config.ServiceName),
+			semconv.ServiceVersionKey.String(version.Version),
+			semconv.DeploymentEnvironmentKey.String(environment),
+		)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sampler)),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		log.Warn("opentelemetry error", zap.Error(err))
+	}))
+
+	return func() {
+		// don't use ctx as it's usually cancelled by the time the service shuts down
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer cancel()
+		if err := provider.Shutdown(shutdownCtx); err != nil {
+			log.Warn("could not flush spans", zap.Error(err))
+		}
+	}, nil
+}
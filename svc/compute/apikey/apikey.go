@@ -0,0 +1,297 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	"unicode/utf8"
+
+	"biller/lib/logger"
+	"biller/lib/pagination"
+	"biller/lib/principal"
+	"biller/lib/resource"
+	"biller/svc/compute/audit"
+	"biller/svc/compute/billingaccount"
+	"biller/svc/compute/store"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Api keys act for their billing account with the permissions of their role. Making a key is like adding a member so
+// it needs the same permissions, only owners can make owner keys.
+
+const maxDisplayNameLength = 100
+
+type server struct {
+	log     *zap.Logger
+	querier store.TxQuerier
+	UnimplementedApiKeyServiceServer
+}
+
+func NewServer(querier store.TxQuerier, log *zap.Logger) *server {
+	return &server{
+		querier: querier,
+		log:     log,
+	}
+}
+
+func (s *server) CreateApiKey(ctx context.Context, req *CreateApiKeyRequest) (*CreateApiKeyResponse, error) {
+	if !resource.ValidResourceID(req.BillingAccountId) {
+		return nil, status.Error(codes.InvalidArgument, "invalid billing account id")
+	}
+	if req.ApiKey == nil {
+		return nil, status.Error(codes.InvalidArgument, "api key is required")
+	}
+	role, ok := billingaccount.ParseRole(req.ApiKey.Role)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "role must be owner, admin, viewer or billing_viewer")
+	}
+	if utf8.RuneCountInString(req.ApiKey.DisplayName) > maxDisplayNameLength {
+		return nil, status.Errorf(codes.InvalidArgument, "display name must be at most %d characters", maxDisplayNameLength)
+	}
+	var expireTime sql.NullTime
+	if req.ApiKey.ExpireTime != nil {
+		expireTime = sql.NullTime{Time: req.ApiKey.ExpireTime.AsTime(), Valid: true}
+		if !expireTime.Time.After(time.Now()) {
+			return nil, status.Error(codes.InvalidArgument, "expire time must be in the future")
+		}
+	}
+
+	tx, txq, err := s.querier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	err = billingaccount.Authorize(ctx, txq, req.BillingAccountId, billingaccount.PermissionMembersManage)
+	if err != nil {
+		return nil, err
+	}
+	if role == store.BillingAccountRoleOwner {
+		err = billingaccount.Authorize(ctx, txq, req.BillingAccountId, billingaccount.PermissionOwnersManage)
+		if err != nil {
+			return nil, err
+		}
+	}
+	_, err = txq.FindBillingAccountById(ctx, req.BillingAccountId)
+	if err == pgx.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "billing account not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "could not find billing account")
+	}
+
+	key, prefix, err := newKey()
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("could not generate api key", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not create api key")
+	}
+	apiKey, err := txq.CreateApiKey(ctx, store.CreateApiKeyParams{
+		BillingAccountID: req.BillingAccountId,
+		DisplayName:      req.ApiKey.DisplayName,
+		Prefix:           prefix,
+		SecretHash:       hashKey(key),
+		Role:             role,
+		CreatedBy:        principal.Actor(ctx),
+		ExpireTime:       expireTime,
+	})
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when creating api key", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not create api key")
+	}
+	// audit the key without its secret, only its hash is kept
+	err = audit.Record(ctx, txq, apiKeyResourceName(apiKey), nil, toApiKeyPb(apiKey))
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when auditing api key creation", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not create api key")
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("transaction failed when creating api key", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not create api key")
+	}
+	logger.FromContext(ctx, s.log).Info("created api key",
+		zap.String("billingAccountId", apiKey.BillingAccountID),
+		zap.String("prefix", apiKey.Prefix),
+		zap.String("role", string(apiKey.Role)),
+		zap.String("createdBy", apiKey.CreatedBy))
+	return &CreateApiKeyResponse{ApiKey: toApiKeyPb(apiKey), Secret: key}, nil
+}
+
+func (s *server) GetApiKey(ctx context.Context, req *GetApiKeyRequest) (*ApiKey, error) {
+	if !resource.ValidResourceID(req.BillingAccountId) {
+		return nil, status.Error(codes.InvalidArgument, "invalid billing account id")
+	}
+	uid, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid api key id")
+	}
+
+	tx, txq, err := s.querier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	err = billingaccount.Authorize(ctx, txq, req.BillingAccountId, billingaccount.PermissionMembersList)
+	if err != nil {
+		return nil, err
+	}
+	apiKey, err := txq.FindApiKeyById(ctx, store.FindApiKeyByIdParams{
+		Uid:              uid,
+		BillingAccountID: req.BillingAccountId,
+	})
+	if err == pgx.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "api key not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "could not find api key")
+	}
+	return toApiKeyPb(apiKey), nil
+}
+
+func (s *server) ListApiKeys(ctx context.Context, req *ListApiKeysRequest) (*ListApiKeysResponse, error) {
+	var res ListApiKeysResponse
+
+	if !resource.ValidResourceID(req.BillingAccountId) {
+		return nil, status.Error(codes.InvalidArgument, "invalid billing account id")
+	}
+	pageSize := pagination.PageSize(req.PageSize)
+	fingerprint := pagination.Fingerprint("ListApiKeys", principal.Fingerprint(ctx), req.BillingAccountId)
+	cursor, err := pagination.Decode(req.PageToken, fingerprint)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+
+	tx, txq, err := s.querier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	err = billingaccount.Authorize(ctx, txq, req.BillingAccountId, billingaccount.PermissionMembersList)
+	if err != nil {
+		return nil, err
+	}
+	// fetch one extra row to find out whether there is another page
+	apiKeys, err := txq.ListApiKeysByBillingAccountId(ctx, store.ListApiKeysByBillingAccountIdParams{
+		BillingAccountID: req.BillingAccountId,
+		RowLimit:         pageSize + 1,
+		RowOffset:        cursor.Offset,
+	})
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when listing api keys", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not list api keys")
+	}
+
+	if len(apiKeys) > int(pageSize) {
+		apiKeys = apiKeys[:pageSize]
+		res.NextPageToken = pagination.Encode(cursor.Next(false, time.Time{}, "", pageSize), fingerprint)
+	}
+	res.PageSize = pageSize
+
+	res.ApiKeys = make([]*ApiKey, len(apiKeys))
+	for i, row := range apiKeys {
+		res.ApiKeys[i] = toApiKeyPb(row)
+	}
+	return &res, nil
+}
+
+func (s *server) RevokeApiKey(ctx context.Context, req *RevokeApiKeyRequest) (*ApiKey, error) {
+	if !resource.ValidResourceID(req.BillingAccountId) {
+		return nil, status.Error(codes.InvalidArgument, "invalid billing account id")
+	}
+	uid, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid api key id")
+	}
+
+	tx, txq, err := s.querier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	err = billingaccount.Authorize(ctx, txq, req.BillingAccountId, billingaccount.PermissionMembersManage)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := txq.FindApiKeyById(ctx, store.FindApiKeyByIdParams{
+		Uid:              uid,
+		BillingAccountID: req.BillingAccountId,
+	})
+	if err == pgx.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "api key not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "could not find api key")
+	}
+	if existing.Role == store.BillingAccountRoleOwner {
+		err = billingaccount.Authorize(ctx, txq, req.BillingAccountId, billingaccount.PermissionOwnersManage)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	apiKey, err := txq.RevokeApiKey(ctx, store.RevokeApiKeyParams{
+		Uid:              existing.Uid,
+		BillingAccountID: existing.BillingAccountID,
+		RevokedBy:        principal.Actor(ctx),
+	})
+	if err == pgx.ErrNoRows {
+		return nil, status.Error(codes.FailedPrecondition, "api key is already revoked")
+	}
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when revoking api key", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not revoke api key")
+	}
+	err = audit.Record(ctx, txq, apiKeyResourceName(apiKey), toApiKeyPb(existing), toApiKeyPb(apiKey))
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when auditing api key revocation", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not revoke api key")
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("transaction failed when revoking api key", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not revoke api key")
+	}
+	logger.FromContext(ctx, s.log).Info("revoked api key",
+		zap.String("billingAccountId", apiKey.BillingAccountID),
+		zap.String("prefix", apiKey.Prefix),
+		zap.String("revokedBy", apiKey.RevokedBy))
+	return toApiKeyPb(apiKey), nil
+}
+
+func apiKeyResourceName(apiKey store.ApiKey) string {
+	return "billing-accounts/" + apiKey.BillingAccountID + "/api-keys/" + apiKey.Uid.String()
+}
+
+func nullTimePb(t sql.NullTime) *timestamppb.Timestamp {
+	if !t.Valid {
+		return nil
+	}
+	return timestamppb.New(t.Time)
+}
+
+func toApiKeyPb(in store.ApiKey) *ApiKey {
+	return &ApiKey{
+		Id:               in.Uid.String(),
+		BillingAccountId: in.BillingAccountID,
+		DisplayName:      in.DisplayName,
+		Prefix:           in.Prefix,
+		Role:             string(in.Role),
+		CreatedBy:        in.CreatedBy,
+		CreateTime:       timestamppb.New(in.CreateTime),
+		ExpireTime:       nullTimePb(in.ExpireTime),
+		LastUsedTime:     nullTimePb(in.LastUsedTime),
+		RevokedBy:        in.RevokedBy,
+		RevokeTime:       nullTimePb(in.RevokeTime),
+	}
+}
@@ -0,0 +1,330 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"biller/lib/pagination"
+	"biller/lib/principal"
+	"biller/svc/compute/store"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type FakeTxQuerier struct {
+	store.TxQuerier
+	apiKey      store.ApiKey
+	apiKeyError error
+	apiKeys     []store.ApiKey
+	// billingAccountState is the state of every billing account, active when not set
+	billingAccountState store.BillingAccountState
+	createParams        *store.CreateApiKeyParams
+	members             []store.BillingAccountMember
+	touched             *uuid.UUID
+}
+
+type FakeTx struct {
+	pgx.Tx
+}
+
+func (tx FakeTx) Rollback(context.Context) error {
+	return nil
+}
+
+func (tx FakeTx) Commit(ctx context.Context) error {
+	return nil
+}
+
+func (q FakeTxQuerier) BeginTx(context.Context, pgx.TxOptions) (pgx.Tx, store.QueryLister, error) {
+	return FakeTx{}, q, nil
+}
+
+func (q FakeTxQuerier) FindBillingAccountById(ctx context.Context, id string) (store.BillingAccount, error) {
+	state := q.billingAccountState
+	if state == "" {
+		state = store.BillingAccountStateActive
+	}
+	return store.BillingAccount{ID: id, State: state}, nil
+}
+
+func (q FakeTxQuerier) FindBillingAccountMember(ctx context.Context, arg store.FindBillingAccountMemberParams) (store.BillingAccountMember, error) {
+	for _, member := range q.members {
+		if member.BillingAccountID == arg.BillingAccountID && member.PrincipalID == arg.PrincipalID {
+			return member, nil
+		}
+	}
+	return store.BillingAccountMember{}, pgx.ErrNoRows
+}
+
+func (q FakeTxQuerier) ListApiKeysByBillingAccountId(ctx context.Context, arg store.ListApiKeysByBillingAccountIdParams) ([]store.ApiKey, error) {
+	apiKeys := q.apiKeys
+	if int(arg.RowOffset) > len(apiKeys) {
+		return nil, nil
+	}
+	apiKeys = apiKeys[arg.RowOffset:]
+	if int(arg.RowLimit) < len(apiKeys) {
+		apiKeys = apiKeys[:arg.RowLimit]
+	}
+	return apiKeys, nil
+}
+
+func (q FakeTxQuerier) CreateApiKey(ctx context.Context, arg store.CreateApiKeyParams) (store.ApiKey, error) {
+	if q.createParams != nil {
+		*q.createParams = arg
+	}
+	return store.ApiKey{
+		Uid:              uuid.New(),
+		BillingAccountID: arg.BillingAccountID,
+		Prefix:           arg.Prefix,
+		Role:             arg.Role,
+		CreatedBy:        arg.CreatedBy,
+		ExpireTime:       arg.ExpireTime,
+	}, nil
+}
+
+func (q FakeTxQuerier) FindApiKeyById(ctx context.Context, arg store.FindApiKeyByIdParams) (store.ApiKey, error) {
+	return q.apiKey, q.apiKeyError
+}
+
+func (q FakeTxQuerier) FindApiKeyByPrefix(ctx context.Context, prefix string) (store.ApiKey, error) {
+	if q.apiKeyError != nil || q.apiKey.Prefix != prefix {
+		return store.ApiKey{}, pgx.ErrNoRows
+	}
+	return q.apiKey, nil
+}
+
+func (q FakeTxQuerier) RevokeApiKey(ctx context.Context, arg store.RevokeApiKeyParams) (store.ApiKey, error) {
+	if q.apiKey.RevokeTime.Valid {
+		return store.ApiKey{}, pgx.ErrNoRows
+	}
+	revoked := q.apiKey
+	revoked.RevokedBy = arg.RevokedBy
+	revoked.RevokeTime = sql.NullTime{Time: time.Now(), Valid: true}
+	return revoked, nil
+}
+
+func (q FakeTxQuerier) TouchApiKey(ctx context.Context, uid uuid.UUID) error {
+	if q.touched != nil {
+		*q.touched = uid
+	}
+	return nil
+}
+
+func systemContext() context.Context {
+	return principal.NewContext(context.Background(), &principal.Principal{ID: "system", Admin: true})
+}
+
+func userContext(id string) context.Context {
+	return principal.NewContext(context.Background(), &principal.Principal{ID: id})
+}
+
+func Test_parseKey(t *testing.T) {
+	key, prefix, err := newKey()
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	parsed, err := parseKey(key)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if parsed != prefix {
+		t.Errorf("expected: %s, got: %s", prefix, parsed)
+	}
+	for _, malformed := range []string{"", "bk_abc", "xx_" + key[3:], key[:len(key)-1], "bk_zzzzzzzzzzzz_" + key[16:]} {
+		_, err = parseKey(malformed)
+		if err == nil {
+			t.Errorf("expected %q to be malformed", malformed)
+		}
+	}
+}
+
+func Test_CreateApiKey(t *testing.T) {
+	members := []store.BillingAccountMember{
+		{BillingAccountID: "billing-account-id", PrincipalID: "admin", Role: store.BillingAccountRoleAdmin},
+		{BillingAccountID: "billing-account-id", PrincipalID: "viewer", Role: store.BillingAccountRoleViewer},
+	}
+	t.Run("should fail when a viewer creates a key", func(t *testing.T) {
+		server := NewServer(FakeTxQuerier{members: members}, zaptest.NewLogger(t))
+		_, err := server.CreateApiKey(userContext("viewer"), &CreateApiKeyRequest{BillingAccountId: "billing-account-id", ApiKey: &ApiKey{Role: "viewer"}})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("expected: %s, got: %s", codes.PermissionDenied, status.Code(err))
+		}
+	})
+	t.Run("should fail when an admin creates an owner key", func(t *testing.T) {
+		server := NewServer(FakeTxQuerier{members: members}, zaptest.NewLogger(t))
+		_, err := server.CreateApiKey(userContext("admin"), &CreateApiKeyRequest{BillingAccountId: "billing-account-id", ApiKey: &ApiKey{Role: "owner"}})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("expected: %s, got: %s", codes.PermissionDenied, status.Code(err))
+		}
+	})
+	t.Run("should fail when the key has already expired", func(t *testing.T) {
+		server := NewServer(FakeTxQuerier{members: members}, zaptest.NewLogger(t))
+		_, err := server.CreateApiKey(userContext("admin"), &CreateApiKeyRequest{
+			BillingAccountId: "billing-account-id",
+			ApiKey:           &ApiKey{Role: "viewer", ExpireTime: timestamppb.New(time.Now().Add(-time.Hour))},
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, status.Code(err))
+		}
+	})
+	t.Run("should store the hash of the key and return the key once", func(t *testing.T) {
+		var params store.CreateApiKeyParams
+		server := NewServer(FakeTxQuerier{members: members, createParams: &params}, zaptest.NewLogger(t))
+		res, err := server.CreateApiKey(userContext("admin"), &CreateApiKeyRequest{BillingAccountId: "billing-account-id", ApiKey: &ApiKey{Role: "viewer"}})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		prefix, err := parseKey(res.Secret)
+		if err != nil {
+			t.Fatalf("expected a well formed key, got: %v", err)
+		}
+		if prefix != params.Prefix || res.ApiKey.Prefix != prefix {
+			t.Errorf("expected prefix %s, got: %s", prefix, params.Prefix)
+		}
+		if string(params.SecretHash) != string(hashKey(res.Secret)) {
+			t.Errorf("expected the hash of the key to be stored")
+		}
+		if params.CreatedBy != "admin" || params.Role != store.BillingAccountRoleViewer {
+			t.Errorf("unexpected api key: %+v", params)
+		}
+	})
+}
+
+func Test_ListApiKeys(t *testing.T) {
+	t.Run("should return a page of keys and a token for the next one", func(t *testing.T) {
+		var querier FakeTxQuerier
+		for i := 0; i < pagination.MinPageSize+1; i++ {
+			querier.apiKeys = append(querier.apiKeys, store.ApiKey{Uid: uuid.New(), BillingAccountID: "billing-account-id"})
+		}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		res, err := server.ListApiKeys(systemContext(), &ListApiKeysRequest{BillingAccountId: "billing-account-id"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if len(res.ApiKeys) != pagination.MinPageSize {
+			t.Errorf("expected: %d, got: %d", pagination.MinPageSize, len(res.ApiKeys))
+		}
+		if res.NextPageToken == "" {
+			t.Fatalf("expected a next page token")
+		}
+
+		res, err = server.ListApiKeys(systemContext(), &ListApiKeysRequest{BillingAccountId: "billing-account-id", PageToken: res.NextPageToken})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if len(res.ApiKeys) != 1 || res.ApiKeys[0].Id != querier.apiKeys[pagination.MinPageSize].Uid.String() {
+			t.Errorf("expected the last key, got: %v", res.ApiKeys)
+		}
+		if res.NextPageToken != "" {
+			t.Errorf("expected no next page token, got: %s", res.NextPageToken)
+		}
+	})
+	t.Run("should fail when the page token is invalid", func(t *testing.T) {
+		server := NewServer(FakeTxQuerier{}, zaptest.NewLogger(t))
+		_, err := server.ListApiKeys(systemContext(), &ListApiKeysRequest{BillingAccountId: "billing-account-id", PageToken: "invalid"})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, status.Code(err))
+		}
+	})
+}
+
+func Test_RevokeApiKey(t *testing.T) {
+	t.Run("should fail when the key is already revoked", func(t *testing.T) {
+		querier := FakeTxQuerier{apiKey: store.ApiKey{Uid: uuid.New(), RevokeTime: sql.NullTime{Time: time.Now(), Valid: true}}}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.RevokeApiKey(systemContext(), &RevokeApiKeyRequest{BillingAccountId: "billing-account-id", Id: querier.apiKey.Uid.String()})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("expected: %s, got: %s", codes.FailedPrecondition, status.Code(err))
+		}
+	})
+	t.Run("should record who revoked the key", func(t *testing.T) {
+		querier := FakeTxQuerier{
+			apiKey:  store.ApiKey{Uid: uuid.New(), BillingAccountID: "billing-account-id", Role: store.BillingAccountRoleViewer},
+			members: []store.BillingAccountMember{{BillingAccountID: "billing-account-id", PrincipalID: "admin", Role: store.BillingAccountRoleAdmin}},
+		}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		res, err := server.RevokeApiKey(userContext("admin"), &RevokeApiKeyRequest{BillingAccountId: "billing-account-id", Id: querier.apiKey.Uid.String()})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if res.RevokedBy != "admin" || res.RevokeTime == nil {
+			t.Errorf("unexpected api key: %v", res)
+		}
+	})
+}
+
+func Test_VerifyAPIKey(t *testing.T) {
+	key, prefix, err := newKey()
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	apiKey := store.ApiKey{
+		Uid:              uuid.New(),
+		BillingAccountID: "billing-account-id",
+		Prefix:           prefix,
+		SecretHash:       hashKey(key),
+		Role:             store.BillingAccountRoleBillingViewer,
+	}
+	t.Run("should return a principal restricted to the billing account of the key", func(t *testing.T) {
+		var touched uuid.UUID
+		authenticator := NewAuthenticator(FakeTxQuerier{apiKey: apiKey, touched: &touched}, "", zaptest.NewLogger(t))
+		p, err := authenticator.VerifyAPIKey(context.Background(), key)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if p.Admin || p.BillingAccountID != "billing-account-id" || p.Role != "billing_viewer" || p.ID != "apikey/"+prefix {
+			t.Errorf("unexpected principal: %+v", p)
+		}
+		if touched != apiKey.Uid {
+			t.Errorf("expected the last used time of the key to be updated")
+		}
+	})
+	t.Run("should fail when the secret doesn't match", func(t *testing.T) {
+		other, _, _ := newKey()
+		authenticator := NewAuthenticator(FakeTxQuerier{apiKey: apiKey}, "", zaptest.NewLogger(t))
+		_, err := authenticator.VerifyAPIKey(context.Background(), "bk_"+prefix+other[len(prefix)+3:])
+		if err == nil {
+			t.Errorf("expected error, got nil")
+		}
+	})
+	t.Run("should fail when the key is revoked or expired", func(t *testing.T) {
+		revoked := apiKey
+		revoked.RevokeTime = sql.NullTime{Time: time.Now(), Valid: true}
+		expired := apiKey
+		expired.ExpireTime = sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true}
+		for _, k := range []store.ApiKey{revoked, expired} {
+			authenticator := NewAuthenticator(FakeTxQuerier{apiKey: k}, "", zaptest.NewLogger(t))
+			_, err := authenticator.VerifyAPIKey(context.Background(), key)
+			if err == nil {
+				t.Errorf("expected error, got nil")
+			}
+		}
+	})
+	t.Run("should fail when the billing account of the key isn't active", func(t *testing.T) {
+		for _, state := range []store.BillingAccountState{store.BillingAccountStateSuspended, store.BillingAccountStateClosed} {
+			authenticator := NewAuthenticator(FakeTxQuerier{apiKey: apiKey, billingAccountState: state}, "", zaptest.NewLogger(t))
+			_, err := authenticator.VerifyAPIKey(context.Background(), key)
+			if !errors.Is(err, errInactiveBillingAccount) {
+				t.Errorf("expected: %v, got: %v", errInactiveBillingAccount, err)
+			}
+		}
+	})
+	t.Run("should accept the admin key", func(t *testing.T) {
+		authenticator := NewAuthenticator(FakeTxQuerier{}, "bootstrap-secret", zaptest.NewLogger(t))
+		p, err := authenticator.VerifyAPIKey(context.Background(), "bootstrap-secret")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !p.Admin {
+			t.Errorf("expected an admin principal, got: %+v", p)
+		}
+	})
+}
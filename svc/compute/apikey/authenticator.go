@@ -0,0 +1,96 @@
+package apikey
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"time"
+
+	"biller/lib/logger"
+	"biller/lib/principal"
+	"biller/svc/compute/store"
+
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	errUnknownKey = errors.New("unknown api key")
+	errRevokedKey = errors.New("api key has been revoked")
+	errExpiredKey = errors.New("api key has expired")
+	// keys can't be used while their billing account is suspended or once it's closed
+	errInactiveBillingAccount = errors.New("billing account of the api key isn't active")
+)
+
+// Authenticator verifies the api keys requests are made with
+type Authenticator struct {
+	adminKeyHash []byte
+	log          *zap.Logger
+	querier      store.Querier
+}
+
+// NewAuthenticator verifies keys against the api keys of billing accounts. adminKey, when set, is a key for admin
+// requests that isn't stored, it exists so there is a way to create the first billing accounts and decide their
+// enablement requests.
+func NewAuthenticator(querier store.Querier, adminKey string, log *zap.Logger) *Authenticator {
+	a := &Authenticator{
+		log:     log,
+		querier: querier,
+	}
+	if adminKey != "" {
+		a.adminKeyHash = hashKey(adminKey)
+	}
+	return a
+}
+
+// VerifyAPIKey returns the principal of a key, it can use the billing account of the key with the permissions of its
+// role while the account is active
+func (a *Authenticator) VerifyAPIKey(ctx context.Context, key string) (*principal.Principal, error) {
+	hash := hashKey(key)
+	if a.adminKeyHash != nil && subtle.ConstantTimeCompare(hash, a.adminKeyHash) == 1 {
+		return &principal.Principal{ID: "admin", Admin: true}, nil
+	}
+
+	prefix, err := parseKey(key)
+	if err != nil {
+		return nil, err
+	}
+	apiKey, err := a.querier.FindApiKeyByPrefix(ctx, prefix)
+	if err == pgx.ErrNoRows {
+		return nil, errUnknownKey
+	}
+	if err != nil {
+		logger.FromContext(ctx, a.log).Error("query failed when finding api key", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not verify api key")
+	}
+	if subtle.ConstantTimeCompare(hash, apiKey.SecretHash) != 1 {
+		return nil, errUnknownKey
+	}
+	if apiKey.RevokeTime.Valid {
+		return nil, errRevokedKey
+	}
+	if apiKey.ExpireTime.Valid && !apiKey.ExpireTime.Time.After(time.Now()) {
+		return nil, errExpiredKey
+	}
+	billingAccount, err := a.querier.FindBillingAccountById(ctx, apiKey.BillingAccountID)
+	if err != nil {
+		logger.FromContext(ctx, a.log).Error("query failed when finding billing account of api key", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not verify api key")
+	}
+	if billingAccount.State != store.BillingAccountStateActive {
+		return nil, errInactiveBillingAccount
+	}
+
+	err = a.querier.TouchApiKey(ctx, apiKey.Uid)
+	if err != nil {
+		// not knowing when a key was last used shouldn't stop it from working
+		logger.FromContext(ctx, a.log).Warn("query failed when updating api key last used time", zap.String("prefix", apiKey.Prefix), zap.Error(err))
+	}
+	return &principal.Principal{
+		ID:               "apikey/" + apiKey.Prefix,
+		BillingAccountID: apiKey.BillingAccountID,
+		Role:             string(apiKey.Role),
+	}, nil
+}
@@ -0,0 +1,54 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// Keys look like bk_<prefix>_<secret>. The prefix finds the key without a scan and is safe to show, the secret is
+// only ever handed out when the key is created and just its hash is stored. Secrets have 256 bits of entropy so a
+// plain sha256 of the key is enough, there's nothing for a slow hash to protect against.
+
+const (
+	keyScheme    = "bk"
+	prefixBytes  = 6
+	secretBytes  = 32
+	keySeparator = "_"
+)
+
+var errMalformedKey = errors.New("malformed api key")
+
+// newKey returns a new key and its prefix
+func newKey() (key string, prefix string, err error) {
+	b := make([]byte, prefixBytes+secretBytes)
+	_, err = rand.Read(b)
+	if err != nil {
+		return "", "", err
+	}
+	prefix = hex.EncodeToString(b[:prefixBytes])
+	secret := base64.RawURLEncoding.EncodeToString(b[prefixBytes:])
+	return keyScheme + keySeparator + prefix + keySeparator + secret, prefix, nil
+}
+
+// parseKey returns the prefix of a key
+func parseKey(key string) (string, error) {
+	parts := strings.SplitN(key, keySeparator, 3)
+	if len(parts) != 3 || parts[0] != keyScheme || len(parts[1]) != hex.EncodedLen(prefixBytes) ||
+		len(parts[2]) != base64.RawURLEncoding.EncodedLen(secretBytes) {
+		return "", errMalformedKey
+	}
+	_, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return "", errMalformedKey
+	}
+	return parts[1], nil
+}
+
+func hashKey(key string) []byte {
+	sum := sha256.Sum256([]byte(key))
+	return sum[:]
+}
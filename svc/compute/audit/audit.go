@@ -0,0 +1,105 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+
+	"biller/lib/filter"
+	"biller/lib/logger"
+	"biller/lib/pagination"
+	"biller/lib/principal"
+	"biller/svc/compute/store"
+
+	"github.com/jackc/pgtype"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type server struct {
+	log     *zap.Logger
+	querier store.TxQuerier
+	UnimplementedAuditServiceServer
+}
+
+func NewServer(querier store.TxQuerier, log *zap.Logger) *server {
+	return &server{
+		querier: querier,
+		log:     log,
+	}
+}
+
+var auditEventFields = filter.Schema{
+	"method":        {Column: "method", Type: filter.String},
+	"principal":     {Column: "principal", Type: filter.String},
+	"resource_name": {Column: "resource_name", Type: filter.String},
+	"request_id":    {Column: "request_id", Type: filter.String},
+	"result_code":   {Column: "result_code", Type: filter.String},
+	"create_time":   {Column: "create_time", Type: filter.Time},
+}
+
+func (s *server) ListAuditEvents(ctx context.Context, req *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	var res ListAuditEventsResponse
+
+	if !principal.IsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "only admins can list audit events")
+	}
+	pageSize := pagination.PageSize(req.PageSize)
+	listFilter, err := filter.Parse(req.Filter, auditEventFields)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
+	}
+	orderBy, err := filter.ParseOrderBy(req.OrderBy, auditEventFields)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order_by: %v", err)
+	}
+	fingerprint := pagination.Fingerprint("ListAuditEvents", principal.Fingerprint(ctx), req.Filter, req.OrderBy)
+	cursor, err := pagination.Decode(req.PageToken, fingerprint)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+
+	// fetch one extra row to find out whether there is another page
+	events, err := s.querier.ListAuditEvents(ctx, store.ListParams{
+		Filter:  listFilter,
+		OrderBy: orderBy,
+		Cursor:  cursor,
+		Limit:   pageSize + 1,
+	})
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when listing audit events", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not list audit events")
+	}
+
+	if len(events) > int(pageSize) {
+		events = events[:pageSize]
+		last := events[len(events)-1]
+		res.NextPageToken = pagination.Encode(cursor.Next(orderBy == nil, last.CreateTime, last.ID, pageSize), fingerprint)
+	}
+	res.PageSize = pageSize
+
+	res.AuditEvents = make([]*AuditEvent, len(events))
+	for i, row := range events {
+		res.AuditEvents[i] = toAuditEventPb(row)
+	}
+	return &res, nil
+}
+
+func toAuditEventPb(row store.AuditLog) *AuditEvent {
+	event := &AuditEvent{
+		Id:           row.ID,
+		CreateTime:   timestamppb.New(row.CreateTime),
+		Method:       row.Method,
+		Principal:    row.Principal,
+		ResourceName: row.ResourceName,
+		RequestId:    row.RequestID,
+		ResultCode:   row.ResultCode,
+	}
+	var fields map[string]interface{}
+	if row.Diff.Status == pgtype.Present && json.Unmarshal(row.Diff.Bytes, &fields) == nil {
+		event.Diff, _ = structpb.NewStruct(fields)
+	}
+	return event
+}
@@ -0,0 +1,226 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"biller/lib/principal"
+	"biller/svc/compute/store"
+
+	"github.com/jackc/pgtype"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type FakeTxQuerier struct {
+	store.TxQuerier
+	events         *[]store.CreateAuditEventParams
+	createEventErr error
+	listEvents     []store.AuditLog
+	listParams     *store.ListParams
+}
+
+func (f FakeTxQuerier) CreateAuditEvent(ctx context.Context, arg store.CreateAuditEventParams) error {
+	if f.createEventErr != nil {
+		return f.createEventErr
+	}
+	*f.events = append(*f.events, arg)
+	return nil
+}
+
+func (f FakeTxQuerier) ListAuditEvents(ctx context.Context, arg store.ListParams) ([]store.AuditLog, error) {
+	*f.listParams = arg
+	return f.listEvents, nil
+}
+
+func systemContext() context.Context {
+	return principal.NewContext(context.Background(), &principal.Principal{ID: "system", Admin: true})
+}
+
+func decodeDiff(t *testing.T, diff pgtype.JSONB) map[string]interface{} {
+	t.Helper()
+	var fields map[string]interface{}
+	if err := json.Unmarshal(diff.Bytes, &fields); err != nil {
+		t.Fatalf("could not decode diff: %v", err)
+	}
+	return fields
+}
+
+func Test_mutating(t *testing.T) {
+	tests := map[string]bool{
+		"/org.cudo.compute.v1.ProjectService/CreateProject":          true,
+		"/org.cudo.compute.v1.BillingAccountService/SetMember":       true,
+		"/org.cudo.compute.v1.ProjectService/GetProject":             false,
+		"/org.cudo.compute.v1.AuditService/ListAuditEvents":          false,
+		"/grpc.health.v1.Health/Check":                               false,
+		"/grpc.reflection.v1alpha.ServerReflection/ServerReflection": false,
+	}
+	for method, want := range tests {
+		if got := mutating(method); got != want {
+			t.Errorf("mutating(%q): expected %v, got %v", method, want, got)
+		}
+	}
+}
+
+func Test_diff(t *testing.T) {
+	t.Run("should only include changed fields", func(t *testing.T) {
+		before := &AuditEvent{Id: "a", Method: "old", Principal: "p"}
+		after := &AuditEvent{Id: "a", Method: "new"}
+		got := diff(before, after)
+		want := map[string]interface{}{
+			"method":    map[string]interface{}{"old": "old", "new": "new"},
+			"principal": map[string]interface{}{"old": "p", "new": nil},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("expected: %v, got: %v", want, got)
+		}
+	})
+	t.Run("should include every field of a created resource", func(t *testing.T) {
+		var before *AuditEvent
+		got := diff(before, &AuditEvent{Id: "a"})
+		want := map[string]interface{}{
+			"id": map[string]interface{}{"old": nil, "new": "a"},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("expected: %v, got: %v", want, got)
+		}
+	})
+}
+
+func Test_Record(t *testing.T) {
+	t.Run("should not record calls that aren't audited", func(t *testing.T) {
+		events := []store.CreateAuditEventParams{}
+		err := Record(context.Background(), FakeTxQuerier{events: &events}, "projects/a", nil, &AuditEvent{Id: "a"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(events) != 0 {
+			t.Errorf("expected no events, got: %v", events)
+		}
+	})
+	t.Run("should record the change once instead of the request", func(t *testing.T) {
+		events := []store.CreateAuditEventParams{}
+		querier := FakeTxQuerier{events: &events}
+		interceptor := UnaryServerInterceptor(querier, zaptest.NewLogger(t))
+		ctx := principal.NewContext(context.Background(), &principal.Principal{ID: "user"})
+		info := &grpc.UnaryServerInfo{FullMethod: "/org.cudo.compute.v1.ProjectService/CreateProject"}
+		_, err := interceptor(ctx, &ListAuditEventsRequest{}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, Record(ctx, querier, "projects/a", nil, &AuditEvent{Id: "a"})
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(events) != 1 {
+			t.Fatalf("expected one event, got: %v", events)
+		}
+		if events[0].Method != info.FullMethod || events[0].Principal != "user" || events[0].ResourceName != "projects/a" || events[0].ResultCode != "OK" {
+			t.Errorf("unexpected event: %+v", events[0])
+		}
+		if _, ok := decodeDiff(t, events[0].Diff)["id"]; !ok {
+			t.Errorf("expected the diff to include the id, got: %s", events[0].Diff.Bytes)
+		}
+	})
+}
+
+func Test_UnaryServerInterceptor(t *testing.T) {
+	t.Run("should record failed calls with the request", func(t *testing.T) {
+		events := []store.CreateAuditEventParams{}
+		interceptor := UnaryServerInterceptor(FakeTxQuerier{events: &events}, zaptest.NewLogger(t))
+		info := &grpc.UnaryServerInfo{FullMethod: "/org.cudo.compute.v1.ProjectService/DeleteProject"}
+		_, err := interceptor(systemContext(), &ListAuditEventsRequest{Filter: "x"}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, status.Error(codes.NotFound, "not found")
+		})
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("expected: %s, got: %v", codes.NotFound, err)
+		}
+		if len(events) != 1 {
+			t.Fatalf("expected one event, got: %v", events)
+		}
+		if events[0].ResultCode != "NotFound" || events[0].Principal != principal.Actor(context.Background()) {
+			t.Errorf("unexpected event: %+v", events[0])
+		}
+		want := map[string]interface{}{"request": map[string]interface{}{"filter": "x"}}
+		if got := decodeDiff(t, events[0].Diff); !reflect.DeepEqual(got, want) {
+			t.Errorf("expected: %v, got: %v", want, got)
+		}
+	})
+	t.Run("should not record reads", func(t *testing.T) {
+		events := []store.CreateAuditEventParams{}
+		interceptor := UnaryServerInterceptor(FakeTxQuerier{events: &events}, zaptest.NewLogger(t))
+		info := &grpc.UnaryServerInfo{FullMethod: "/org.cudo.compute.v1.ProjectService/GetProject"}
+		_, err := interceptor(systemContext(), &ListAuditEventsRequest{}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(events) != 0 {
+			t.Errorf("expected no events, got: %v", events)
+		}
+	})
+	t.Run("should return the handler result when recording fails", func(t *testing.T) {
+		interceptor := UnaryServerInterceptor(FakeTxQuerier{createEventErr: errors.New("failure")}, zaptest.NewLogger(t))
+		info := &grpc.UnaryServerInfo{FullMethod: "/org.cudo.compute.v1.ProjectService/DeleteProject"}
+		res, err := interceptor(systemContext(), &ListAuditEventsRequest{}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "ok", nil
+		})
+		if err != nil || res != "ok" {
+			t.Errorf("expected the handler result, got: %v, %v", res, err)
+		}
+	})
+}
+
+func Test_ListAuditEvents(t *testing.T) {
+	t.Run("should only allow admins", func(t *testing.T) {
+		server := NewServer(FakeTxQuerier{}, zaptest.NewLogger(t))
+		ctx := principal.NewContext(context.Background(), &principal.Principal{ID: "user"})
+		_, err := server.ListAuditEvents(ctx, &ListAuditEventsRequest{})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("expected: %s, got: %v", codes.PermissionDenied, err)
+		}
+	})
+	t.Run("should reject unknown filter fields", func(t *testing.T) {
+		server := NewServer(FakeTxQuerier{}, zaptest.NewLogger(t))
+		_, err := server.ListAuditEvents(systemContext(), &ListAuditEventsRequest{Filter: `diff = "x"`})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %v", codes.InvalidArgument, err)
+		}
+	})
+	t.Run("should return events with their diff", func(t *testing.T) {
+		var params store.ListParams
+		querier := FakeTxQuerier{
+			listParams: &params,
+			listEvents: []store.AuditLog{{
+				ID:           "1",
+				CreateTime:   time.Now(),
+				Method:       "/org.cudo.compute.v1.ProjectService/CreateProject",
+				ResourceName: "projects/a",
+				ResultCode:   "OK",
+				Diff:         pgtype.JSONB{Bytes: []byte(`{"id":{"old":null,"new":"a"}}`), Status: pgtype.Present},
+			}},
+		}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		res, err := server.ListAuditEvents(systemContext(), &ListAuditEventsRequest{Filter: `resource_name = "projects/a"`})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if params.Filter == nil {
+			t.Errorf("expected the filter to be passed to the query")
+		}
+		if len(res.AuditEvents) != 1 || res.AuditEvents[0].ResourceName != "projects/a" {
+			t.Fatalf("unexpected events: %v", res.AuditEvents)
+		}
+		if res.AuditEvents[0].Diff.Fields["id"].GetStructValue().Fields["new"].GetStringValue() != "a" {
+			t.Errorf("unexpected diff: %v", res.AuditEvents[0].Diff)
+		}
+		if res.NextPageToken != "" {
+			t.Errorf("expected no next page, got: %s", res.NextPageToken)
+		}
+	})
+}
@@ -0,0 +1,144 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"biller/lib/logger"
+	"biller/lib/principal"
+	"biller/lib/service"
+	"biller/svc/compute/store"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Every call of a mutating method of the compute services is audited. Handlers record the change they make with
+// Record in the transaction that makes it, so the event is only kept when the change is. Calls that fail, or that
+// don't record their change, are recorded by the interceptor after the handler returns with the request in place of
+// the diff.
+
+// servicePrefix is the package of the methods that are audited, meta services such as health checks are not
+const servicePrefix = "/org.cudo.compute.v1."
+
+// the interceptor records events after the call, when its context may have been cancelled
+const recordTimeout = 5 * time.Second
+
+type contextKey struct{}
+
+// event is the audit event of the call being handled
+type event struct {
+	method   string
+	recorded bool
+}
+
+// mutating reports whether a method changes anything, every method of the compute services that doesn't get or list
+func mutating(method string) bool {
+	if !strings.HasPrefix(method, servicePrefix) {
+		return false
+	}
+	name := method[strings.LastIndex(method, "/")+1:]
+	return !strings.HasPrefix(name, "Get") && !strings.HasPrefix(name, "List")
+}
+
+// UnaryServerInterceptor audits calls of mutating methods, recording them with querier when the handler didn't
+func UnaryServerInterceptor(querier store.Querier, log *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !mutating(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		e := &event{method: info.FullMethod}
+		res, err := handler(context.WithValue(ctx, contextKey{}, e), req)
+		// a handler that recorded its change and then failed rolled the event back with the change
+		if e.recorded && err == nil {
+			return res, err
+		}
+
+		requestDiff := map[string]interface{}{}
+		if msg, ok := req.(proto.Message); ok {
+			requestDiff["request"] = toMap(msg)
+		}
+		params, paramsErr := eventParams(ctx, e.method, "", requestDiff, status.Code(err))
+		if paramsErr == nil {
+			recordCtx, cancel := context.WithTimeout(context.Background(), recordTimeout)
+			paramsErr = querier.CreateAuditEvent(recordCtx, params)
+			cancel()
+		}
+		if paramsErr != nil {
+			logger.FromContext(ctx, log).Error("could not record audit event", zap.String("resultCode", params.ResultCode), zap.Error(paramsErr))
+		}
+		return res, err
+	}
+}
+
+// Record writes the audit event of the call being handled with q, which should be the transaction making the change
+// so the event is committed or rolled back with it. before is nil when the resource was created and after when it
+// was deleted. Calls that aren't audited, such as internal ones, aren't recorded.
+func Record(ctx context.Context, q store.Querier, resourceName string, before proto.Message, after proto.Message) error {
+	e, ok := ctx.Value(contextKey{}).(*event)
+	if !ok {
+		return nil
+	}
+	params, err := eventParams(ctx, e.method, resourceName, diff(before, after), codes.OK)
+	if err != nil {
+		return err
+	}
+	err = q.CreateAuditEvent(ctx, params)
+	if err != nil {
+		return err
+	}
+	e.recorded = true
+	return nil
+}
+
+func eventParams(ctx context.Context, method string, resourceName string, changes map[string]interface{}, code codes.Code) (store.CreateAuditEventParams, error) {
+	params := store.CreateAuditEventParams{
+		Method:       method,
+		Principal:    principal.Actor(ctx),
+		ResourceName: resourceName,
+		RequestID:    service.RequestIDFromContext(ctx),
+		ResultCode:   code.String(),
+	}
+	err := params.Diff.Set(changes)
+	return params, err
+}
+
+// diff returns the old and new values of the fields that differ between before and after, either may be nil
+func diff(before proto.Message, after proto.Message) map[string]interface{} {
+	old, updated := toMap(before), toMap(after)
+	changes := map[string]interface{}{}
+	for field, value := range updated {
+		if !reflect.DeepEqual(old[field], value) {
+			changes[field] = map[string]interface{}{"old": old[field], "new": value}
+		}
+	}
+	for field, value := range old {
+		if _, ok := updated[field]; !ok {
+			changes[field] = map[string]interface{}{"old": value, "new": nil}
+		}
+	}
+	return changes
+}
+
+// toMap returns the fields of a message as they are shown in the json api, unset fields are left out
+func toMap(msg proto.Message) map[string]interface{} {
+	if msg == nil || !msg.ProtoReflect().IsValid() {
+		return nil
+	}
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+	return fields
+}
@@ -0,0 +1,141 @@
+package billingaccount
+
+import (
+	"context"
+
+	"biller/lib/principal"
+	"biller/svc/compute/store"
+
+	"github.com/jackc/pgx/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Access to a billing account and its projects is granted by the role of the principal's membership of the account.
+// Admin principals have every permission, requests not made on behalf of a principal have none. A principal that isn't
+// a member is told the billing account doesn't exist, a member without the permission is denied. Principals restricted
+// to one billing account, e.g. api keys, have the permissions of their own role on it and nothing else.
+
+type Permission string
+
+const (
+	PermissionBillingAccountGet    Permission = "billing_accounts.get"
+	PermissionBillingAccountUpdate Permission = "billing_accounts.update"
+	PermissionBillingAccountClose  Permission = "billing_accounts.close"
+	PermissionEnablementRequest    Permission = "billing_accounts.request_enablement"
+	PermissionMembersList          Permission = "members.list"
+	PermissionMembersManage        Permission = "members.manage"
+	PermissionOwnersManage         Permission = "members.manage_owners"
+	PermissionSpendGet             Permission = "spend.get"
+	PermissionProjectsGet          Permission = "projects.get"
+	PermissionProjectsCreate       Permission = "projects.create"
+	PermissionProjectsUpdate       Permission = "projects.update"
+	PermissionProjectsDelete       Permission = "projects.delete"
+)
+
+var rolePermissions = map[store.BillingAccountRole]map[Permission]bool{
+	store.BillingAccountRoleOwner: {
+		PermissionBillingAccountGet:    true,
+		PermissionBillingAccountUpdate: true,
+		PermissionBillingAccountClose:  true,
+		PermissionEnablementRequest:    true,
+		PermissionMembersList:          true,
+		PermissionMembersManage:        true,
+		PermissionOwnersManage:         true,
+		PermissionSpendGet:             true,
+		PermissionProjectsGet:          true,
+		PermissionProjectsCreate:       true,
+		PermissionProjectsUpdate:       true,
+		PermissionProjectsDelete:       true,
+	},
+	// everything an owner can do apart from closing the account, admins can't add or remove owners
+	store.BillingAccountRoleAdmin: {
+		PermissionBillingAccountGet:    true,
+		PermissionBillingAccountUpdate: true,
+		PermissionEnablementRequest:    true,
+		PermissionMembersList:          true,
+		PermissionMembersManage:        true,
+		PermissionSpendGet:             true,
+		PermissionProjectsGet:          true,
+		PermissionProjectsCreate:       true,
+		PermissionProjectsUpdate:       true,
+		PermissionProjectsDelete:       true,
+	},
+	store.BillingAccountRoleViewer: {
+		PermissionBillingAccountGet: true,
+		PermissionMembersList:       true,
+		PermissionProjectsGet:       true,
+	},
+	store.BillingAccountRoleBillingViewer: {
+		PermissionBillingAccountGet: true,
+		PermissionSpendGet:          true,
+	},
+}
+
+// HasPermission reports whether a role grants a permission
+func HasPermission(role store.BillingAccountRole, permission Permission) bool {
+	return rolePermissions[role][permission]
+}
+
+// Authorize checks the principal of the request has a permission on a billing account
+func Authorize(ctx context.Context, q store.Querier, billingAccountID string, permission Permission) error {
+	if principal.IsAdmin(ctx) {
+		return nil
+	}
+	p, ok := principal.FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "credentials are required")
+	}
+	if p.BillingAccountID != "" {
+		if p.BillingAccountID != billingAccountID {
+			return status.Error(codes.NotFound, "billing account not found")
+		}
+		if !HasPermission(store.BillingAccountRole(p.Role), permission) {
+			return status.Errorf(codes.PermissionDenied, "missing permission %s", permission)
+		}
+		return nil
+	}
+	member, err := q.FindBillingAccountMember(ctx, store.FindBillingAccountMemberParams{
+		BillingAccountID: billingAccountID,
+		PrincipalID:      p.ID,
+	})
+	if err == pgx.ErrNoRows {
+		return status.Error(codes.NotFound, "billing account not found")
+	}
+	if err != nil {
+		return status.Error(codes.Internal, "could not check permission")
+	}
+	if !HasPermission(member.Role, permission) {
+		return status.Errorf(codes.PermissionDenied, "missing permission %s", permission)
+	}
+	return nil
+}
+
+// Scope returns the billing accounts listings should be restricted to for a permission. all is true when nothing
+// should be filtered out.
+func Scope(ctx context.Context, q store.Querier, permission Permission) (all bool, billingAccountIDs []string, err error) {
+	if principal.IsAdmin(ctx) {
+		return true, []string{}, nil
+	}
+	p, ok := principal.FromContext(ctx)
+	if !ok {
+		return false, nil, status.Error(codes.Unauthenticated, "credentials are required")
+	}
+	if p.BillingAccountID != "" {
+		if !HasPermission(store.BillingAccountRole(p.Role), permission) {
+			return false, []string{}, nil
+		}
+		return false, []string{p.BillingAccountID}, nil
+	}
+	memberships, err := q.ListBillingAccountMembershipsByPrincipalId(ctx, p.ID)
+	if err != nil {
+		return false, nil, status.Error(codes.Internal, "could not check permission")
+	}
+	billingAccountIDs = []string{}
+	for _, member := range memberships {
+		if HasPermission(member.Role, permission) {
+			billingAccountIDs = append(billingAccountIDs, member.BillingAccountID)
+		}
+	}
+	return false, billingAccountIDs, nil
+}
@@ -3,13 +3,17 @@ package billingaccount
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
 	"biller/lib/conv"
+	"biller/lib/logger"
 	"biller/svc/compute/store"
 
 	"github.com/cockroachdb/apd/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"go.uber.org/zap"
 )
 
@@ -32,22 +36,114 @@ func (b *Biller) Run(ctx context.Context) error {
 	billingAccounts, err := b.querier.ListAllBillingAccounts(ctx)
 
 	if err != nil {
-		b.log.Error("list billing accounts failed", zap.Error(err))
+		logger.FromContext(ctx, b.log).Error("list billing accounts failed", zap.Error(err))
 		return err
 	}
 
 	// TODO: Set start/end times based on ctx or run parameters
 	// This is definitely something that could benefit from being run in temporal
-	// endTime and startTime are currently the first & last nanoseconds of the current month
+	// every period is billed even when one fails, the run fails if any of them did. Accounts are billed for the periods
+	// of their own time zone.
 	now := time.Now()
-	startTime := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
-	endTime := startTime.AddDate(0, 1, 0)
-
-	err = b.calculateDemandSpend(ctx, billingAccounts, startTime, endTime)
-	b.log.Error("error calculating demand spend: %v", zap.Error(err))
+	var failed []error
+	var billed int
+	for _, group := range GroupByBillingLocation(billingAccounts) {
+		periods := billingPeriods(now.In(group.Location))
+		for _, period := range periods {
+			billed++
+			err = b.calculateDemandSpend(ctx, group.BillingAccounts, period.start, period.end)
+			if err != nil {
+				logger.FromContext(ctx, b.log).Error("error calculating demand spend", zap.Time("startTime", period.start), zap.Error(err))
+				failed = append(failed, err)
+			}
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("billing failed for %d of %d periods: %w", len(failed), billed, failed[0])
+	}
 	return nil
 }
 
+// closingWindow is how long the biller keeps billing a period after it ends, the sealer leaves it unsealed for longer
+const closingWindow = 24 * time.Hour
+
+type billingPeriod struct {
+	start time.Time
+	end   time.Time
+}
+
+// billingPeriods returns the periods billed at now, the current one and, for the closingWindow after it ended, the
+// one before so the run right after month end bills its last hours
+func billingPeriods(now time.Time) []billingPeriod {
+	startTime, endTime := BillingPeriod(now)
+	periods := []billingPeriod{{start: startTime, end: endTime}}
+	if now.Sub(startTime) < closingWindow {
+		previousStart, previousEnd := BillingPeriod(startTime.Add(-time.Nanosecond))
+		periods = append([]billingPeriod{{start: previousStart, end: previousEnd}}, periods...)
+	}
+	return periods
+}
+
+// BillingPeriod returns the period that t is billed in, endTime and startTime are the first & last nanoseconds of the
+// month
+func BillingPeriod(t time.Time) (startTime time.Time, endTime time.Time) {
+	startTime = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	return startTime, startTime.AddDate(0, 1, 0)
+}
+
+// BillingLocation returns the time zone the billing periods of an account are in, accounts without a valid one are
+// billed in UTC
+func BillingLocation(account store.BillingAccount) *time.Location {
+	if account.Timezone == "" || account.Timezone == "Local" {
+		return time.UTC
+	}
+	location, err := time.LoadLocation(account.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// AccountBillingPeriod returns the period of a billing account that t is billed in, its month in the account's time
+// zone
+func AccountBillingPeriod(account store.BillingAccount, t time.Time) (startTime time.Time, endTime time.Time) {
+	return BillingPeriod(t.In(BillingLocation(account)))
+}
+
+// LocatedBillingAccounts are billing accounts whose billing periods are in the same time zone
+type LocatedBillingAccounts struct {
+	Location        *time.Location
+	BillingAccounts []store.BillingAccount
+}
+
+// GroupByBillingLocation groups billing accounts by the time zone of their billing periods, in the order the time
+// zones first appear
+func GroupByBillingLocation(billingAccounts []store.BillingAccount) []LocatedBillingAccounts {
+	var groups []LocatedBillingAccounts
+	index := make(map[string]int)
+	for _, billingAccount := range billingAccounts {
+		location := BillingLocation(billingAccount)
+		i, ok := index[location.String()]
+		if !ok {
+			i = len(groups)
+			index[location.String()] = i
+			groups = append(groups, LocatedBillingAccounts{Location: location})
+		}
+		groups[i].BillingAccounts = append(groups[i].BillingAccounts, billingAccount)
+	}
+	return groups
+}
+
+// errSpendSealed is returned when the spend of the period was sealed, it can't be changed any more
+var errSpendSealed = errors.New("billing account spend is sealed")
+
+// define apd context
+var apdContext = apd.Context{
+	MaxExponent: 65,
+	MinExponent: -18,
+	Precision:   65,
+}
+
 type DemandSpend struct {
 	BillingAccountID string
 	Spend            *apd.Decimal
@@ -69,147 +165,397 @@ type OrderSpend struct {
 // Get billing account -> orders -> leases
 func (b *Biller) calculateDemandSpend(ctx context.Context, billingAccounts []store.BillingAccount, startTime time.Time, endTime time.Time) error {
 	for _, billingAccount := range billingAccounts {
-		spend := &DemandSpend{
-			BillingAccountID: billingAccount.ID,
-			Projects:         make(map[string]*ProjectSpend),
-			Spend:            apd.New(0, 0),
+		// closed accounts had their final invoice issued when they were closed, billing them again would overwrite it
+		if billingAccount.CloseTime.Valid {
+			continue
+		}
+		_, err := billAccount(ctx, b.querier, billingAccount.ID, startTime, endTime)
+		if errors.Is(err, errSpendSealed) {
+			logger.FromContext(ctx, b.log).Info("spend of the period is sealed", zap.String("billingAccountId", billingAccount.ID))
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// billAccount calculates and writes the spend of every order billed to a billing account in a period, the spend of
+// their projects and the spend of the account
+func billAccount(ctx context.Context, querier store.Querier, billingAccountID string, startTime time.Time, endTime time.Time) (store.BillingAccountSpend, error) {
+	spend := &DemandSpend{
+		BillingAccountID: billingAccountID,
+		Projects:         make(map[string]*ProjectSpend),
+		Spend:            apd.New(0, 0),
+	}
+
+	// the order and project spend break sealed spend down so they can't change either, check before writing any of it
+	sealed, err := querier.IsBillingAccountSpendSealed(ctx, store.IsBillingAccountSpendSealedParams{
+		BillingAccountID: billingAccountID,
+		StartTime:        startTime,
+		EndTime:          endTime,
+	})
+	if err != nil {
+		return store.BillingAccountSpend{}, fmt.Errorf("check billing account spend sealed failed: %w", err)
+	}
+	if sealed {
+		return store.BillingAccountSpend{}, errSpendSealed
+	}
+
+	orders, err := querier.ListOrdersByBillingAccountId(ctx, billingAccountID) // TODO paginate this at some point
+
+	if err != nil {
+		return store.BillingAccountSpend{}, fmt.Errorf("error when listing orders for billing account: %w", err)
+	}
+	// orders moved to another billing account are still billed here up to the transfer
+	transferred, err := querier.ListOrdersTransferredFromBillingAccount(ctx, store.ListOrdersTransferredFromBillingAccountParams{
+		BillingAccountID: billingAccountID,
+		StartTime:        startTime,
+	})
+	if err != nil {
+		return store.BillingAccountSpend{}, fmt.Errorf("error when listing orders transferred from billing account: %w", err)
+	}
+	orders = append(orders, transferred...)
+
+	for _, order := range orders {
+		if _, ok := spend.Projects[order.ProjectID]; !ok {
+			spend.Projects[order.ProjectID] = &ProjectSpend{
+				ProjectID: order.ProjectID,
+				Orders:    make(map[string]*OrderSpend),
+				Spend:     apd.New(0, 0),
+			}
 		}
 
-		orders, err := b.querier.ListOrdersByBillingAccountId(ctx, billingAccount.ID) // TODO paginate this at some point
+		orderSpend, err := calculateOrderSpend(ctx, querier, order, billingAccountID, startTime, endTime)
+		if err != nil {
+			return store.BillingAccountSpend{}, err
+		}
+		spend.Projects[order.ProjectID].Orders[order.ID] = orderSpend
 
+		_, err = apdContext.Add(
+			spend.Projects[order.ProjectID].Spend,
+			spend.Projects[order.ProjectID].Spend,
+			orderSpend.Spend,
+		)
+		if err != nil {
+			return store.BillingAccountSpend{}, fmt.Errorf("error adding order spend to project spend: %w", err)
+		}
+		_, err = apdContext.Add(
+			spend.Spend,
+			spend.Spend,
+			orderSpend.Spend,
+		)
+		if err != nil {
+			return store.BillingAccountSpend{}, fmt.Errorf("error adding order spend to billing account spend: %w", err)
+		}
+		// write order spend
+		_, err = querier.CreateOrderSpend(ctx, store.CreateOrderSpendParams{
+			Uid:              uuid.New(),
+			OrderID:          order.ID,
+			BillingAccountID: billingAccountID,
+			Spend:            *orderSpend.Spend,
+			StartTime:        startTime,
+			EndTime:          endTime,
+		})
 		if err != nil {
-			return fmt.Errorf("error when listing orders for billing account: %w", err)
+			return store.BillingAccountSpend{}, fmt.Errorf("create order spend failed: %w", err)
 		}
+		// write project spend
+		_, err = querier.CreateProjectSpend(ctx, store.CreateProjectSpendParams{
+			Uid:              uuid.New(),
+			ProjectID:        order.ProjectID,
+			BillingAccountID: billingAccountID,
+			Spend:            *spend.Projects[order.ProjectID].Spend,
+			StartTime:        startTime,
+			EndTime:          endTime,
+		})
+		if err != nil {
+			return store.BillingAccountSpend{}, fmt.Errorf("create project spend failed: %w", err)
+		}
+	}
+	// sla credits issued against this period are reported alongside the spend
+	credit, err := querier.SumSLACreditsForBillingAccount(ctx, store.SumSLACreditsForBillingAccountParams{
+		BillingAccountID: billingAccountID,
+		ApplyStartTime:   startTime,
+		ApplyEndTime:     endTime,
+	})
+	if err != nil {
+		return store.BillingAccountSpend{}, fmt.Errorf("sum sla credits for billing account failed: %w", err)
+	}
+	// write billing account spend
+	accountSpend, err := querier.CreateBillingAccountSpend(ctx, store.CreateBillingAccountSpendParams{
+		Uid:              uuid.New(),
+		BillingAccountID: billingAccountID,
+		Spend:            *spend.Spend,
+		Credit:           credit,
+		StartTime:        startTime,
+		EndTime:          endTime,
+	})
+	if err == pgx.ErrNoRows {
+		return store.BillingAccountSpend{}, errSpendSealed
+	}
+	if err != nil {
+		return store.BillingAccountSpend{}, fmt.Errorf("create billing account spend failed: %w", err)
+	}
+	return accountSpend, nil
+}
 
-		for _, order := range orders {
-			if _, ok := spend.Projects[order.ProjectID]; !ok {
-				spend.Projects[order.ProjectID] = &ProjectSpend{
-					ProjectID: order.ProjectID,
-					Orders:    make(map[string]*OrderSpend),
-					Spend:     apd.New(0, 0),
-				}
-			}
-			spend.Projects[order.ProjectID].Orders[order.ID] = &OrderSpend{
-				OrderID: order.ID,
-				Spend:   apd.New(0, 0),
-			}
+// calculateLeaseSpend returns the spend of a lease for the hours it was active in the specified time range
+func calculateLeaseSpend(lease store.Lease, startTime time.Time, endTime time.Time) (*apd.Decimal, error) {
+	var (
+		leaseStartTime time.Time
+		leaseEndTime   time.Time
+	)
 
-			leases, err := b.querier.ListLeasesForTimeRangeByOrderId(ctx, store.ListLeasesForTimeRangeByOrderIdParams{
-				OrderID: order.ID,
-				StartTime: sql.NullTime{
-					Time:  startTime,
-					Valid: true,
-				},
-				EndTime: endTime,
-			})
+	if lease.CreateTime.After(startTime) {
+		leaseStartTime = lease.CreateTime
+	} else {
+		leaseStartTime = startTime
+	}
+
+	if lease.EndTime.Time.Before(endTime) && lease.EndTime.Valid {
+		leaseEndTime = lease.EndTime.Time
+	} else {
+		leaseEndTime = endTime.Add(time.Nanosecond * -1)
+	}
+
+	leaseDuration := leaseEndTime.Sub(leaseStartTime)
+	leaseHours := leaseDuration.Hours()
+	// convert priceHr to decimal from float64
+	priceHrDecimal, err := conv.FromFloat(lease.PriceHr)
+	if err != nil {
+		return nil, fmt.Errorf("error converting price hour to decimal: %w", err)
+	}
+	// convert leaseHours to decimal from float64
+	leaseHoursDecimal, err := conv.FromFloat(leaseHours)
+	if err != nil {
+		return nil, fmt.Errorf("error converting lease hours to decimal: %w", err)
+	}
+
+	leaseSpend := apd.New(0, 0)
+	cond, err := apdContext.Mul(leaseSpend, &leaseHoursDecimal, &priceHrDecimal)
+	if err != nil {
+		return nil, fmt.Errorf("error calculating lease spend: %w", err)
+	}
+	if cond.Any() {
+		return nil, fmt.Errorf("error calculating lease spend: %w", err)
+	}
+	return leaseSpend, nil
+}
+
+type billingWindow struct {
+	startTime time.Time
+	endTime   time.Time
+}
+
+// billingWindows returns the parts of the billing period in which the order was billed to the billing account. The
+// transfers are the moves of the order since startTime, oldest first, before the first one the order belonged to the
+// account it was moved from.
+func billingWindows(order store.Order, billingAccountID string, transfers []store.ListOrderTransfersRow, startTime time.Time, endTime time.Time) []billingWindow {
+	owner := order.BillingAccountID
+	if len(transfers) > 0 {
+		owner = transfers[0].FromBillingAccountID
+	}
+
+	var windows []billingWindow
+	from := startTime
+	for _, transfer := range transfers {
+		if !transfer.TransferTime.Before(endTime) {
+			break
+		}
+		if owner == billingAccountID && transfer.TransferTime.After(from) {
+			windows = append(windows, billingWindow{startTime: from, endTime: transfer.TransferTime})
+		}
+		from = transfer.TransferTime
+		owner = transfer.ToBillingAccountID
+	}
+	if owner == billingAccountID && from.Before(endTime) {
+		windows = append(windows, billingWindow{startTime: from, endTime: endTime})
+	}
+	return windows
+}
+
+// calculateOrderSpend returns the spend of the leases of an order charged to the billing account in the specified
+// time range
+func calculateOrderSpend(ctx context.Context, querier store.Querier, order store.Order, billingAccountID string, startTime time.Time, endTime time.Time) (*OrderSpend, error) {
+	spend := &OrderSpend{
+		OrderID: order.ID,
+		Spend:   apd.New(0, 0),
+	}
 
+	transfers, err := querier.ListOrderTransfers(ctx, store.ListOrderTransfersParams{
+		OrderID:   order.ID,
+		StartTime: startTime,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list order transfers failed: %w", err)
+	}
+
+	for _, window := range billingWindows(order, billingAccountID, transfers, startTime, endTime) {
+		leases, err := querier.ListLeasesForTimeRangeByOrderId(ctx, store.ListLeasesForTimeRangeByOrderIdParams{
+			OrderID: order.ID,
+			StartTime: sql.NullTime{
+				Time:  window.startTime,
+				Valid: true,
+			},
+			EndTime: window.endTime,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list leases for time range by order id failed: %w", err)
+		}
+
+		for _, lease := range leases {
+			leaseSpend, err := calculateLeaseSpend(lease, window.startTime, window.endTime)
 			if err != nil {
-				return fmt.Errorf("list leases for time range by order id failed: %w", err)
+				return nil, err
+			}
+			cond, err := apdContext.Add(spend.Spend, spend.Spend, leaseSpend)
+			if err != nil {
+				return nil, fmt.Errorf("error adding lease spend to order spend: %w", err)
+			}
+			if cond.Any() {
+				return nil, fmt.Errorf("error adding lease spend to order spend: %s", cond)
 			}
+		}
+	}
+	return spend, nil
+}
 
-			for _, lease := range leases {
-				// calculate number of hours the lease was active in the specified time range
-				var (
-					leaseStartTime time.Time
-					leaseEndTime   time.Time
-				)
-
-				if lease.CreateTime.After(startTime) {
-					leaseStartTime = lease.CreateTime
-				} else {
-					leaseStartTime = startTime
-				}
+// BillProject writes the spend of the orders of a single project for the billing periods that t is in, each billing
+// account's in its own time zone. It is used for the final bill of a deleted project, whose leases have ended, so the
+// spend only runs up to the deletion. Orders moved during the period are billed to each of their billing accounts, and
+// the spend of each of those accounts is recalculated in the same transaction.
+func BillProject(ctx context.Context, querier store.Querier, projectID string, t time.Time) (*ProjectSpend, error) {
+	spend := &ProjectSpend{
+		ProjectID: projectID,
+		Orders:    make(map[string]*OrderSpend),
+		Spend:     apd.New(0, 0),
+	}
 
-				if lease.EndTime.Time.Before(endTime) && lease.EndTime.Valid {
-					leaseEndTime = lease.EndTime.Time
-				} else {
-					leaseEndTime = endTime.Add(time.Nanosecond * -1)
-				}
+	orders, err := querier.ListOrdersByProjectId(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("error when listing orders for project: %w", err)
+	}
 
-				leaseDuration := leaseEndTime.Sub(leaseStartTime)
-				leaseHours := leaseDuration.Hours()
-				// convert priceHr to decimal from float64
-				priceHrDecimal, err := conv.FromFloat(lease.PriceHr)
-				if err != nil {
-					return fmt.Errorf("error converting price hour to decimal: %w", err)
-				}
-				// convert leaseHours to decimal from float64
-				leaseHoursDecimal, err := conv.FromFloat(leaseHours)
-				if err != nil {
-					return fmt.Errorf("error converting lease hours to decimal: %w", err)
-				}
+	periods := make(map[string]billingPeriod)
+	period := func(billingAccountID string) (billingPeriod, error) {
+		if p, ok := periods[billingAccountID]; ok {
+			return p, nil
+		}
+		account, err := querier.FindBillingAccountById(ctx, billingAccountID)
+		if err != nil {
+			return billingPeriod{}, fmt.Errorf("find billing account failed: %w", err)
+		}
+		startTime, endTime := AccountBillingPeriod(account, t)
+		periods[billingAccountID] = billingPeriod{start: startTime, end: endTime}
+		return periods[billingAccountID], nil
+	}
 
-				// define apd context
-				apdContext := apd.Context{
-					MaxExponent: 65,
-					MinExponent: -18,
-					Precision:   65,
-				}
-				leaseSpendDecimal := apd.NullDecimal{
-					Decimal: *apd.New(0, 0),
-					Valid:   false,
-				}
-				cond, err := apdContext.Mul(&leaseSpendDecimal.Decimal, &leaseHoursDecimal, &priceHrDecimal)
-				if err != nil {
-					return fmt.Errorf("error calculating lease spend: %w", err)
-				}
-				if cond.Any() {
-					return fmt.Errorf("error calculating lease spend: %w", err)
-				}
+	accountSpend := make(map[string]*apd.Decimal)
+	sealed := make(map[string]bool)
+	for _, order := range orders {
+		current, err := period(order.BillingAccountID)
+		if err != nil {
+			return nil, err
+		}
+		// the period of an account in another time zone can start up to a day and a bit before this one
+		transfers, err := querier.ListOrderTransfers(ctx, store.ListOrderTransfersParams{
+			OrderID:   order.ID,
+			StartTime: current.start.Add(-2 * closingWindow),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list order transfers failed: %w", err)
+		}
+		billingAccountIDs := []string{order.BillingAccountID}
+		for _, transfer := range transfers {
+			from, err := period(transfer.FromBillingAccountID)
+			if err != nil {
+				return nil, err
+			}
+			if transfer.TransferTime.Before(from.start) {
+				continue
+			}
+			billingAccountIDs = append(billingAccountIDs, transfer.FromBillingAccountID)
+		}
 
-				cond, err = apdContext.Add(
-					spend.Projects[order.ProjectID].Orders[order.ID].Spend,
-					spend.Projects[order.ProjectID].Orders[order.ID].Spend,
-					&leaseSpendDecimal.Decimal,
-				)
-				apdContext.Add(
-					spend.Projects[order.ProjectID].Spend,
-					spend.Projects[order.ProjectID].Spend,
-					&leaseSpendDecimal.Decimal,
-				)
+		spend.Orders[order.ID] = &OrderSpend{
+			OrderID: order.ID,
+			Spend:   apd.New(0, 0),
+		}
+		billed := make(map[string]bool)
+		for _, billingAccountID := range billingAccountIDs {
+			if billed[billingAccountID] {
+				continue
+			}
+			billed[billingAccountID] = true
+			p := periods[billingAccountID]
+			if _, ok := sealed[billingAccountID]; !ok {
+				sealed[billingAccountID], err = querier.IsBillingAccountSpendSealed(ctx, store.IsBillingAccountSpendSealedParams{
+					BillingAccountID: billingAccountID,
+					StartTime:        p.start,
+					EndTime:          p.end,
+				})
 				if err != nil {
-					return fmt.Errorf("error adding lease spend to order spend: %w", err)
-				}
-				if cond.Any() {
-					return fmt.Errorf("error adding lease spend to project spend: %w", err)
+					return nil, fmt.Errorf("check billing account spend sealed failed: %w", err)
 				}
-				apdContext.Add(
-					spend.Spend,
-					spend.Spend,
-					&leaseSpendDecimal.Decimal,
-				)
 			}
-			// write order spend
-			_, err = b.querier.CreateOrderSpend(ctx, store.CreateOrderSpendParams{
-				OrderID:   order.ID,
-				Spend:     *spend.Projects[order.ProjectID].Orders[order.ID].Spend,
-				StartTime: startTime,
-				EndTime:   endTime,
-			})
+			// the spend of a sealed period, and its breakdown, can't change
+			if sealed[billingAccountID] {
+				continue
+			}
+
+			orderSpend, err := calculateOrderSpend(ctx, querier, order, billingAccountID, p.start, p.end)
 			if err != nil {
-				return fmt.Errorf("create order spend failed: %w", err)
+				return nil, err
 			}
-			// write project spend
-			_, err = b.querier.CreateProjectSpend(ctx, store.CreateProjectSpendParams{
-				ProjectID: order.ProjectID,
-				Spend:     *spend.Projects[order.ProjectID].Spend,
-				StartTime: startTime,
-				EndTime:   endTime,
+			if _, ok := accountSpend[billingAccountID]; !ok {
+				accountSpend[billingAccountID] = apd.New(0, 0)
+			}
+			_, err = apdContext.Add(accountSpend[billingAccountID], accountSpend[billingAccountID], orderSpend.Spend)
+			if err != nil {
+				return nil, fmt.Errorf("error adding order spend to project spend: %w", err)
+			}
+			_, err = apdContext.Add(spend.Orders[order.ID].Spend, spend.Orders[order.ID].Spend, orderSpend.Spend)
+			if err != nil {
+				return nil, fmt.Errorf("error adding lease spend to order spend: %w", err)
+			}
+
+			_, err = querier.CreateOrderSpend(ctx, store.CreateOrderSpendParams{
+				Uid:              uuid.New(),
+				OrderID:          order.ID,
+				BillingAccountID: billingAccountID,
+				Spend:            *orderSpend.Spend,
+				StartTime:        p.start,
+				EndTime:          p.end,
 			})
 			if err != nil {
-				return fmt.Errorf("create project spend failed: %w", err)
+				return nil, fmt.Errorf("create order spend failed: %w", err)
 			}
 		}
-		// write billing account spend
-		_, err = b.querier.CreateBillingAccountSpend(ctx, store.CreateBillingAccountSpendParams{
-			BillingAccountID: billingAccount.ID,
-			Spend:            *spend.Spend,
-			StartTime:        startTime,
-			EndTime:          endTime,
+	}
+	for billingAccountID, projectSpend := range accountSpend {
+		p := periods[billingAccountID]
+		_, err = apdContext.Add(spend.Spend, spend.Spend, projectSpend)
+		if err != nil {
+			return nil, fmt.Errorf("error adding order spend to project spend: %w", err)
+		}
+		_, err = querier.CreateProjectSpend(ctx, store.CreateProjectSpendParams{
+			Uid:              uuid.New(),
+			ProjectID:        projectID,
+			BillingAccountID: billingAccountID,
+			Spend:            *projectSpend,
+			StartTime:        p.start,
+			EndTime:          p.end,
 		})
 		if err != nil {
-			return fmt.Errorf("create billing account spend failed: %w", err)
+			return nil, fmt.Errorf("create project spend failed: %w", err)
+		}
+		// the account total includes this bill, recalculate it now rather than leaving it stale until the next run
+		_, err = billAccount(ctx, querier, billingAccountID, p.start, p.end)
+		if err != nil {
+			return nil, err
 		}
 	}
-	return nil
+	return spend, nil
 }
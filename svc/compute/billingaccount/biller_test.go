@@ -12,6 +12,7 @@ import (
 	"biller/svc/compute/store"
 
 	"github.com/cockroachdb/apd/v2"
+	"github.com/jackc/pgx/v4"
 	"go.uber.org/zap/zaptest"
 )
 
@@ -19,6 +20,14 @@ func (txq FakeTxQuerier) ListOrdersByBillingAccountId(ctx context.Context, billi
 	return txq.orders, txq.listOrdersByBillingAccountIdError
 }
 
+func (txq FakeTxQuerier) ListOrdersTransferredFromBillingAccount(ctx context.Context, arg store.ListOrdersTransferredFromBillingAccountParams) ([]store.Order, error) {
+	return txq.transferredOrders, nil
+}
+
+func (txq FakeTxQuerier) ListOrderTransfers(ctx context.Context, arg store.ListOrderTransfersParams) ([]store.ListOrderTransfersRow, error) {
+	return txq.orderTransfers, nil
+}
+
 func (txq FakeTxQuerier) ListLeasesForTimeRangeByOrderId(ctx context.Context, arg store.ListLeasesForTimeRangeByOrderIdParams) ([]store.Lease, error) {
 	return txq.leasesForTimeRange, txq.leasesForTimeRangeError
 }
@@ -35,10 +44,191 @@ func (txq *FakeTxQuerier) CreateOrderSpend(ctx context.Context, arg store.Create
 
 func (txq *FakeTxQuerier) CreateBillingAccountSpend(ctx context.Context, arg store.CreateBillingAccountSpendParams) (store.BillingAccountSpend, error) {
 	txq.billingAccountSpend = arg.Spend
+	txq.billingAccountCredit = arg.Credit
+	if txq.billedPeriods != nil {
+		txq.billedPeriods[arg.BillingAccountID] = append(txq.billedPeriods[arg.BillingAccountID], billingPeriod{start: arg.StartTime, end: arg.EndTime})
+	}
 	return txq.createBillingAccountSpend, txq.createBillingAccountSpendError
 }
 
+func (txq FakeTxQuerier) IsBillingAccountSpendSealed(ctx context.Context, arg store.IsBillingAccountSpendSealedParams) (bool, error) {
+	return txq.spendSealed, nil
+}
+
+func (txq FakeTxQuerier) SumSLACreditsForBillingAccount(ctx context.Context, arg store.SumSLACreditsForBillingAccountParams) (apd.Decimal, error) {
+	return txq.slaCredit, txq.slaCreditError
+}
+
+func (txq FakeTxQuerier) ListAllBillingAccounts(ctx context.Context) ([]store.BillingAccount, error) {
+	return txq.listBillingAccounts, nil
+}
+
+func TestBiller_Run(t *testing.T) {
+	t.Run("should fail when billing fails", func(t *testing.T) {
+		var querier FakeTxQuerier
+		querier.listBillingAccounts = []store.BillingAccount{{ID: "1"}}
+		querier.listOrdersByBillingAccountIdError = errors.New("list orders by billing account id error")
+		err := NewBiller(&querier, zaptest.NewLogger(t)).Run(context.Background())
+		if err == nil {
+			t.Errorf("expected error, got nil")
+		}
+	})
+	t.Run("should succeed when every account was billed", func(t *testing.T) {
+		var querier FakeTxQuerier
+		querier.listBillingAccounts = []store.BillingAccount{{ID: "1"}}
+		err := NewBiller(&querier, zaptest.NewLogger(t)).Run(context.Background())
+		if err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+	})
+	t.Run("should leave the final spend of an account closed during the period unchanged", func(t *testing.T) {
+		var querier FakeTxQuerier
+		querier.orders = []store.Order{{ID: "1", BillingAccountID: "1", ProjectID: "1", PriceHr: 100}}
+		querier.leasesForTimeRange = []store.Lease{{
+			ID:         "1",
+			OrderID:    "1",
+			CreateTime: time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC),
+			PriceHr:    100,
+		}}
+		querier.listBillingAccounts = []store.BillingAccount{{
+			ID:        "1",
+			State:     store.BillingAccountStateClosed,
+			CloseTime: sql.NullTime{Time: time.Now(), Valid: true},
+		}}
+		err := NewBiller(&querier, zaptest.NewLogger(t)).Run(context.Background())
+		if err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+		if !querier.orderSpend.IsZero() || !querier.projectSpend.IsZero() || !querier.billingAccountSpend.IsZero() {
+			t.Errorf("expected no spend to be written, got order %s, project %s, billing account %s",
+				querier.orderSpend.String(), querier.projectSpend.String(), querier.billingAccountSpend.String())
+		}
+	})
+	t.Run("should bill each account for the periods of its own time zone", func(t *testing.T) {
+		querier := FakeTxQuerier{billedPeriods: map[string][]billingPeriod{}}
+		querier.listBillingAccounts = []store.BillingAccount{
+			{ID: "utc", Timezone: "UTC"},
+			{ID: "kiritimati", Timezone: "Pacific/Kiritimati"},
+			{ID: "default"},
+		}
+		err := NewBiller(&querier, zaptest.NewLogger(t)).Run(context.Background())
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		for _, account := range querier.listBillingAccounts {
+			location := BillingLocation(account)
+			periods := querier.billedPeriods[account.ID]
+			if len(periods) == 0 {
+				t.Fatalf("expected %s to be billed", account.ID)
+			}
+			for _, period := range periods {
+				start := period.start.In(location)
+				if start.Day() != 1 || start.Hour() != 0 || start.Minute() != 0 || !period.end.Equal(start.AddDate(0, 1, 0)) {
+					t.Errorf("expected %s to be billed for a month in %s, got: %v to %v", account.ID, location, period.start, period.end)
+				}
+			}
+		}
+	})
+}
+
+func Test_BillingLocation(t *testing.T) {
+	tests := []struct {
+		timezone string
+		expected string
+	}{
+		{"", "UTC"},
+		{"UTC", "UTC"},
+		{"Europe/London", "Europe/London"},
+		{"Local", "UTC"},
+		{"Mars/Olympus", "UTC"},
+	}
+	for _, tt := range tests {
+		location := BillingLocation(store.BillingAccount{Timezone: tt.timezone})
+		if location.String() != tt.expected {
+			t.Errorf("%q: expected: %s, got: %s", tt.timezone, tt.expected, location)
+		}
+	}
+}
+
+func Test_AccountBillingPeriod(t *testing.T) {
+	t.Run("should be the month of the account's time zone", func(t *testing.T) {
+		newYork, err := time.LoadLocation("America/New_York")
+		if err != nil {
+			t.Fatal(err)
+		}
+		// still January in New York
+		startTime, endTime := AccountBillingPeriod(store.BillingAccount{Timezone: "America/New_York"}, time.Date(2020, time.February, 1, 3, 0, 0, 0, time.UTC))
+		if !startTime.Equal(time.Date(2020, time.January, 1, 0, 0, 0, 0, newYork)) || !endTime.Equal(time.Date(2020, time.February, 1, 0, 0, 0, 0, newYork)) {
+			t.Errorf("unexpected period: %v to %v", startTime, endTime)
+		}
+	})
+}
+
+func Test_GroupByBillingLocation(t *testing.T) {
+	groups := GroupByBillingLocation([]store.BillingAccount{
+		{ID: "1", Timezone: "Europe/London"},
+		{ID: "2"},
+		{ID: "3", Timezone: "Europe/London"},
+		{ID: "4", Timezone: "UTC"},
+	})
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got: %v", groups)
+	}
+	if groups[0].Location.String() != "Europe/London" || len(groups[0].BillingAccounts) != 2 || groups[0].BillingAccounts[1].ID != "3" {
+		t.Errorf("unexpected group: %v", groups[0])
+	}
+	if groups[1].Location.String() != "UTC" || len(groups[1].BillingAccounts) != 2 {
+		t.Errorf("unexpected group: %v", groups[1])
+	}
+}
+
 func Test_calculateDemandSpend(t *testing.T) {
+	t.Run("should not bill accounts closed before the period", func(t *testing.T) {
+		querier := FakeTxQuerier{listOrdersByBillingAccountIdError: errors.New("failure to list orders")}
+		biller := NewBiller(&querier, zaptest.NewLogger(t))
+		startTime := time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC)
+		accounts := []store.BillingAccount{{
+			ID:        "billing-account-id",
+			State:     store.BillingAccountStateClosed,
+			CloseTime: sql.NullTime{Time: startTime.Add(-time.Hour), Valid: true},
+		}}
+		err := biller.calculateDemandSpend(context.Background(), accounts, startTime, startTime.AddDate(0, 1, 0))
+		if err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+	})
+	t.Run("should skip accounts whose spend of the period is sealed", func(t *testing.T) {
+		var querier FakeTxQuerier
+		querier.createBillingAccountSpendError = pgx.ErrNoRows
+		biller := NewBiller(&querier, zaptest.NewLogger(t))
+		startTime := time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC)
+		accounts := []store.BillingAccount{{ID: "1"}, {ID: "2"}}
+		err := biller.calculateDemandSpend(context.Background(), accounts, startTime, startTime.AddDate(0, 1, 0))
+		if err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+	})
+	t.Run("should leave the order and project spend of a sealed period unchanged", func(t *testing.T) {
+		var querier FakeTxQuerier
+		querier.spendSealed = true
+		querier.orders = []store.Order{{ID: "1", BillingAccountID: "1", ProjectID: "1", PriceHr: 100}}
+		querier.leasesForTimeRange = []store.Lease{{
+			ID:         "1",
+			OrderID:    "1",
+			CreateTime: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			PriceHr:    100,
+		}}
+		biller := NewBiller(&querier, zaptest.NewLogger(t))
+		startTime := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+		err := biller.calculateDemandSpend(context.Background(), []store.BillingAccount{{ID: "1"}}, startTime, startTime.AddDate(0, 1, 0))
+		if err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+		if !querier.orderSpend.IsZero() || !querier.projectSpend.IsZero() || !querier.billingAccountSpend.IsZero() {
+			t.Errorf("expected no spend to be written, got order %s, project %s, billing account %s",
+				querier.orderSpend.String(), querier.projectSpend.String(), querier.billingAccountSpend.String())
+		}
+	})
 	t.Run("should fail when ListOrdersByBillingAccountId query returns an error", func(t *testing.T) {
 		var querier FakeTxQuerier
 		querier.listOrdersByBillingAccountIdError = errors.New("list orders by billing account id error")
@@ -534,6 +724,46 @@ func Test_calculateDemandSpend(t *testing.T) {
 			t.Errorf("expected billing account spend to be %s, got %s", "9599.999999999930300", querier.billingAccountSpend.String())
 		}
 	})
+	t.Run("should fail when SumSLACreditsForBillingAccount returns an error", func(t *testing.T) {
+		var querier FakeTxQuerier
+		querier.slaCreditError = errors.New("sum sla credits error")
+		biller := NewBiller(&querier, zaptest.NewLogger(t))
+		billingAccounts := []store.BillingAccount{
+			{
+				ID:            "1",
+				CreateTime:    time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+				SupplyEnabled: true,
+				DemandEnabled: true,
+			},
+		}
+		err := biller.calculateDemandSpend(context.Background(), billingAccounts, time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC))
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if err.Error() != "sum sla credits for billing account failed: sum sla credits error" {
+			t.Errorf("expected error message to be '%s', got %v", "sum sla credits for billing account failed: sum sla credits error", err.Error())
+		}
+	})
+	t.Run("should write sla credits issued for the period with the billing account spend", func(t *testing.T) {
+		var querier FakeTxQuerier
+		querier.slaCredit = *apd.New(125, -1)
+		biller := NewBiller(&querier, zaptest.NewLogger(t))
+		billingAccounts := []store.BillingAccount{
+			{
+				ID:            "1",
+				CreateTime:    time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+				SupplyEnabled: true,
+				DemandEnabled: true,
+			},
+		}
+		err := biller.calculateDemandSpend(context.Background(), billingAccounts, time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC))
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if querier.billingAccountCredit.String() != "12.5" {
+			t.Errorf("expected billing account credit to be %s, got %s", "12.5", querier.billingAccountCredit.String())
+		}
+	})
 }
 
 func Test_LoopAddMultipleNullDecimals(t *testing.T) {
@@ -640,3 +870,108 @@ func Test_LoopAddMultipleNullDecimals(t *testing.T) {
 		}
 	})
 }
+
+func Test_billingPeriods(t *testing.T) {
+	january := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	february := time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC)
+	march := time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should bill the current period", func(t *testing.T) {
+		periods := billingPeriods(time.Date(2020, time.February, 10, 0, 5, 0, 0, time.UTC))
+		if len(periods) != 1 || !periods[0].start.Equal(february) || !periods[0].end.Equal(march) {
+			t.Errorf("unexpected periods %v", periods)
+		}
+	})
+	t.Run("should bill the period that just ended right after month end", func(t *testing.T) {
+		periods := billingPeriods(time.Date(2020, time.February, 1, 0, 5, 0, 0, time.UTC))
+		if len(periods) != 2 || !periods[0].start.Equal(january) || !periods[0].end.Equal(february) || !periods[1].start.Equal(february) {
+			t.Errorf("unexpected periods %v", periods)
+		}
+	})
+}
+
+func Test_billingWindows(t *testing.T) {
+	startTime := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	endTime := time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC)
+	transferTime := time.Date(2020, time.January, 10, 0, 0, 0, 0, time.UTC)
+	order := store.Order{ID: "1", BillingAccountID: "new"}
+	transfers := []store.ListOrderTransfersRow{
+		{FromBillingAccountID: "old", ToBillingAccountID: "new", TransferTime: transferTime},
+	}
+
+	t.Run("should bill the whole period to the billing account of an order that was not moved", func(t *testing.T) {
+		windows := billingWindows(order, "new", nil, startTime, endTime)
+		if len(windows) != 1 || !windows[0].startTime.Equal(startTime) || !windows[0].endTime.Equal(endTime) {
+			t.Errorf("unexpected windows %v", windows)
+		}
+	})
+	t.Run("should bill the old billing account up to the transfer", func(t *testing.T) {
+		windows := billingWindows(order, "old", transfers, startTime, endTime)
+		if len(windows) != 1 || !windows[0].startTime.Equal(startTime) || !windows[0].endTime.Equal(transferTime) {
+			t.Errorf("unexpected windows %v", windows)
+		}
+	})
+	t.Run("should bill the new billing account after the transfer", func(t *testing.T) {
+		windows := billingWindows(order, "new", transfers, startTime, endTime)
+		if len(windows) != 1 || !windows[0].startTime.Equal(transferTime) || !windows[0].endTime.Equal(endTime) {
+			t.Errorf("unexpected windows %v", windows)
+		}
+	})
+	t.Run("should bill a billing account the order was moved back to for both windows", func(t *testing.T) {
+		returnTime := time.Date(2020, time.January, 20, 0, 0, 0, 0, time.UTC)
+		moves := append(transfers, store.ListOrderTransfersRow{FromBillingAccountID: "new", ToBillingAccountID: "old", TransferTime: returnTime})
+		windows := billingWindows(store.Order{ID: "1", BillingAccountID: "old"}, "old", moves, startTime, endTime)
+		if len(windows) != 2 || !windows[0].endTime.Equal(transferTime) || !windows[1].startTime.Equal(returnTime) {
+			t.Errorf("unexpected windows %v", windows)
+		}
+	})
+	t.Run("should ignore transfers after the period", func(t *testing.T) {
+		later := []store.ListOrderTransfersRow{
+			{FromBillingAccountID: "old", ToBillingAccountID: "new", TransferTime: endTime.Add(time.Hour)},
+		}
+		windows := billingWindows(order, "old", later, startTime, endTime)
+		if len(windows) != 1 || !windows[0].startTime.Equal(startTime) || !windows[0].endTime.Equal(endTime) {
+			t.Errorf("unexpected windows %v", windows)
+		}
+		if windows := billingWindows(order, "new", later, startTime, endTime); len(windows) != 0 {
+			t.Errorf("expected no windows, got %v", windows)
+		}
+	})
+}
+
+func Test_calculateOrderSpend(t *testing.T) {
+	t.Run("should only bill the leases in the windows of the billing account", func(t *testing.T) {
+		var querier FakeTxQuerier
+		querier.orderTransfers = []store.ListOrderTransfersRow{
+			{FromBillingAccountID: "old", ToBillingAccountID: "new", TransferTime: time.Date(2020, time.January, 1, 10, 0, 0, 0, time.UTC)},
+		}
+		querier.leasesForTimeRange = []store.Lease{
+			{
+				ID:         "1",
+				OrderID:    "1",
+				CreateTime: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+				EndTime:    sql.NullTime{Time: time.Date(2020, time.January, 1, 12, 0, 0, 0, time.UTC), Valid: true},
+				PriceHr:    2,
+			},
+		}
+		order := store.Order{ID: "1", BillingAccountID: "new"}
+		startTime := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+		endTime := time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+		oldSpend, err := calculateOrderSpend(context.Background(), &querier, order, "old", startTime, endTime)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		// an open window ends a nanosecond before the transfer
+		if spend, _ := oldSpend.Spend.Float64(); spend < 19.99 || spend >= 20 {
+			t.Errorf("expected old billing account spend to be %s, got %s", "20", oldSpend.Spend.String())
+		}
+		newSpend, err := calculateOrderSpend(context.Background(), &querier, order, "new", startTime, endTime)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if newSpend.Spend.String() != "4" {
+			t.Errorf("expected new billing account spend to be %s, got %s", "4", newSpend.Spend.String())
+		}
+	})
+}
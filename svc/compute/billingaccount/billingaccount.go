@@ -2,10 +2,17 @@ package billingaccount
 
 import (
 	"context"
+	"time"
 
+	"biller/lib/filter"
+	"biller/lib/logger"
+	"biller/lib/pagination"
+	"biller/lib/principal"
 	"biller/lib/resource"
+	"biller/svc/compute/audit"
 	"biller/svc/compute/store"
 
+	"github.com/cockroachdb/apd/v2"
 	"github.com/jackc/pgx/v4"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
@@ -29,6 +36,22 @@ func NewServer(querier store.TxQuerier, log *zap.Logger) *server {
 func (s *server) CreateBillingAccount(ctx context.Context, req *CreateBillingAccountRequest) (*BillingAccount, error) {
 	var res BillingAccount
 
+	account := req.BillingAccount
+	if account == nil {
+		account = &BillingAccount{}
+	}
+	err := validateProfile(account)
+	if err != nil {
+		return &res, err
+	}
+	if p, ok := principal.FromContext(ctx); ok && p.BillingAccountID != "" {
+		return &res, status.Error(codes.PermissionDenied, "principals restricted to a billing account can't create billing accounts")
+	}
+	var profile store.UpdateBillingAccountProfileParams
+	for path := range updatableBillingAccountFields {
+		setProfileField(&profile, account, path)
+	}
+
 	tx, txq, err := s.querier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
 	if err != nil {
 		return &res, err
@@ -37,23 +60,49 @@ func (s *server) CreateBillingAccount(ctx context.Context, req *CreateBillingAcc
 
 	nanoID, err := resource.NewNanoID(12)
 	if err != nil {
-		s.log.Error("could not generate nano id", zap.Error(err))
+		logger.FromContext(ctx, s.log).Error("could not generate nano id", zap.Error(err))
 		return &res, err
 	}
-	newBillingAccount, err := txq.CreateBillingAccount(ctx, nanoID)
+	newBillingAccount, err := txq.CreateBillingAccount(ctx, store.CreateBillingAccountParams{
+		ID:                 nanoID,
+		DisplayName:        profile.DisplayName,
+		ContactEmails:      profile.ContactEmails,
+		AddressLine1:       profile.AddressLine1,
+		AddressLine2:       profile.AddressLine2,
+		AddressCity:        profile.AddressCity,
+		AddressRegion:      profile.AddressRegion,
+		AddressPostalCode:  profile.AddressPostalCode,
+		AddressCountryCode: profile.AddressCountryCode,
+		TaxID:              profile.TaxID,
+		Currency:           profile.Currency,
+		Timezone:           profile.Timezone,
+	})
 	if err != nil {
-		s.log.Error("could not create account", zap.Error(err))
+		logger.FromContext(ctx, s.log).Error("could not create account", zap.Error(err))
 		return &res, status.Error(codes.Internal, codes.Internal.String())
 	}
 
+	// the principal creating a billing account becomes its first owner
+	if p, ok := principal.FromContext(ctx); ok {
+		_, err = txq.SetBillingAccountMember(ctx, store.SetBillingAccountMemberParams{
+			BillingAccountID: newBillingAccount.ID,
+			PrincipalID:      p.ID,
+			Role:             store.BillingAccountRoleOwner,
+		})
+		if err != nil {
+			logger.FromContext(ctx, s.log).Error("could not add billing account owner", zap.Error(err))
+			return &res, status.Error(codes.Internal, codes.Internal.String())
+		}
+	}
+	err = audit.Record(ctx, txq, "billing-accounts/"+newBillingAccount.ID, nil, toBillingAccountPb(newBillingAccount))
 	if err != nil {
-		s.log.Error("could not add billing account owner role", zap.Error(err))
-		return &res, status.Error(codes.Internal, codes.Internal.String())
+		logger.FromContext(ctx, s.log).Error("query failed when auditing billing account creation", zap.Error(err))
+		return &res, status.Error(codes.Internal, "creation failed")
 	}
 
 	err = tx.Commit(ctx)
 	if err != nil {
-		s.log.Error("transaction failed when creating billing account", zap.Error(err))
+		logger.FromContext(ctx, s.log).Error("transaction failed when creating billing account", zap.Error(err))
 		return &res, status.Error(codes.Internal, "creation failed")
 	}
 
@@ -67,6 +116,10 @@ func (s *server) GetBillingAccount(ctx context.Context, req *GetBillingAccountRe
 		return &res, status.Error(codes.InvalidArgument, "invalid id")
 	}
 
+	err := Authorize(ctx, s.querier, req.Id, PermissionBillingAccountGet)
+	if err != nil {
+		return &res, err
+	}
 	account, err := s.querier.FindBillingAccountById(ctx, req.Id)
 	if err == pgx.ErrNoRows {
 		return &res, status.Error(codes.NotFound, "billing account not found")
@@ -78,14 +131,34 @@ func (s *server) GetBillingAccount(ctx context.Context, req *GetBillingAccountRe
 	return toBillingAccountPb(account), nil
 }
 
+// billingAccountFields are the fields billing accounts can be filtered and ordered by
+var billingAccountFields = filter.Schema{
+	"id":             {Column: "id", Type: filter.String},
+	"create_time":    {Column: "create_time", Type: filter.Time},
+	"supply_enabled": {Column: "supply_enabled", Type: filter.Bool},
+	"demand_enabled": {Column: "demand_enabled", Type: filter.Bool},
+	"display_name":   {Column: "display_name", Type: filter.String},
+	"currency":       {Column: "currency", Type: filter.String},
+	"state":          {Column: "state", Type: filter.String, Values: []string{string(store.BillingAccountStateActive), string(store.BillingAccountStateSuspended), string(store.BillingAccountStateClosed)}},
+	"close_time":     {Column: "close_time", Type: filter.Time},
+}
+
 func (s *server) ListBillingAccounts(ctx context.Context, req *ListBillingAccountsRequest) (*ListBillingAccountsResponse, error) {
 	var res ListBillingAccountsResponse
 
-	if req.PageSize > 100 {
-		req.PageSize = 100
+	pageSize := pagination.PageSize(req.PageSize)
+	listFilter, err := filter.Parse(req.Filter, billingAccountFields)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
 	}
-	if req.PageSize < 10 {
-		req.PageSize = 10
+	orderBy, err := filter.ParseOrderBy(req.OrderBy, billingAccountFields)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order_by: %v", err)
+	}
+	fingerprint := pagination.Fingerprint("ListBillingAccounts", principal.Fingerprint(ctx), req.Filter, req.OrderBy)
+	cursor, err := pagination.Decode(req.PageToken, fingerprint)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
 	}
 
 	tx, txq, err := s.querier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
@@ -94,31 +167,436 @@ func (s *server) ListBillingAccounts(ctx context.Context, req *ListBillingAccoun
 	}
 	defer tx.Rollback(ctx)
 
-	ids := make([]string, 100)
+	allBillingAccounts, billingAccountIDs, err := Scope(ctx, txq, PermissionBillingAccountGet)
+	if err != nil {
+		return nil, err
+	}
 
-	datacenter, err := txq.ListBillingAccounts(ctx, store.ListBillingAccountsParams{
-		Ids:   ids,
-		Limit: req.PageSize,
+	// fetch one extra row to find out whether there is another page
+	billingAccounts, err := txq.ListBillingAccounts(ctx, store.ListBillingAccountsParams{
+		ListParams: store.ListParams{
+			Filter:  listFilter,
+			OrderBy: orderBy,
+			Cursor:  cursor,
+			Limit:   pageSize + 1,
+		},
+		AllBillingAccounts: allBillingAccounts,
+		BillingAccountIds:  billingAccountIDs,
 	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, codes.Internal.String())
 	}
 
-	res.PageSize = req.PageSize
+	if len(billingAccounts) > int(pageSize) {
+		billingAccounts = billingAccounts[:pageSize]
+		last := billingAccounts[len(billingAccounts)-1]
+		res.NextPageToken = pagination.Encode(cursor.Next(orderBy == nil, last.CreateTime, last.ID, pageSize), fingerprint)
+	}
+	res.PageSize = pageSize
 
-	res.BillingAccounts = make([]*BillingAccount, len(datacenter))
-	for i, row := range datacenter {
+	res.BillingAccounts = make([]*BillingAccount, len(billingAccounts))
+	for i, row := range billingAccounts {
 		res.BillingAccounts[i] = toBillingAccountPb(row)
 	}
 	return &res, nil
 }
 
+// UpdateBillingAccount changes the profile fields named in the update mask, or all the fields set in the request when
+// there is no mask
+func (s *server) UpdateBillingAccount(ctx context.Context, req *UpdateBillingAccountRequest) (*BillingAccount, error) {
+	if req.BillingAccount == nil || !resource.ValidResourceID(req.BillingAccount.Id) {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+
+	var paths []string
+	if len(req.UpdateMask.GetPaths()) == 0 {
+		account := req.BillingAccount
+		if account.DisplayName != "" {
+			paths = append(paths, "display_name")
+		}
+		if len(account.ContactEmails) > 0 {
+			paths = append(paths, "contact_emails")
+		}
+		if account.Address != nil {
+			paths = append(paths, "address")
+		}
+		if account.TaxId != "" {
+			paths = append(paths, "tax_id")
+		}
+		if account.Currency != "" {
+			paths = append(paths, "currency")
+		}
+		if account.Timezone != "" {
+			paths = append(paths, "timezone")
+		}
+	} else {
+		if !req.UpdateMask.IsValid(req.BillingAccount) {
+			return nil, status.Error(codes.InvalidArgument, "invalid update mask")
+		}
+		req.UpdateMask.Normalize()
+		for _, path := range req.UpdateMask.GetPaths() {
+			if !updatableBillingAccountFields[path] {
+				return nil, status.Errorf(codes.InvalidArgument, "field %s can not be updated", path)
+			}
+			paths = append(paths, path)
+		}
+	}
+	err := validateProfile(req.BillingAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, txq, err := s.querier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	err = Authorize(ctx, txq, req.BillingAccount.Id, PermissionBillingAccountUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := txq.SelectBillingAccountForUpdate(ctx, req.BillingAccount.Id)
+	if err == pgx.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "billing account not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "could not find billing account")
+	}
+
+	// nothing to change
+	if len(paths) == 0 {
+		return toBillingAccountPb(existing), nil
+	}
+
+	updates := store.UpdateBillingAccountProfileParams{
+		ID:                 existing.ID,
+		DisplayName:        existing.DisplayName,
+		ContactEmails:      existing.ContactEmails,
+		AddressLine1:       existing.AddressLine1,
+		AddressLine2:       existing.AddressLine2,
+		AddressCity:        existing.AddressCity,
+		AddressRegion:      existing.AddressRegion,
+		AddressPostalCode:  existing.AddressPostalCode,
+		AddressCountryCode: existing.AddressCountryCode,
+		TaxID:              existing.TaxID,
+		Currency:           existing.Currency,
+		Timezone:           existing.Timezone,
+	}
+	for _, path := range paths {
+		setProfileField(&updates, req.BillingAccount, path)
+	}
+
+	updated, err := txq.UpdateBillingAccountProfile(ctx, updates)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when updating billing account", zap.Error(err))
+		return nil, status.Error(codes.Internal, "update failed")
+	}
+	err = audit.Record(ctx, txq, "billing-accounts/"+existing.ID, toBillingAccountPb(existing), toBillingAccountPb(updated))
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when auditing billing account update", zap.Error(err))
+		return nil, status.Error(codes.Internal, "update failed")
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("transaction failed when updating billing account", zap.Error(err))
+		return nil, status.Error(codes.Internal, "update failed")
+	}
+	return toBillingAccountPb(updated), nil
+}
+
+// findBillingAccount returns the billing account a request is for once the principal is authorized for the permission
+func findBillingAccount(ctx context.Context, q store.Querier, id string, permission Permission) (store.BillingAccount, error) {
+	err := Authorize(ctx, q, id, permission)
+	if err != nil {
+		return store.BillingAccount{}, err
+	}
+	account, err := q.FindBillingAccountById(ctx, id)
+	if err == pgx.ErrNoRows {
+		return account, status.Error(codes.NotFound, "billing account not found")
+	}
+	if err != nil {
+		return account, status.Error(codes.Internal, "could not find billing account")
+	}
+	return account, nil
+}
+
+// periodTime is the time whose billing period a spend request is for
+func periodTime(t *timestamppb.Timestamp) time.Time {
+	if t == nil {
+		return time.Now()
+	}
+	return t.AsTime()
+}
+
+// GetBillingAccountSpend returns the spend of a billing account for a billing period and the projects it was spent on
+func (s *server) GetBillingAccountSpend(ctx context.Context, req *GetBillingAccountSpendRequest) (*BillingAccountSpend, error) {
+	if !resource.ValidResourceID(req.Id) {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+
+	tx, txq, err := s.querier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	account, err := findBillingAccount(ctx, txq, req.Id, PermissionSpendGet)
+	if err != nil {
+		return nil, err
+	}
+	startTime, endTime := AccountBillingPeriod(account, periodTime(req.PeriodTime))
+
+	spend, err := txq.FindBillingAccountSpendForPeriod(ctx, store.FindBillingAccountSpendForPeriodParams{
+		BillingAccountID: account.ID,
+		StartTime:        startTime,
+		EndTime:          endTime,
+	})
+	if err == pgx.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "billing account has no spend for the period")
+	}
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when getting billing account spend", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not get billing account spend")
+	}
+
+	projectSpend, err := txq.ListProjectSpendForBillingAccount(ctx, store.ListProjectSpendForBillingAccountParams{
+		BillingAccountID: account.ID,
+		StartTime:        spend.StartTime,
+		EndTime:          spend.EndTime,
+	})
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when listing billing account project spend", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not get billing account spend")
+	}
+
+	res := toBillingAccountSpendPb(spend)
+	res.ProjectSpend = make([]*BillingAccountProjectSpend, len(projectSpend))
+	for i, row := range projectSpend {
+		res.ProjectSpend[i] = &BillingAccountProjectSpend{
+			ProjectId: row.ProjectID,
+			Spend:     row.Spend.String(),
+		}
+	}
+	return res, nil
+}
+
+// billingAccountSpendFields are the fields the spend history of a billing account can be filtered and ordered by
+var billingAccountSpendFields = filter.Schema{
+	"start_time": {Column: "start_time", Type: filter.Time},
+	"end_time":   {Column: "end_time", Type: filter.Time},
+	"spend":      {Column: "spend", Type: filter.Number},
+	"credit":     {Column: "credit", Type: filter.Number},
+	"final":      {Column: "final", Type: filter.Bool},
+}
+
+func (s *server) ListBillingAccountSpendHistory(ctx context.Context, req *ListBillingAccountSpendHistoryRequest) (*ListBillingAccountSpendHistoryResponse, error) {
+	var res ListBillingAccountSpendHistoryResponse
+
+	if !resource.ValidResourceID(req.Id) {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+	var rangeStart, rangeEnd string
+	startTime := time.Unix(0, 0)
+	if req.StartTime != nil {
+		startTime = req.StartTime.AsTime()
+		rangeStart = startTime.Format(time.RFC3339Nano)
+	}
+	endTime := time.Now().AddDate(100, 0, 0)
+	if req.EndTime != nil {
+		endTime = req.EndTime.AsTime()
+		rangeEnd = endTime.Format(time.RFC3339Nano)
+	}
+	if !startTime.Before(endTime) {
+		return nil, status.Error(codes.InvalidArgument, "start time must be before end time")
+	}
+	pageSize := pagination.PageSize(req.PageSize)
+	spendFilter, err := filter.Parse(req.Filter, billingAccountSpendFields)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
+	}
+	orderBy, err := filter.ParseOrderBy(req.OrderBy, billingAccountSpendFields)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order_by: %v", err)
+	}
+	fingerprint := pagination.Fingerprint("ListBillingAccountSpendHistory", principal.Fingerprint(ctx), req.Id, req.Filter, req.OrderBy,
+		rangeStart, rangeEnd)
+	cursor, err := pagination.Decode(req.PageToken, fingerprint)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+
+	tx, txq, err := s.querier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	account, err := findBillingAccount(ctx, txq, req.Id, PermissionSpendGet)
+	if err != nil {
+		return nil, err
+	}
+
+	// spend is ordered by billing period so pages are found by offset, fetching one extra row to find out whether
+	// there is another page
+	spend, err := txq.ListBillingAccountSpend(ctx, store.ListBillingAccountSpendParams{
+		BillingAccountID: account.ID,
+		StartTime:        startTime,
+		EndTime:          endTime,
+		Filter:           spendFilter,
+		OrderBy:          orderBy,
+		Limit:            pageSize + 1,
+		Offset:           cursor.Offset,
+	})
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when listing billing account spend", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not list billing account spend")
+	}
+
+	if len(spend) > int(pageSize) {
+		spend = spend[:pageSize]
+		res.NextPageToken = pagination.Encode(cursor.Next(false, time.Time{}, "", pageSize), fingerprint)
+	}
+	res.PageSize = pageSize
+
+	res.BillingAccountSpend = make([]*BillingAccountSpend, len(spend))
+	for i, row := range spend {
+		res.BillingAccountSpend[i] = toBillingAccountSpendPb(row)
+	}
+	return &res, nil
+}
+
+// orderSpendFields are the fields the order spend of a billing period can be filtered and ordered by
+var orderSpendFields = filter.Schema{
+	"order_id":   {Column: "order_id", Type: filter.String},
+	"project_id": {Column: "project_id", Type: filter.String},
+	"infra_type": {Column: "infra_type", Type: filter.String, Values: []string{string(store.InfrastructureTypeDedicated), string(store.InfrastructureTypeShared), string(store.InfrastructureTypeStorage)}},
+	"spend":      {Column: "spend", Type: filter.Number},
+}
+
+// ListOrderSpend returns the spend of every order billed to a billing account in a billing period, optionally only
+// the orders of one project
+func (s *server) ListOrderSpend(ctx context.Context, req *ListOrderSpendRequest) (*ListOrderSpendResponse, error) {
+	var res ListOrderSpendResponse
+
+	if !resource.ValidResourceID(req.Id) {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+	if req.ProjectId != "" && !resource.ValidResourceID(req.ProjectId) {
+		return nil, status.Error(codes.InvalidArgument, "invalid project id")
+	}
+	pageSize := pagination.PageSize(req.PageSize)
+	spendFilter, err := filter.Parse(req.Filter, orderSpendFields)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
+	}
+	orderBy, err := filter.ParseOrderBy(req.OrderBy, orderSpendFields)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order_by: %v", err)
+	}
+
+	tx, txq, err := s.querier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	account, err := findBillingAccount(ctx, txq, req.Id, PermissionSpendGet)
+	if err != nil {
+		return nil, err
+	}
+	// the period is only known once the time zone of the account is
+	startTime, endTime := AccountBillingPeriod(account, periodTime(req.PeriodTime))
+	fingerprint := pagination.Fingerprint("ListOrderSpend", principal.Fingerprint(ctx), req.Id, req.ProjectId, req.Filter, req.OrderBy,
+		startTime.Format(time.RFC3339))
+	cursor, err := pagination.Decode(req.PageToken, fingerprint)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+
+	// fetch one extra row to find out whether there is another page
+	spend, err := txq.ListOrderSpendForBillingAccount(ctx, store.ListOrderSpendForBillingAccountParams{
+		BillingAccountID: account.ID,
+		StartTime:        startTime,
+		EndTime:          endTime,
+		ProjectID:        req.ProjectId,
+		Filter:           spendFilter,
+		OrderBy:          orderBy,
+		Limit:            pageSize + 1,
+		Offset:           cursor.Offset,
+	})
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when listing order spend", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not list order spend")
+	}
+
+	if len(spend) > int(pageSize) {
+		spend = spend[:pageSize]
+		res.NextPageToken = pagination.Encode(cursor.Next(false, time.Time{}, "", pageSize), fingerprint)
+	}
+	res.PageSize = pageSize
+
+	res.OrderSpend = make([]*BillingAccountOrderSpend, len(spend))
+	for i, row := range spend {
+		res.OrderSpend[i] = &BillingAccountOrderSpend{
+			OrderId:   row.OrderID,
+			ProjectId: row.ProjectID,
+			InfraType: string(row.InfraType),
+			Spend:     row.Spend.String(),
+		}
+	}
+	return &res, nil
+}
+
+func toBillingAccountSpendPb(in store.BillingAccountSpend) *BillingAccountSpend {
+	return &BillingAccountSpend{
+		Uid:              in.Uid.String(),
+		BillingAccountId: in.BillingAccountID,
+		Spend:            in.Spend.String(),
+		Credit:           in.Credit.String(),
+		Due:              due(in.Spend, in.Credit).String(),
+		Final:            in.Final,
+		StartTime:        timestamppb.New(in.StartTime),
+		EndTime:          timestamppb.New(in.EndTime),
+	}
+}
+
+// due applies the sla credits of a period to its spend, credit beyond the spend isn't carried over
+func due(spend apd.Decimal, credit apd.Decimal) *apd.Decimal {
+	res := apd.New(0, 0)
+	_, err := apdContext.Sub(res, &spend, &credit)
+	if err != nil || res.Negative {
+		return apd.New(0, 0)
+	}
+	return res
+}
+
 func toBillingAccountPb(in store.BillingAccount) *BillingAccount {
 	out := BillingAccount{
 		Id:            in.ID,
 		CreateTime:    timestamppb.New(in.CreateTime),
 		DemandEnabled: in.DemandEnabled,
 		SupplyEnabled: in.SupplyEnabled,
+		DisplayName:   in.DisplayName,
+		ContactEmails: in.ContactEmails,
+		TaxId:         in.TaxID,
+		Currency:      in.Currency,
+		Timezone:      in.Timezone,
+		UpdateTime:    timestamppb.New(in.UpdateTime),
+		State:         string(in.State),
+		CloseTime:     nullTimePb(in.CloseTime),
+	}
+	if in.AddressLine1 != "" || in.AddressCity != "" || in.AddressCountryCode != "" {
+		out.Address = &PostalAddress{
+			Line1:       in.AddressLine1,
+			Line2:       in.AddressLine2,
+			City:        in.AddressCity,
+			Region:      in.AddressRegion,
+			PostalCode:  in.AddressPostalCode,
+			CountryCode: in.AddressCountryCode,
+		}
 	}
 	return &out
 }
@@ -131,6 +609,9 @@ func EnsureDemandEnabled(ctx context.Context, querier store.Querier, accountID s
 	if err != nil {
 		return status.Error(codes.Internal, err.Error())
 	}
+	if account.State == store.BillingAccountStateSuspended || account.State == store.BillingAccountStateClosed {
+		return status.Errorf(codes.FailedPrecondition, "billing account is %s", account.State)
+	}
 	if !account.DemandEnabled {
 		return status.Error(codes.PermissionDenied, "billing account not enabled for demand")
 	}
@@ -145,6 +626,9 @@ func EnsureSupplyEnabled(ctx context.Context, querier store.Querier, accountID s
 	if err != nil {
 		return status.Error(codes.Internal, err.Error())
 	}
+	if account.State == store.BillingAccountStateSuspended || account.State == store.BillingAccountStateClosed {
+		return status.Errorf(codes.FailedPrecondition, "billing account is %s", account.State)
+	}
 	if !account.SupplyEnabled {
 		return status.Error(codes.PermissionDenied, "billing account not enabled for supply")
 	}
@@ -11,6 +11,7 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -25,6 +26,31 @@ type BillingAccountServiceClient interface {
 	CreateBillingAccount(ctx context.Context, in *CreateBillingAccountRequest, opts ...grpc.CallOption) (*BillingAccount, error)
 	GetBillingAccount(ctx context.Context, in *GetBillingAccountRequest, opts ...grpc.CallOption) (*BillingAccount, error)
 	ListBillingAccounts(ctx context.Context, in *ListBillingAccountsRequest, opts ...grpc.CallOption) (*ListBillingAccountsResponse, error)
+	UpdateBillingAccount(ctx context.Context, in *UpdateBillingAccountRequest, opts ...grpc.CallOption) (*BillingAccount, error)
+	GetBillingAccountSpend(ctx context.Context, in *GetBillingAccountSpendRequest, opts ...grpc.CallOption) (*BillingAccountSpend, error)
+	ListBillingAccountSpendHistory(ctx context.Context, in *ListBillingAccountSpendHistoryRequest, opts ...grpc.CallOption) (*ListBillingAccountSpendHistoryResponse, error)
+	ListOrderSpend(ctx context.Context, in *ListOrderSpendRequest, opts ...grpc.CallOption) (*ListOrderSpendResponse, error)
+	CreateEnablementRequest(ctx context.Context, in *CreateEnablementRequestRequest, opts ...grpc.CallOption) (*EnablementRequest, error)
+	ListEnablementRequests(ctx context.Context, in *ListEnablementRequestsRequest, opts ...grpc.CallOption) (*ListEnablementRequestsResponse, error)
+	// admin only
+	ApproveEnablementRequest(ctx context.Context, in *DecideEnablementRequestRequest, opts ...grpc.CallOption) (*EnablementRequest, error)
+	// admin only
+	RejectEnablementRequest(ctx context.Context, in *DecideEnablementRequestRequest, opts ...grpc.CallOption) (*EnablementRequest, error)
+	// admin only, suspended accounts can't create projects or orders
+	SuspendBillingAccount(ctx context.Context, in *ChangeBillingAccountStateRequest, opts ...grpc.CallOption) (*BillingAccount, error)
+	// admin only
+	ReactivateBillingAccount(ctx context.Context, in *ChangeBillingAccountStateRequest, opts ...grpc.CallOption) (*BillingAccount, error)
+	// closes an account whose projects have all been deleted and issues its final invoice, closed accounts can't
+	// be reactivated
+	CloseBillingAccount(ctx context.Context, in *ChangeBillingAccountStateRequest, opts ...grpc.CallOption) (*BillingAccount, error)
+	ListBillingAccountStateChanges(ctx context.Context, in *ListBillingAccountStateChangesRequest, opts ...grpc.CallOption) (*ListBillingAccountStateChangesResponse, error)
+	// admin only, revoking demand stops new orders but leaves running leases alone
+	RevokeEnablement(ctx context.Context, in *RevokeEnablementRequest, opts ...grpc.CallOption) (*EnablementRequest, error)
+	ListBillingAccountMembers(ctx context.Context, in *ListBillingAccountMembersRequest, opts ...grpc.CallOption) (*ListBillingAccountMembersResponse, error)
+	// adds a principal to the account or changes its role, only owners can grant or take away the owner role
+	SetBillingAccountMember(ctx context.Context, in *SetBillingAccountMemberRequest, opts ...grpc.CallOption) (*BillingAccountMember, error)
+	// the last owner of an account can't be removed
+	RemoveBillingAccountMember(ctx context.Context, in *RemoveBillingAccountMemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type billingAccountServiceClient struct {
@@ -62,6 +88,150 @@ func (c *billingAccountServiceClient) ListBillingAccounts(ctx context.Context, i
 	return out, nil
 }
 
+func (c *billingAccountServiceClient) UpdateBillingAccount(ctx context.Context, in *UpdateBillingAccountRequest, opts ...grpc.CallOption) (*BillingAccount, error) {
+	out := new(BillingAccount)
+	err := c.cc.Invoke(ctx, "/org.cudo.compute.v1.BillingAccountService/UpdateBillingAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billingAccountServiceClient) GetBillingAccountSpend(ctx context.Context, in *GetBillingAccountSpendRequest, opts ...grpc.CallOption) (*BillingAccountSpend, error) {
+	out := new(BillingAccountSpend)
+	err := c.cc.Invoke(ctx, "/org.cudo.compute.v1.BillingAccountService/GetBillingAccountSpend", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billingAccountServiceClient) ListBillingAccountSpendHistory(ctx context.Context, in *ListBillingAccountSpendHistoryRequest, opts ...grpc.CallOption) (*ListBillingAccountSpendHistoryResponse, error) {
+	out := new(ListBillingAccountSpendHistoryResponse)
+	err := c.cc.Invoke(ctx, "/org.cudo.compute.v1.BillingAccountService/ListBillingAccountSpendHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billingAccountServiceClient) ListOrderSpend(ctx context.Context, in *ListOrderSpendRequest, opts ...grpc.CallOption) (*ListOrderSpendResponse, error) {
+	out := new(ListOrderSpendResponse)
+	err := c.cc.Invoke(ctx, "/org.cudo.compute.v1.BillingAccountService/ListOrderSpend", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billingAccountServiceClient) CreateEnablementRequest(ctx context.Context, in *CreateEnablementRequestRequest, opts ...grpc.CallOption) (*EnablementRequest, error) {
+	out := new(EnablementRequest)
+	err := c.cc.Invoke(ctx, "/org.cudo.compute.v1.BillingAccountService/CreateEnablementRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billingAccountServiceClient) ListEnablementRequests(ctx context.Context, in *ListEnablementRequestsRequest, opts ...grpc.CallOption) (*ListEnablementRequestsResponse, error) {
+	out := new(ListEnablementRequestsResponse)
+	err := c.cc.Invoke(ctx, "/org.cudo.compute.v1.BillingAccountService/ListEnablementRequests", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billingAccountServiceClient) ApproveEnablementRequest(ctx context.Context, in *DecideEnablementRequestRequest, opts ...grpc.CallOption) (*EnablementRequest, error) {
+	out := new(EnablementRequest)
+	err := c.cc.Invoke(ctx, "/org.cudo.compute.v1.BillingAccountService/ApproveEnablementRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billingAccountServiceClient) RejectEnablementRequest(ctx context.Context, in *DecideEnablementRequestRequest, opts ...grpc.CallOption) (*EnablementRequest, error) {
+	out := new(EnablementRequest)
+	err := c.cc.Invoke(ctx, "/org.cudo.compute.v1.BillingAccountService/RejectEnablementRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billingAccountServiceClient) SuspendBillingAccount(ctx context.Context, in *ChangeBillingAccountStateRequest, opts ...grpc.CallOption) (*BillingAccount, error) {
+	out := new(BillingAccount)
+	err := c.cc.Invoke(ctx, "/org.cudo.compute.v1.BillingAccountService/SuspendBillingAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billingAccountServiceClient) ReactivateBillingAccount(ctx context.Context, in *ChangeBillingAccountStateRequest, opts ...grpc.CallOption) (*BillingAccount, error) {
+	out := new(BillingAccount)
+	err := c.cc.Invoke(ctx, "/org.cudo.compute.v1.BillingAccountService/ReactivateBillingAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billingAccountServiceClient) CloseBillingAccount(ctx context.Context, in *ChangeBillingAccountStateRequest, opts ...grpc.CallOption) (*BillingAccount, error) {
+	out := new(BillingAccount)
+	err := c.cc.Invoke(ctx, "/org.cudo.compute.v1.BillingAccountService/CloseBillingAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billingAccountServiceClient) ListBillingAccountStateChanges(ctx context.Context, in *ListBillingAccountStateChangesRequest, opts ...grpc.CallOption) (*ListBillingAccountStateChangesResponse, error) {
+	out := new(ListBillingAccountStateChangesResponse)
+	err := c.cc.Invoke(ctx, "/org.cudo.compute.v1.BillingAccountService/ListBillingAccountStateChanges", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billingAccountServiceClient) RevokeEnablement(ctx context.Context, in *RevokeEnablementRequest, opts ...grpc.CallOption) (*EnablementRequest, error) {
+	out := new(EnablementRequest)
+	err := c.cc.Invoke(ctx, "/org.cudo.compute.v1.BillingAccountService/RevokeEnablement", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billingAccountServiceClient) ListBillingAccountMembers(ctx context.Context, in *ListBillingAccountMembersRequest, opts ...grpc.CallOption) (*ListBillingAccountMembersResponse, error) {
+	out := new(ListBillingAccountMembersResponse)
+	err := c.cc.Invoke(ctx, "/org.cudo.compute.v1.BillingAccountService/ListBillingAccountMembers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billingAccountServiceClient) SetBillingAccountMember(ctx context.Context, in *SetBillingAccountMemberRequest, opts ...grpc.CallOption) (*BillingAccountMember, error) {
+	out := new(BillingAccountMember)
+	err := c.cc.Invoke(ctx, "/org.cudo.compute.v1.BillingAccountService/SetBillingAccountMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billingAccountServiceClient) RemoveBillingAccountMember(ctx context.Context, in *RemoveBillingAccountMemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/org.cudo.compute.v1.BillingAccountService/RemoveBillingAccountMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BillingAccountServiceServer is the server API for BillingAccountService service.
 // All implementations must embed UnimplementedBillingAccountServiceServer
 // for forward compatibility
@@ -69,6 +239,31 @@ type BillingAccountServiceServer interface {
 	CreateBillingAccount(context.Context, *CreateBillingAccountRequest) (*BillingAccount, error)
 	GetBillingAccount(context.Context, *GetBillingAccountRequest) (*BillingAccount, error)
 	ListBillingAccounts(context.Context, *ListBillingAccountsRequest) (*ListBillingAccountsResponse, error)
+	UpdateBillingAccount(context.Context, *UpdateBillingAccountRequest) (*BillingAccount, error)
+	GetBillingAccountSpend(context.Context, *GetBillingAccountSpendRequest) (*BillingAccountSpend, error)
+	ListBillingAccountSpendHistory(context.Context, *ListBillingAccountSpendHistoryRequest) (*ListBillingAccountSpendHistoryResponse, error)
+	ListOrderSpend(context.Context, *ListOrderSpendRequest) (*ListOrderSpendResponse, error)
+	CreateEnablementRequest(context.Context, *CreateEnablementRequestRequest) (*EnablementRequest, error)
+	ListEnablementRequests(context.Context, *ListEnablementRequestsRequest) (*ListEnablementRequestsResponse, error)
+	// admin only
+	ApproveEnablementRequest(context.Context, *DecideEnablementRequestRequest) (*EnablementRequest, error)
+	// admin only
+	RejectEnablementRequest(context.Context, *DecideEnablementRequestRequest) (*EnablementRequest, error)
+	// admin only, suspended accounts can't create projects or orders
+	SuspendBillingAccount(context.Context, *ChangeBillingAccountStateRequest) (*BillingAccount, error)
+	// admin only
+	ReactivateBillingAccount(context.Context, *ChangeBillingAccountStateRequest) (*BillingAccount, error)
+	// closes an account whose projects have all been deleted and issues its final invoice, closed accounts can't
+	// be reactivated
+	CloseBillingAccount(context.Context, *ChangeBillingAccountStateRequest) (*BillingAccount, error)
+	ListBillingAccountStateChanges(context.Context, *ListBillingAccountStateChangesRequest) (*ListBillingAccountStateChangesResponse, error)
+	// admin only, revoking demand stops new orders but leaves running leases alone
+	RevokeEnablement(context.Context, *RevokeEnablementRequest) (*EnablementRequest, error)
+	ListBillingAccountMembers(context.Context, *ListBillingAccountMembersRequest) (*ListBillingAccountMembersResponse, error)
+	// adds a principal to the account or changes its role, only owners can grant or take away the owner role
+	SetBillingAccountMember(context.Context, *SetBillingAccountMemberRequest) (*BillingAccountMember, error)
+	// the last owner of an account can't be removed
+	RemoveBillingAccountMember(context.Context, *RemoveBillingAccountMemberRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedBillingAccountServiceServer()
 }
 
@@ -85,6 +280,54 @@ func (UnimplementedBillingAccountServiceServer) GetBillingAccount(context.Contex
 func (UnimplementedBillingAccountServiceServer) ListBillingAccounts(context.Context, *ListBillingAccountsRequest) (*ListBillingAccountsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListBillingAccounts not implemented")
 }
+func (UnimplementedBillingAccountServiceServer) UpdateBillingAccount(context.Context, *UpdateBillingAccountRequest) (*BillingAccount, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBillingAccount not implemented")
+}
+func (UnimplementedBillingAccountServiceServer) GetBillingAccountSpend(context.Context, *GetBillingAccountSpendRequest) (*BillingAccountSpend, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBillingAccountSpend not implemented")
+}
+func (UnimplementedBillingAccountServiceServer) ListBillingAccountSpendHistory(context.Context, *ListBillingAccountSpendHistoryRequest) (*ListBillingAccountSpendHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBillingAccountSpendHistory not implemented")
+}
+func (UnimplementedBillingAccountServiceServer) ListOrderSpend(context.Context, *ListOrderSpendRequest) (*ListOrderSpendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrderSpend not implemented")
+}
+func (UnimplementedBillingAccountServiceServer) CreateEnablementRequest(context.Context, *CreateEnablementRequestRequest) (*EnablementRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateEnablementRequest not implemented")
+}
+func (UnimplementedBillingAccountServiceServer) ListEnablementRequests(context.Context, *ListEnablementRequestsRequest) (*ListEnablementRequestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEnablementRequests not implemented")
+}
+func (UnimplementedBillingAccountServiceServer) ApproveEnablementRequest(context.Context, *DecideEnablementRequestRequest) (*EnablementRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveEnablementRequest not implemented")
+}
+func (UnimplementedBillingAccountServiceServer) RejectEnablementRequest(context.Context, *DecideEnablementRequestRequest) (*EnablementRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectEnablementRequest not implemented")
+}
+func (UnimplementedBillingAccountServiceServer) SuspendBillingAccount(context.Context, *ChangeBillingAccountStateRequest) (*BillingAccount, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuspendBillingAccount not implemented")
+}
+func (UnimplementedBillingAccountServiceServer) ReactivateBillingAccount(context.Context, *ChangeBillingAccountStateRequest) (*BillingAccount, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReactivateBillingAccount not implemented")
+}
+func (UnimplementedBillingAccountServiceServer) CloseBillingAccount(context.Context, *ChangeBillingAccountStateRequest) (*BillingAccount, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseBillingAccount not implemented")
+}
+func (UnimplementedBillingAccountServiceServer) ListBillingAccountStateChanges(context.Context, *ListBillingAccountStateChangesRequest) (*ListBillingAccountStateChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBillingAccountStateChanges not implemented")
+}
+func (UnimplementedBillingAccountServiceServer) RevokeEnablement(context.Context, *RevokeEnablementRequest) (*EnablementRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeEnablement not implemented")
+}
+func (UnimplementedBillingAccountServiceServer) ListBillingAccountMembers(context.Context, *ListBillingAccountMembersRequest) (*ListBillingAccountMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBillingAccountMembers not implemented")
+}
+func (UnimplementedBillingAccountServiceServer) SetBillingAccountMember(context.Context, *SetBillingAccountMemberRequest) (*BillingAccountMember, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBillingAccountMember not implemented")
+}
+func (UnimplementedBillingAccountServiceServer) RemoveBillingAccountMember(context.Context, *RemoveBillingAccountMemberRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveBillingAccountMember not implemented")
+}
 func (UnimplementedBillingAccountServiceServer) mustEmbedUnimplementedBillingAccountServiceServer() {}
 
 // UnsafeBillingAccountServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -152,6 +395,294 @@ func _BillingAccountService_ListBillingAccounts_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BillingAccountService_UpdateBillingAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBillingAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingAccountServiceServer).UpdateBillingAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.cudo.compute.v1.BillingAccountService/UpdateBillingAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingAccountServiceServer).UpdateBillingAccount(ctx, req.(*UpdateBillingAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BillingAccountService_GetBillingAccountSpend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBillingAccountSpendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingAccountServiceServer).GetBillingAccountSpend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.cudo.compute.v1.BillingAccountService/GetBillingAccountSpend",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingAccountServiceServer).GetBillingAccountSpend(ctx, req.(*GetBillingAccountSpendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BillingAccountService_ListBillingAccountSpendHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBillingAccountSpendHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingAccountServiceServer).ListBillingAccountSpendHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.cudo.compute.v1.BillingAccountService/ListBillingAccountSpendHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingAccountServiceServer).ListBillingAccountSpendHistory(ctx, req.(*ListBillingAccountSpendHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BillingAccountService_ListOrderSpend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrderSpendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingAccountServiceServer).ListOrderSpend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.cudo.compute.v1.BillingAccountService/ListOrderSpend",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingAccountServiceServer).ListOrderSpend(ctx, req.(*ListOrderSpendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BillingAccountService_CreateEnablementRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateEnablementRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingAccountServiceServer).CreateEnablementRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.cudo.compute.v1.BillingAccountService/CreateEnablementRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingAccountServiceServer).CreateEnablementRequest(ctx, req.(*CreateEnablementRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BillingAccountService_ListEnablementRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEnablementRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingAccountServiceServer).ListEnablementRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.cudo.compute.v1.BillingAccountService/ListEnablementRequests",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingAccountServiceServer).ListEnablementRequests(ctx, req.(*ListEnablementRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BillingAccountService_ApproveEnablementRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecideEnablementRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingAccountServiceServer).ApproveEnablementRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.cudo.compute.v1.BillingAccountService/ApproveEnablementRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingAccountServiceServer).ApproveEnablementRequest(ctx, req.(*DecideEnablementRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BillingAccountService_RejectEnablementRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecideEnablementRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingAccountServiceServer).RejectEnablementRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.cudo.compute.v1.BillingAccountService/RejectEnablementRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingAccountServiceServer).RejectEnablementRequest(ctx, req.(*DecideEnablementRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BillingAccountService_SuspendBillingAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeBillingAccountStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingAccountServiceServer).SuspendBillingAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.cudo.compute.v1.BillingAccountService/SuspendBillingAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingAccountServiceServer).SuspendBillingAccount(ctx, req.(*ChangeBillingAccountStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BillingAccountService_ReactivateBillingAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeBillingAccountStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingAccountServiceServer).ReactivateBillingAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.cudo.compute.v1.BillingAccountService/ReactivateBillingAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingAccountServiceServer).ReactivateBillingAccount(ctx, req.(*ChangeBillingAccountStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BillingAccountService_CloseBillingAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeBillingAccountStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingAccountServiceServer).CloseBillingAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.cudo.compute.v1.BillingAccountService/CloseBillingAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingAccountServiceServer).CloseBillingAccount(ctx, req.(*ChangeBillingAccountStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BillingAccountService_ListBillingAccountStateChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBillingAccountStateChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingAccountServiceServer).ListBillingAccountStateChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.cudo.compute.v1.BillingAccountService/ListBillingAccountStateChanges",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingAccountServiceServer).ListBillingAccountStateChanges(ctx, req.(*ListBillingAccountStateChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BillingAccountService_RevokeEnablement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeEnablementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingAccountServiceServer).RevokeEnablement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.cudo.compute.v1.BillingAccountService/RevokeEnablement",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingAccountServiceServer).RevokeEnablement(ctx, req.(*RevokeEnablementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BillingAccountService_ListBillingAccountMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBillingAccountMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingAccountServiceServer).ListBillingAccountMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.cudo.compute.v1.BillingAccountService/ListBillingAccountMembers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingAccountServiceServer).ListBillingAccountMembers(ctx, req.(*ListBillingAccountMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BillingAccountService_SetBillingAccountMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBillingAccountMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingAccountServiceServer).SetBillingAccountMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.cudo.compute.v1.BillingAccountService/SetBillingAccountMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingAccountServiceServer).SetBillingAccountMember(ctx, req.(*SetBillingAccountMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BillingAccountService_RemoveBillingAccountMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveBillingAccountMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingAccountServiceServer).RemoveBillingAccountMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.cudo.compute.v1.BillingAccountService/RemoveBillingAccountMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingAccountServiceServer).RemoveBillingAccountMember(ctx, req.(*RemoveBillingAccountMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BillingAccountService_ServiceDesc is the grpc.ServiceDesc for BillingAccountService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -171,6 +702,70 @@ var BillingAccountService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListBillingAccounts",
 			Handler:    _BillingAccountService_ListBillingAccounts_Handler,
 		},
+		{
+			MethodName: "UpdateBillingAccount",
+			Handler:    _BillingAccountService_UpdateBillingAccount_Handler,
+		},
+		{
+			MethodName: "GetBillingAccountSpend",
+			Handler:    _BillingAccountService_GetBillingAccountSpend_Handler,
+		},
+		{
+			MethodName: "ListBillingAccountSpendHistory",
+			Handler:    _BillingAccountService_ListBillingAccountSpendHistory_Handler,
+		},
+		{
+			MethodName: "ListOrderSpend",
+			Handler:    _BillingAccountService_ListOrderSpend_Handler,
+		},
+		{
+			MethodName: "CreateEnablementRequest",
+			Handler:    _BillingAccountService_CreateEnablementRequest_Handler,
+		},
+		{
+			MethodName: "ListEnablementRequests",
+			Handler:    _BillingAccountService_ListEnablementRequests_Handler,
+		},
+		{
+			MethodName: "ApproveEnablementRequest",
+			Handler:    _BillingAccountService_ApproveEnablementRequest_Handler,
+		},
+		{
+			MethodName: "RejectEnablementRequest",
+			Handler:    _BillingAccountService_RejectEnablementRequest_Handler,
+		},
+		{
+			MethodName: "SuspendBillingAccount",
+			Handler:    _BillingAccountService_SuspendBillingAccount_Handler,
+		},
+		{
+			MethodName: "ReactivateBillingAccount",
+			Handler:    _BillingAccountService_ReactivateBillingAccount_Handler,
+		},
+		{
+			MethodName: "CloseBillingAccount",
+			Handler:    _BillingAccountService_CloseBillingAccount_Handler,
+		},
+		{
+			MethodName: "ListBillingAccountStateChanges",
+			Handler:    _BillingAccountService_ListBillingAccountStateChanges_Handler,
+		},
+		{
+			MethodName: "RevokeEnablement",
+			Handler:    _BillingAccountService_RevokeEnablement_Handler,
+		},
+		{
+			MethodName: "ListBillingAccountMembers",
+			Handler:    _BillingAccountService_ListBillingAccountMembers_Handler,
+		},
+		{
+			MethodName: "SetBillingAccountMember",
+			Handler:    _BillingAccountService_SetBillingAccountMember_Handler,
+		},
+		{
+			MethodName: "RemoveBillingAccountMember",
+			Handler:    _BillingAccountService_RemoveBillingAccountMember_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "svc/compute/billingaccount/billingaccount.proto",
@@ -5,41 +5,108 @@ import (
 	"testing"
 	"time"
 
+	"biller/lib/pagination"
+	"biller/lib/principal"
 	"biller/svc/compute/store"
 
 	"github.com/cockroachdb/apd/v2"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v4"
 	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type FakeTxQuerier struct {
 	store.TxQuerier
 	billingAccountSpend               apd.Decimal
+	billingAccountCredit              apd.Decimal
+	billedPeriods                     map[string][]billingPeriod
 	createBillingAccountSpend         store.BillingAccountSpend
 	createBillingAccountSpendError    error
 	createBillingAccount              store.BillingAccount
+	createBillingAccountParams        *store.CreateBillingAccountParams
+	updateBillingAccountParams        *store.UpdateBillingAccountProfileParams
 	createOrderSpend                  store.OrderSpend
 	createOrderSpendError             error
 	createProjectSpend                store.ProjectSpend
 	createProjectSpendError           error
 	getBillingAccount                 store.BillingAccount
+	getBillingAccountError            error
+	billingAccountSpendForPeriod      store.BillingAccountSpend
+	billingAccountSpendForPeriodError error
+	billingAccountSpendHistory        []store.BillingAccountSpend
+	billingAccountProjectSpend        []store.ProjectSpend
+	billingAccountOrderSpend          []store.ListOrderSpendForBillingAccountRow
+	orderSpendParams                  *store.ListOrderSpendForBillingAccountParams
+	enablementRequests                []store.EnablementRequest
+	enablementRequest                 store.EnablementRequest
+	enablementRequestError            error
+	revokeEnablementError             error
+	enabled                           *[]string
+	projectCount                      int64
+	stateChange                       *store.CreateBillingAccountStateChangeParams
+	stateChanges                      []store.BillingAccountStateChange
+	finalSpend                        *uuid.UUID
+	disabled                          *[]string
 	listBillingAccounts               []store.BillingAccount
 	listOrdersByBillingAccountIdError error
 	leasesForTimeRange                []store.Lease
 	leasesForTimeRangeError           error
 	orders                            []store.Order
+	orderTransfers                    []store.ListOrderTransfersRow
+	transferredOrders                 []store.Order
 	projectSpend                      apd.Decimal
+	slaCredit                         apd.Decimal
+	slaCreditError                    error
+	spendSealed                       bool
 	orderSpend                        apd.Decimal
+	members                           []store.BillingAccountMember
+	setMember                         *store.SetBillingAccountMemberParams
+	deletedMember                     *store.DeleteBillingAccountMemberParams
+	auditEvents                       *[]store.CreateAuditEventParams
 	err                               error
 }
 
-func (txq FakeTxQuerier) CreateBillingAccount(ctx context.Context, id string) (store.BillingAccount, error) {
+func (txq FakeTxQuerier) CreateBillingAccount(ctx context.Context, arg store.CreateBillingAccountParams) (store.BillingAccount, error) {
+	if txq.createBillingAccountParams != nil {
+		*txq.createBillingAccountParams = arg
+	}
 	return txq.createBillingAccount, nil
 }
 
+func (txq FakeTxQuerier) UpdateBillingAccountProfile(ctx context.Context, arg store.UpdateBillingAccountProfileParams) (store.BillingAccount, error) {
+	if txq.updateBillingAccountParams != nil {
+		*txq.updateBillingAccountParams = arg
+	}
+	return store.BillingAccount{ID: arg.ID, DisplayName: arg.DisplayName, Currency: arg.Currency}, nil
+}
+
 func (txq FakeTxQuerier) FindBillingAccountById(ctx context.Context, id string) (store.BillingAccount, error) {
-	return txq.getBillingAccount, nil
+	return txq.getBillingAccount, txq.getBillingAccountError
+}
+
+func (txq FakeTxQuerier) FindBillingAccountSpendForPeriod(ctx context.Context, arg store.FindBillingAccountSpendForPeriodParams) (store.BillingAccountSpend, error) {
+	return txq.billingAccountSpendForPeriod, txq.billingAccountSpendForPeriodError
+}
+
+func (txq FakeTxQuerier) ListBillingAccountSpend(ctx context.Context, arg store.ListBillingAccountSpendParams) ([]store.BillingAccountSpend, error) {
+	if int(arg.Limit) < len(txq.billingAccountSpendHistory) {
+		return txq.billingAccountSpendHistory[:arg.Limit], nil
+	}
+	return txq.billingAccountSpendHistory, nil
+}
+
+func (txq FakeTxQuerier) ListProjectSpendForBillingAccount(ctx context.Context, arg store.ListProjectSpendForBillingAccountParams) ([]store.ProjectSpend, error) {
+	return txq.billingAccountProjectSpend, nil
+}
+
+func (txq FakeTxQuerier) ListOrderSpendForBillingAccount(ctx context.Context, arg store.ListOrderSpendForBillingAccountParams) ([]store.ListOrderSpendForBillingAccountRow, error) {
+	if txq.orderSpendParams != nil {
+		*txq.orderSpendParams = arg
+	}
+	return txq.billingAccountOrderSpend, nil
 }
 
 func (txq FakeTxQuerier) ListBillingAccounts(ctx context.Context, arg store.ListBillingAccountsParams) ([]store.BillingAccount, error) {
@@ -58,11 +125,11 @@ func (tx FakeTx) Commit(ctx context.Context) error {
 	return nil
 }
 
-func (q FakeTxQuerier) BeginTx(context.Context, pgx.TxOptions) (pgx.Tx, store.Querier, error) {
+func (q FakeTxQuerier) BeginTx(context.Context, pgx.TxOptions) (pgx.Tx, store.QueryLister, error) {
 	return FakeTx{}, &q, nil
 }
 
-func (q FakeTxQuerier) ExecWithTx(context.Context, pgx.TxOptions, func(store.Querier) error) error {
+func (q FakeTxQuerier) ExecWithTx(context.Context, pgx.TxOptions, func(store.QueryLister) error) error {
 	return nil
 }
 
@@ -81,7 +148,7 @@ func Test_CreateBillingAccount(t *testing.T) {
 		}
 		server := NewServer(&querier, logger)
 
-		res, err := server.CreateBillingAccount(context.Background(), &CreateBillingAccountRequest{})
+		res, err := server.CreateBillingAccount(systemContext(), &CreateBillingAccountRequest{})
 		if err != nil {
 			t.Errorf("expected no error, got: %v", err)
 		}
@@ -115,7 +182,7 @@ func Test_GetBillingAccount(t *testing.T) {
 		}
 		server := NewServer(&querier, logger)
 
-		res, err := server.GetBillingAccount(context.Background(), &GetBillingAccountRequest{
+		res, err := server.GetBillingAccount(systemContext(), &GetBillingAccountRequest{
 			Id: "d543d430-0b1a-4255-868d-af2f08ee4c41",
 		})
 		if err != nil {
@@ -153,7 +220,7 @@ func Test_ListBillingAccounts(t *testing.T) {
 		}
 		server := NewServer(&querier, logger)
 
-		res, err := server.ListBillingAccounts(context.Background(), &ListBillingAccountsRequest{})
+		res, err := server.ListBillingAccounts(systemContext(), &ListBillingAccountsRequest{})
 		if err != nil {
 			t.Errorf("expected no error, got: %v", err)
 		}
@@ -173,4 +240,205 @@ func Test_ListBillingAccounts(t *testing.T) {
 			t.Errorf("expected payment enabled false, got: %v", res.BillingAccounts[0].DemandEnabled)
 		}
 	})
+	t.Run("should reject a filter on a state that doesn't exist", func(t *testing.T) {
+		var querier FakeTxQuerier
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		_, err := server.ListBillingAccounts(systemContext(), &ListBillingAccountsRequest{Filter: `state = "bogus"`})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, status.Code(err))
+		}
+	})
+}
+
+func Test_GetBillingAccountSpend(t *testing.T) {
+	t.Run("should fail when the billing account does not exist", func(t *testing.T) {
+		querier := FakeTxQuerier{getBillingAccountError: pgx.ErrNoRows}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		_, err := server.GetBillingAccountSpend(systemContext(), &GetBillingAccountSpendRequest{Id: "billing-account-id"})
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("expected: %s, got: %s", codes.NotFound, status.Code(err))
+		}
+	})
+	t.Run("should fail when the principal is not a member of the billing account", func(t *testing.T) {
+		querier := FakeTxQuerier{getBillingAccount: store.BillingAccount{ID: "billing-account-id"}}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		ctx := principal.NewContext(context.Background(), &principal.Principal{ID: "user"})
+		_, err := server.GetBillingAccountSpend(ctx, &GetBillingAccountSpendRequest{Id: "billing-account-id"})
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("expected: %s, got: %s", codes.NotFound, status.Code(err))
+		}
+	})
+	t.Run("should fail when there is no spend for the period", func(t *testing.T) {
+		querier := FakeTxQuerier{
+			getBillingAccount:                 store.BillingAccount{ID: "billing-account-id"},
+			billingAccountSpendForPeriodError: pgx.ErrNoRows,
+		}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		_, err := server.GetBillingAccountSpend(systemContext(), &GetBillingAccountSpendRequest{Id: "billing-account-id"})
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("expected: %s, got: %s", codes.NotFound, status.Code(err))
+		}
+	})
+	t.Run("should return the spend of the period and its projects", func(t *testing.T) {
+		querier := FakeTxQuerier{
+			getBillingAccount: store.BillingAccount{ID: "billing-account-id"},
+			billingAccountSpendForPeriod: store.BillingAccountSpend{
+				BillingAccountID: "billing-account-id",
+				Spend:            *apd.New(1500, -2),
+				Credit:           *apd.New(150, -2),
+			},
+			billingAccountProjectSpend: []store.ProjectSpend{
+				{ProjectID: "project-1", Spend: *apd.New(1000, -2)},
+				{ProjectID: "project-2", Spend: *apd.New(500, -2)},
+			},
+		}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		res, err := server.GetBillingAccountSpend(systemContext(), &GetBillingAccountSpendRequest{Id: "billing-account-id"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if res.Spend != "15.00" || res.Credit != "1.50" || res.Due != "13.50" {
+			t.Errorf("unexpected spend: %v", res)
+		}
+		if len(res.ProjectSpend) != 2 || res.ProjectSpend[1].ProjectId != "project-2" || res.ProjectSpend[1].Spend != "5.00" {
+			t.Errorf("unexpected project spend: %v", res.ProjectSpend)
+		}
+	})
+}
+
+func Test_due(t *testing.T) {
+	t.Run("should not be due less than nothing when the credit is more than the spend", func(t *testing.T) {
+		res := due(*apd.New(100, -2), *apd.New(250, -2))
+		if !res.IsZero() {
+			t.Errorf("expected nothing due, got: %s", res.String())
+		}
+	})
+}
+
+func Test_ListBillingAccountSpendHistory(t *testing.T) {
+	t.Run("should fail when the time range is empty", func(t *testing.T) {
+		now := time.Now()
+		server := NewServer(&FakeTxQuerier{}, zaptest.NewLogger(t))
+		_, err := server.ListBillingAccountSpendHistory(systemContext(), &ListBillingAccountSpendHistoryRequest{
+			Id:        "billing-account-id",
+			StartTime: timestamppb.New(now),
+			EndTime:   timestamppb.New(now),
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, status.Code(err))
+		}
+	})
+	t.Run("should return a page of spend and a token for the next one", func(t *testing.T) {
+		querier := FakeTxQuerier{getBillingAccount: store.BillingAccount{ID: "billing-account-id"}}
+		for i := 0; i < pagination.MinPageSize+1; i++ {
+			querier.billingAccountSpendHistory = append(querier.billingAccountSpendHistory, store.BillingAccountSpend{
+				BillingAccountID: "billing-account-id",
+				Spend:            *apd.New(int64(i), 0),
+			})
+		}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		res, err := server.ListBillingAccountSpendHistory(systemContext(), &ListBillingAccountSpendHistoryRequest{Id: "billing-account-id"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if len(res.BillingAccountSpend) != pagination.MinPageSize {
+			t.Errorf("expected: %d, got: %d", pagination.MinPageSize, len(res.BillingAccountSpend))
+		}
+		if res.NextPageToken == "" {
+			t.Errorf("expected a next page token")
+		}
+	})
+	t.Run("should reject an order on a field that can't be ordered by", func(t *testing.T) {
+		server := NewServer(&FakeTxQuerier{}, zaptest.NewLogger(t))
+		_, err := server.ListBillingAccountSpendHistory(systemContext(), &ListBillingAccountSpendHistoryRequest{
+			Id:      "billing-account-id",
+			OrderBy: "billing_account_id",
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, status.Code(err))
+		}
+	})
+	t.Run("should reject a filter that doesn't parse", func(t *testing.T) {
+		server := NewServer(&FakeTxQuerier{}, zaptest.NewLogger(t))
+		_, err := server.ListBillingAccountSpendHistory(systemContext(), &ListBillingAccountSpendHistoryRequest{
+			Id:     "billing-account-id",
+			Filter: "final =",
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, status.Code(err))
+		}
+	})
+}
+
+func Test_ListOrderSpend(t *testing.T) {
+	t.Run("should list the order spend of a project for the chosen period", func(t *testing.T) {
+		var params store.ListOrderSpendForBillingAccountParams
+		querier := FakeTxQuerier{
+			getBillingAccount: store.BillingAccount{ID: "billing-account-id"},
+			billingAccountOrderSpend: []store.ListOrderSpendForBillingAccountRow{
+				{OrderID: "order-1", ProjectID: "project-1", InfraType: store.InfrastructureTypeShared, Spend: *apd.New(250, -2)},
+			},
+			orderSpendParams: &params,
+		}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		res, err := server.ListOrderSpend(systemContext(), &ListOrderSpendRequest{
+			Id:         "billing-account-id",
+			ProjectId:  "project-1",
+			PeriodTime: timestamppb.New(time.Date(2022, time.March, 15, 0, 0, 0, 0, time.UTC)),
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !params.StartTime.Equal(time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC)) || !params.EndTime.Equal(time.Date(2022, time.April, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("unexpected period: %v to %v", params.StartTime, params.EndTime)
+		}
+		if params.ProjectID != "project-1" {
+			t.Errorf("expected: %s, got: %s", "project-1", params.ProjectID)
+		}
+		if len(res.OrderSpend) != 1 || res.OrderSpend[0].Spend != "2.50" || res.OrderSpend[0].InfraType != "shared" {
+			t.Errorf("unexpected order spend: %v", res.OrderSpend)
+		}
+	})
+	t.Run("should reject a filter on a field that can't be filtered", func(t *testing.T) {
+		server := NewServer(&FakeTxQuerier{}, zaptest.NewLogger(t))
+		_, err := server.ListOrderSpend(systemContext(), &ListOrderSpendRequest{
+			Id:     "billing-account-id",
+			Filter: `billing_account_id = "other"`,
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, status.Code(err))
+		}
+	})
+	t.Run("should reject an infra type that doesn't exist", func(t *testing.T) {
+		server := NewServer(&FakeTxQuerier{}, zaptest.NewLogger(t))
+		_, err := server.ListOrderSpend(systemContext(), &ListOrderSpendRequest{
+			Id:     "billing-account-id",
+			Filter: `infra_type = "bogus"`,
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, status.Code(err))
+		}
+	})
+	t.Run("should pass the filter and order to the query", func(t *testing.T) {
+		var params store.ListOrderSpendForBillingAccountParams
+		querier := FakeTxQuerier{
+			getBillingAccount: store.BillingAccount{ID: "billing-account-id"},
+			orderSpendParams:  &params,
+		}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		_, err := server.ListOrderSpend(systemContext(), &ListOrderSpendRequest{
+			Id:      "billing-account-id",
+			Filter:  `infra_type = "shared"`,
+			OrderBy: "spend desc",
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if params.Filter == nil {
+			t.Errorf("expected a filter")
+		}
+		if len(params.OrderBy) != 1 {
+			t.Errorf("expected: %d, got: %d", 1, len(params.OrderBy))
+		}
+	})
 }
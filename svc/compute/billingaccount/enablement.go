@@ -0,0 +1,363 @@
+package billingaccount
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	"unicode/utf8"
+
+	"biller/lib/logger"
+	"biller/lib/pagination"
+	"biller/lib/principal"
+	"biller/lib/resource"
+	"biller/svc/compute/audit"
+	"biller/svc/compute/store"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Billing accounts are enabled for demand or supply by an admin approving a request for it. Every decision records
+// who made it, when and why. Revoking demand clears demand_enabled so no new projects or orders can be created for
+// the account, leases that are already running carry on and are billed as usual.
+
+const maxReasonLength = 1024
+
+func parseEnablementKind(kind string) (store.EnablementKind, bool) {
+	switch store.EnablementKind(kind) {
+	case store.EnablementKindDemand, store.EnablementKindSupply:
+		return store.EnablementKind(kind), true
+	}
+	return "", false
+}
+
+func enabled(account store.BillingAccount, kind store.EnablementKind) bool {
+	if kind == store.EnablementKindDemand {
+		return account.DemandEnabled
+	}
+	return account.SupplyEnabled
+}
+
+func (s *server) CreateEnablementRequest(ctx context.Context, req *CreateEnablementRequestRequest) (*EnablementRequest, error) {
+	if !resource.ValidResourceID(req.BillingAccountId) {
+		return nil, status.Error(codes.InvalidArgument, "invalid billing account id")
+	}
+	kind, ok := parseEnablementKind(req.Kind)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "kind must be demand or supply")
+	}
+	if utf8.RuneCountInString(req.Reason) > maxReasonLength {
+		return nil, status.Errorf(codes.InvalidArgument, "reason must be at most %d characters", maxReasonLength)
+	}
+
+	tx, txq, err := s.querier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	err = Authorize(ctx, txq, req.BillingAccountId, PermissionEnablementRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	// lock the billing account so only one request per kind can be pending
+	account, err := txq.SelectBillingAccountForUpdate(ctx, req.BillingAccountId)
+	if err == pgx.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "billing account not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "could not find billing account")
+	}
+	if enabled(account, kind) {
+		return nil, status.Errorf(codes.FailedPrecondition, "billing account already enabled for %s", kind)
+	}
+	requests, err := txq.ListEnablementRequestsByBillingAccountId(ctx, account.ID)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when listing enablement requests", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not create enablement request")
+	}
+	for _, r := range requests {
+		if r.Kind == kind && r.Status == store.EnablementStatusPending {
+			return nil, status.Errorf(codes.AlreadyExists, "a %s enablement request is already pending", kind)
+		}
+	}
+
+	request, err := txq.CreateEnablementRequest(ctx, store.CreateEnablementRequestParams{
+		BillingAccountID: account.ID,
+		Kind:             kind,
+		Reason:           req.Reason,
+		RequestedBy:      principal.Actor(ctx),
+	})
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when creating enablement request", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not create enablement request")
+	}
+	err = audit.Record(ctx, txq, enablementRequestResourceName(request), nil, toEnablementRequestPb(request))
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when auditing enablement request creation", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not create enablement request")
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("transaction failed when creating enablement request", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not create enablement request")
+	}
+	return toEnablementRequestPb(request), nil
+}
+
+func (s *server) ListEnablementRequests(ctx context.Context, req *ListEnablementRequestsRequest) (*ListEnablementRequestsResponse, error) {
+	var res ListEnablementRequestsResponse
+
+	if !resource.ValidResourceID(req.BillingAccountId) {
+		return nil, status.Error(codes.InvalidArgument, "invalid billing account id")
+	}
+	pageSize := pagination.PageSize(req.PageSize)
+	fingerprint := pagination.Fingerprint("ListEnablementRequests", principal.Fingerprint(ctx), req.BillingAccountId)
+	cursor, err := pagination.Decode(req.PageToken, fingerprint)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+
+	tx, txq, err := s.querier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	account, err := findBillingAccount(ctx, txq, req.BillingAccountId, PermissionBillingAccountGet)
+	if err != nil {
+		return nil, err
+	}
+
+	// fetch one extra row to find out whether there is another page
+	requests, err := txq.ListEnablementRequests(ctx, store.ListEnablementRequestsParams{
+		BillingAccountID: account.ID,
+		RowLimit:         pageSize + 1,
+		RowOffset:        cursor.Offset,
+	})
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when listing enablement requests", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not list enablement requests")
+	}
+
+	if len(requests) > int(pageSize) {
+		requests = requests[:pageSize]
+		res.NextPageToken = pagination.Encode(cursor.Next(false, time.Time{}, "", pageSize), fingerprint)
+	}
+	res.PageSize = pageSize
+
+	res.EnablementRequests = make([]*EnablementRequest, len(requests))
+	for i, row := range requests {
+		res.EnablementRequests[i] = toEnablementRequestPb(row)
+	}
+	return &res, nil
+}
+
+// ApproveEnablementRequest approves a pending request and enables the billing account for its kind
+func (s *server) ApproveEnablementRequest(ctx context.Context, req *DecideEnablementRequestRequest) (*EnablementRequest, error) {
+	return s.decideEnablementRequest(ctx, req, store.EnablementStatusApproved)
+}
+
+// RejectEnablementRequest rejects a pending request, the billing account can make a new one
+func (s *server) RejectEnablementRequest(ctx context.Context, req *DecideEnablementRequestRequest) (*EnablementRequest, error) {
+	return s.decideEnablementRequest(ctx, req, store.EnablementStatusRejected)
+}
+
+func (s *server) decideEnablementRequest(ctx context.Context, req *DecideEnablementRequestRequest, decision store.EnablementStatus) (*EnablementRequest, error) {
+	if !principal.IsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "only admins can decide enablement requests")
+	}
+	if !resource.ValidResourceID(req.BillingAccountId) {
+		return nil, status.Error(codes.InvalidArgument, "invalid billing account id")
+	}
+	uid, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid enablement request id")
+	}
+	err = validateDecisionReason(req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, txq, err := s.querier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	request, err := txq.SelectEnablementRequestForUpdate(ctx, store.SelectEnablementRequestForUpdateParams{
+		Uid:              uid,
+		BillingAccountID: req.BillingAccountId,
+	})
+	if err == pgx.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "enablement request not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "could not find enablement request")
+	}
+	if request.Status != store.EnablementStatusPending {
+		return nil, status.Errorf(codes.FailedPrecondition, "enablement request is already %s", request.Status)
+	}
+	pending := toEnablementRequestPb(request)
+
+	request, err = txq.DecideEnablementRequest(ctx, store.DecideEnablementRequestParams{
+		Uid:            request.Uid,
+		Status:         decision,
+		DecidedBy:      principal.Actor(ctx),
+		DecisionReason: req.Reason,
+	})
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when deciding enablement request", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not decide enablement request")
+	}
+	if decision == store.EnablementStatusApproved {
+		if request.Kind == store.EnablementKindDemand {
+			_, err = txq.EnableBillingAccountDemand(ctx, request.BillingAccountID)
+		} else {
+			_, err = txq.EnableBillingAccountSupply(ctx, request.BillingAccountID)
+		}
+		if err != nil {
+			logger.FromContext(ctx, s.log).Error("query failed when enabling billing account", zap.Error(err))
+			return nil, status.Error(codes.Internal, "could not decide enablement request")
+		}
+	}
+	err = audit.Record(ctx, txq, enablementRequestResourceName(request), pending, toEnablementRequestPb(request))
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when auditing enablement decision", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not decide enablement request")
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("transaction failed when deciding enablement request", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not decide enablement request")
+	}
+	logger.FromContext(ctx, s.log).Info("decided enablement request",
+		zap.String("billingAccountId", request.BillingAccountID),
+		zap.String("kind", string(request.Kind)),
+		zap.String("decision", string(decision)),
+		zap.String("decidedBy", request.DecidedBy))
+	return toEnablementRequestPb(request), nil
+}
+
+// RevokeEnablement revokes the approved request of a kind and disables the billing account for it
+func (s *server) RevokeEnablement(ctx context.Context, req *RevokeEnablementRequest) (*EnablementRequest, error) {
+	if !principal.IsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "only admins can revoke enablement")
+	}
+	if !resource.ValidResourceID(req.BillingAccountId) {
+		return nil, status.Error(codes.InvalidArgument, "invalid billing account id")
+	}
+	kind, ok := parseEnablementKind(req.Kind)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "kind must be demand or supply")
+	}
+	err := validateDecisionReason(req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, txq, err := s.querier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = txq.SelectBillingAccountForUpdate(ctx, req.BillingAccountId)
+	if err == pgx.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "billing account not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "could not find billing account")
+	}
+
+	request, err := txq.RevokeEnablementRequest(ctx, store.RevokeEnablementRequestParams{
+		BillingAccountID: req.BillingAccountId,
+		Kind:             kind,
+		RevokedBy:        principal.Actor(ctx),
+		RevokeReason:     req.Reason,
+	})
+	if err == pgx.ErrNoRows {
+		return nil, status.Errorf(codes.FailedPrecondition, "billing account is not enabled for %s", kind)
+	}
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when revoking enablement", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not revoke enablement")
+	}
+	if kind == store.EnablementKindDemand {
+		_, err = txq.DisableBillingAccountDemand(ctx, request.BillingAccountID)
+	} else {
+		_, err = txq.DisableBillingAccountSupply(ctx, request.BillingAccountID)
+	}
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when disabling billing account", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not revoke enablement")
+	}
+	// the request was approved until this revocation, which only set its status and who revoked it why and when
+	approved := request
+	approved.Status = store.EnablementStatusApproved
+	approved.RevokedBy = ""
+	approved.RevokeReason = ""
+	approved.RevokeTime = sql.NullTime{}
+	err = audit.Record(ctx, txq, enablementRequestResourceName(request), toEnablementRequestPb(approved), toEnablementRequestPb(request))
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when auditing enablement revocation", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not revoke enablement")
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("transaction failed when revoking enablement", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not revoke enablement")
+	}
+	logger.FromContext(ctx, s.log).Info("revoked enablement",
+		zap.String("billingAccountId", request.BillingAccountID),
+		zap.String("kind", string(kind)),
+		zap.String("revokedBy", request.RevokedBy))
+	return toEnablementRequestPb(request), nil
+}
+
+func enablementRequestResourceName(request store.EnablementRequest) string {
+	return "billing-accounts/" + request.BillingAccountID + "/enablement-requests/" + request.Uid.String()
+}
+
+func validateDecisionReason(reason string) error {
+	if reason == "" {
+		return status.Error(codes.InvalidArgument, "reason is required")
+	}
+	if utf8.RuneCountInString(reason) > maxReasonLength {
+		return status.Errorf(codes.InvalidArgument, "reason must be at most %d characters", maxReasonLength)
+	}
+	return nil
+}
+
+func nullTimePb(t sql.NullTime) *timestamppb.Timestamp {
+	if !t.Valid {
+		return nil
+	}
+	return timestamppb.New(t.Time)
+}
+
+func toEnablementRequestPb(in store.EnablementRequest) *EnablementRequest {
+	return &EnablementRequest{
+		Id:               in.Uid.String(),
+		BillingAccountId: in.BillingAccountID,
+		Kind:             string(in.Kind),
+		Status:           string(in.Status),
+		Reason:           in.Reason,
+		RequestedBy:      in.RequestedBy,
+		CreateTime:       timestamppb.New(in.CreateTime),
+		DecidedBy:        in.DecidedBy,
+		DecisionReason:   in.DecisionReason,
+		DecideTime:       nullTimePb(in.DecideTime),
+		RevokedBy:        in.RevokedBy,
+		RevokeReason:     in.RevokeReason,
+		RevokeTime:       nullTimePb(in.RevokeTime),
+	}
+}
@@ -0,0 +1,327 @@
+package billingaccount
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"biller/lib/pagination"
+	"biller/lib/principal"
+	"biller/svc/compute/audit"
+	"biller/svc/compute/store"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (txq FakeTxQuerier) SelectBillingAccountForUpdate(ctx context.Context, id string) (store.BillingAccount, error) {
+	return txq.getBillingAccount, txq.getBillingAccountError
+}
+
+func (txq FakeTxQuerier) ListEnablementRequestsByBillingAccountId(ctx context.Context, billingAccountID string) ([]store.EnablementRequest, error) {
+	return txq.enablementRequests, nil
+}
+
+func (txq FakeTxQuerier) ListEnablementRequests(ctx context.Context, arg store.ListEnablementRequestsParams) ([]store.EnablementRequest, error) {
+	requests := txq.enablementRequests
+	if int(arg.RowOffset) > len(requests) {
+		return nil, nil
+	}
+	requests = requests[arg.RowOffset:]
+	if int(arg.RowLimit) < len(requests) {
+		requests = requests[:arg.RowLimit]
+	}
+	return requests, nil
+}
+
+func (txq FakeTxQuerier) CreateEnablementRequest(ctx context.Context, arg store.CreateEnablementRequestParams) (store.EnablementRequest, error) {
+	return store.EnablementRequest{
+		Uid:              uuid.New(),
+		BillingAccountID: arg.BillingAccountID,
+		Kind:             arg.Kind,
+		Status:           store.EnablementStatusPending,
+		Reason:           arg.Reason,
+		RequestedBy:      arg.RequestedBy,
+	}, nil
+}
+
+func (txq FakeTxQuerier) SelectEnablementRequestForUpdate(ctx context.Context, arg store.SelectEnablementRequestForUpdateParams) (store.EnablementRequest, error) {
+	return txq.enablementRequest, txq.enablementRequestError
+}
+
+func (txq FakeTxQuerier) DecideEnablementRequest(ctx context.Context, arg store.DecideEnablementRequestParams) (store.EnablementRequest, error) {
+	request := txq.enablementRequest
+	request.Status = arg.Status
+	request.DecidedBy = arg.DecidedBy
+	request.DecisionReason = arg.DecisionReason
+	return request, nil
+}
+
+func (txq FakeTxQuerier) RevokeEnablementRequest(ctx context.Context, arg store.RevokeEnablementRequestParams) (store.EnablementRequest, error) {
+	return store.EnablementRequest{
+		BillingAccountID: arg.BillingAccountID,
+		Kind:             arg.Kind,
+		Status:           store.EnablementStatusRevoked,
+		RevokedBy:        arg.RevokedBy,
+		RevokeReason:     arg.RevokeReason,
+	}, txq.revokeEnablementError
+}
+
+func (txq FakeTxQuerier) CreateAuditEvent(ctx context.Context, arg store.CreateAuditEventParams) error {
+	if txq.auditEvents != nil {
+		*txq.auditEvents = append(*txq.auditEvents, arg)
+	}
+	return nil
+}
+
+func (txq FakeTxQuerier) EnableBillingAccountDemand(ctx context.Context, id string) (store.BillingAccount, error) {
+	*txq.enabled = append(*txq.enabled, "demand")
+	return store.BillingAccount{}, nil
+}
+
+func (txq FakeTxQuerier) EnableBillingAccountSupply(ctx context.Context, id string) (store.BillingAccount, error) {
+	*txq.enabled = append(*txq.enabled, "supply")
+	return store.BillingAccount{}, nil
+}
+
+func (txq FakeTxQuerier) DisableBillingAccountDemand(ctx context.Context, id string) (store.BillingAccount, error) {
+	*txq.disabled = append(*txq.disabled, "demand")
+	return store.BillingAccount{}, nil
+}
+
+func (txq FakeTxQuerier) DisableBillingAccountSupply(ctx context.Context, id string) (store.BillingAccount, error) {
+	*txq.disabled = append(*txq.disabled, "supply")
+	return store.BillingAccount{}, nil
+}
+
+func Test_CreateEnablementRequest(t *testing.T) {
+	t.Run("should fail when the kind is unknown", func(t *testing.T) {
+		server := NewServer(&FakeTxQuerier{}, zaptest.NewLogger(t))
+		_, err := server.CreateEnablementRequest(systemContext(), &CreateEnablementRequestRequest{BillingAccountId: "billing-account-id", Kind: "admin"})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, status.Code(err))
+		}
+	})
+	t.Run("should fail when the billing account is already enabled", func(t *testing.T) {
+		querier := FakeTxQuerier{getBillingAccount: store.BillingAccount{ID: "billing-account-id", DemandEnabled: true}}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		_, err := server.CreateEnablementRequest(systemContext(), &CreateEnablementRequestRequest{BillingAccountId: "billing-account-id", Kind: "demand"})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("expected: %s, got: %s", codes.FailedPrecondition, status.Code(err))
+		}
+	})
+	t.Run("should fail when a request is already pending", func(t *testing.T) {
+		querier := FakeTxQuerier{
+			getBillingAccount: store.BillingAccount{ID: "billing-account-id"},
+			enablementRequests: []store.EnablementRequest{
+				{Kind: store.EnablementKindDemand, Status: store.EnablementStatusPending},
+			},
+		}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		_, err := server.CreateEnablementRequest(systemContext(), &CreateEnablementRequestRequest{BillingAccountId: "billing-account-id", Kind: "demand"})
+		if status.Code(err) != codes.AlreadyExists {
+			t.Errorf("expected: %s, got: %s", codes.AlreadyExists, status.Code(err))
+		}
+	})
+	t.Run("should record who made the request", func(t *testing.T) {
+		querier := FakeTxQuerier{
+			getBillingAccount: store.BillingAccount{ID: "billing-account-id"},
+			enablementRequests: []store.EnablementRequest{
+				{Kind: store.EnablementKindSupply, Status: store.EnablementStatusPending},
+			},
+			members: []store.BillingAccountMember{
+				{BillingAccountID: "billing-account-id", PrincipalID: "user", Role: store.BillingAccountRoleAdmin},
+			},
+		}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		ctx := principal.NewContext(context.Background(), &principal.Principal{ID: "user"})
+		res, err := server.CreateEnablementRequest(ctx, &CreateEnablementRequestRequest{BillingAccountId: "billing-account-id", Kind: "demand", Reason: "launching"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if res.RequestedBy != "user" || res.Status != "pending" || res.Reason != "launching" {
+			t.Errorf("unexpected enablement request: %v", res)
+		}
+	})
+}
+
+func Test_ListEnablementRequests(t *testing.T) {
+	querier := FakeTxQuerier{getBillingAccount: store.BillingAccount{ID: "billing-account-id"}}
+	for i := 0; i < pagination.MinPageSize+1; i++ {
+		querier.enablementRequests = append(querier.enablementRequests, store.EnablementRequest{Uid: uuid.New(), BillingAccountID: "billing-account-id"})
+	}
+
+	t.Run("should return a page of requests and a token for the next one", func(t *testing.T) {
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		res, err := server.ListEnablementRequests(systemContext(), &ListEnablementRequestsRequest{BillingAccountId: "billing-account-id"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if len(res.EnablementRequests) != pagination.MinPageSize {
+			t.Errorf("expected: %d, got: %d", pagination.MinPageSize, len(res.EnablementRequests))
+		}
+		if res.NextPageToken == "" {
+			t.Fatalf("expected a next page token")
+		}
+
+		res, err = server.ListEnablementRequests(systemContext(), &ListEnablementRequestsRequest{BillingAccountId: "billing-account-id", PageToken: res.NextPageToken})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if len(res.EnablementRequests) != 1 || res.EnablementRequests[0].Id != querier.enablementRequests[pagination.MinPageSize].Uid.String() {
+			t.Errorf("expected the last request, got: %v", res.EnablementRequests)
+		}
+		if res.NextPageToken != "" {
+			t.Errorf("expected no next page token, got: %s", res.NextPageToken)
+		}
+	})
+	t.Run("should fail when the page token was issued for another billing account", func(t *testing.T) {
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		res, err := server.ListEnablementRequests(systemContext(), &ListEnablementRequestsRequest{BillingAccountId: "billing-account-id"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		_, err = server.ListEnablementRequests(systemContext(), &ListEnablementRequestsRequest{BillingAccountId: "other-billing-account-id", PageToken: res.NextPageToken})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, status.Code(err))
+		}
+	})
+}
+
+func Test_ApproveEnablementRequest(t *testing.T) {
+	t.Run("should fail when the caller is not an admin", func(t *testing.T) {
+		server := NewServer(&FakeTxQuerier{}, zaptest.NewLogger(t))
+		ctx := principal.NewContext(context.Background(), &principal.Principal{ID: "user"})
+		_, err := server.ApproveEnablementRequest(ctx, &DecideEnablementRequestRequest{BillingAccountId: "billing-account-id", Id: uuid.NewString(), Reason: "ok"})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("expected: %s, got: %s", codes.PermissionDenied, status.Code(err))
+		}
+	})
+	t.Run("should fail without a reason", func(t *testing.T) {
+		server := NewServer(&FakeTxQuerier{}, zaptest.NewLogger(t))
+		_, err := server.ApproveEnablementRequest(systemContext(), &DecideEnablementRequestRequest{BillingAccountId: "billing-account-id", Id: uuid.NewString()})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, status.Code(err))
+		}
+	})
+	t.Run("should fail when the request does not exist", func(t *testing.T) {
+		querier := FakeTxQuerier{enablementRequestError: pgx.ErrNoRows}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		_, err := server.ApproveEnablementRequest(systemContext(), &DecideEnablementRequestRequest{BillingAccountId: "billing-account-id", Id: uuid.NewString(), Reason: "ok"})
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("expected: %s, got: %s", codes.NotFound, status.Code(err))
+		}
+	})
+	t.Run("should fail when the request was already decided", func(t *testing.T) {
+		querier := FakeTxQuerier{enablementRequest: store.EnablementRequest{Status: store.EnablementStatusRejected}}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		_, err := server.ApproveEnablementRequest(systemContext(), &DecideEnablementRequestRequest{BillingAccountId: "billing-account-id", Id: uuid.NewString(), Reason: "ok"})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("expected: %s, got: %s", codes.FailedPrecondition, status.Code(err))
+		}
+	})
+	t.Run("should enable the billing account and record the decision", func(t *testing.T) {
+		var enabled []string
+		querier := FakeTxQuerier{
+			enablementRequest: store.EnablementRequest{
+				BillingAccountID: "billing-account-id",
+				Kind:             store.EnablementKindSupply,
+				Status:           store.EnablementStatusPending,
+			},
+			enabled: &enabled,
+		}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		ctx := principal.NewContext(context.Background(), &principal.Principal{ID: "admin", Admin: true})
+		res, err := server.ApproveEnablementRequest(ctx, &DecideEnablementRequestRequest{BillingAccountId: "billing-account-id", Id: uuid.NewString(), Reason: "verified"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if res.Status != "approved" || res.DecidedBy != "admin" || res.DecisionReason != "verified" {
+			t.Errorf("unexpected enablement request: %v", res)
+		}
+		if len(enabled) != 1 || enabled[0] != "supply" {
+			t.Errorf("expected supply to be enabled, got: %v", enabled)
+		}
+	})
+}
+
+func Test_RejectEnablementRequest(t *testing.T) {
+	t.Run("should not enable the billing account", func(t *testing.T) {
+		var enabled []string
+		querier := FakeTxQuerier{
+			enablementRequest: store.EnablementRequest{Kind: store.EnablementKindDemand, Status: store.EnablementStatusPending},
+			enabled:           &enabled,
+		}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		res, err := server.RejectEnablementRequest(systemContext(), &DecideEnablementRequestRequest{BillingAccountId: "billing-account-id", Id: uuid.NewString(), Reason: "unverified"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if res.Status != "rejected" || res.DecidedBy != "system" {
+			t.Errorf("unexpected enablement request: %v", res)
+		}
+		if len(enabled) != 0 {
+			t.Errorf("expected nothing to be enabled, got: %v", enabled)
+		}
+	})
+}
+
+func Test_RevokeEnablement(t *testing.T) {
+	t.Run("should fail when the billing account is not enabled", func(t *testing.T) {
+		querier := FakeTxQuerier{revokeEnablementError: pgx.ErrNoRows}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		_, err := server.RevokeEnablement(systemContext(), &RevokeEnablementRequest{BillingAccountId: "billing-account-id", Kind: "demand", Reason: "fraud"})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("expected: %s, got: %s", codes.FailedPrecondition, status.Code(err))
+		}
+	})
+	t.Run("should disable demand and record who revoked it", func(t *testing.T) {
+		var disabled []string
+		querier := FakeTxQuerier{disabled: &disabled}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		ctx := principal.NewContext(context.Background(), &principal.Principal{ID: "admin", Admin: true})
+		res, err := server.RevokeEnablement(ctx, &RevokeEnablementRequest{BillingAccountId: "billing-account-id", Kind: "demand", Reason: "fraud"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if res.Status != "revoked" || res.RevokedBy != "admin" || res.RevokeReason != "fraud" {
+			t.Errorf("unexpected enablement request: %v", res)
+		}
+		if len(disabled) != 1 || disabled[0] != "demand" {
+			t.Errorf("expected demand to be disabled, got: %v", disabled)
+		}
+	})
+	t.Run("should audit the revocation as a change from approved", func(t *testing.T) {
+		var events []store.CreateAuditEventParams
+		querier := FakeTxQuerier{disabled: &[]string{}, auditEvents: &events}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		ctx := principal.NewContext(context.Background(), &principal.Principal{ID: "admin", Admin: true})
+		req := &RevokeEnablementRequest{BillingAccountId: "billing-account-id", Kind: "demand", Reason: "fraud"}
+		interceptor := audit.UnaryServerInterceptor(&querier, zaptest.NewLogger(t))
+		info := &grpc.UnaryServerInfo{FullMethod: "/org.cudo.compute.v1.BillingAccountService/RevokeEnablement"}
+		_, err := interceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return server.RevokeEnablement(ctx, req.(*RevokeEnablementRequest))
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if len(events) != 1 {
+			t.Fatalf("expected one event, got: %v", events)
+		}
+		var diff map[string]map[string]interface{}
+		err = json.Unmarshal(events[0].Diff.Bytes, &diff)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if diff["status"]["old"] != "approved" || diff["status"]["new"] != "revoked" {
+			t.Errorf("expected the status to change from approved to revoked, got: %v", diff["status"])
+		}
+		if _, ok := diff["billing_account_id"]; ok {
+			t.Errorf("expected only the changed fields in the diff, got: %v", diff)
+		}
+	})
+}
@@ -0,0 +1,207 @@
+package billingaccount
+
+import (
+	"context"
+	"time"
+
+	"biller/lib/logger"
+	"biller/lib/pagination"
+	"biller/lib/principal"
+	"biller/lib/resource"
+	"biller/svc/compute/audit"
+	"biller/svc/compute/store"
+
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Billing accounts are active until they are suspended, e.g. for non-payment, or closed. Suspended accounts can be
+// reactivated, closed accounts can't. Only active accounts can create projects and orders, leases that are already
+// running are left alone. Every change of state is recorded in billing_account_state_change.
+
+// SuspendBillingAccount suspends an active billing account
+func (s *server) SuspendBillingAccount(ctx context.Context, req *ChangeBillingAccountStateRequest) (*BillingAccount, error) {
+	if !principal.IsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "only admins can suspend billing accounts")
+	}
+	return s.changeState(ctx, req, store.BillingAccountStateSuspended, func(ctx context.Context, q store.Querier, account store.BillingAccount) error {
+		if account.State != store.BillingAccountStateActive {
+			return status.Errorf(codes.FailedPrecondition, "billing account is %s", account.State)
+		}
+		return nil
+	})
+}
+
+// ReactivateBillingAccount makes a suspended billing account active again
+func (s *server) ReactivateBillingAccount(ctx context.Context, req *ChangeBillingAccountStateRequest) (*BillingAccount, error) {
+	if !principal.IsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "only admins can reactivate billing accounts")
+	}
+	return s.changeState(ctx, req, store.BillingAccountStateActive, func(ctx context.Context, q store.Querier, account store.BillingAccount) error {
+		if account.State != store.BillingAccountStateSuspended {
+			return status.Errorf(codes.FailedPrecondition, "billing account is %s", account.State)
+		}
+		return nil
+	})
+}
+
+// CloseBillingAccount closes a billing account once all of its projects have been deleted. The account is billed for
+// the period it is closed in and that spend is marked as its final invoice.
+func (s *server) CloseBillingAccount(ctx context.Context, req *ChangeBillingAccountStateRequest) (*BillingAccount, error) {
+	return s.changeState(ctx, req, store.BillingAccountStateClosed, func(ctx context.Context, q store.Querier, account store.BillingAccount) error {
+		err := Authorize(ctx, q, account.ID, PermissionBillingAccountClose)
+		if err != nil {
+			return err
+		}
+		if account.State == store.BillingAccountStateClosed {
+			return status.Error(codes.FailedPrecondition, "billing account is already closed")
+		}
+		projects, err := q.CountProjectsByBillingAccountId(ctx, account.ID)
+		if err != nil {
+			logger.FromContext(ctx, s.log).Error("query failed when counting billing account projects", zap.Error(err))
+			return status.Error(codes.Internal, "could not close billing account")
+		}
+		if projects > 0 {
+			return status.Errorf(codes.FailedPrecondition, "billing account still has %d projects, they must be deleted first", projects)
+		}
+
+		startTime, endTime := AccountBillingPeriod(account, time.Now())
+		spend, err := billAccount(ctx, q, account.ID, startTime, endTime)
+		if err != nil {
+			logger.FromContext(ctx, s.log).Error("error issuing final invoice", zap.String("billingAccountId", account.ID), zap.Error(err))
+			return status.Error(codes.Internal, "could not issue final invoice")
+		}
+		err = q.MarkBillingAccountSpendFinal(ctx, spend.Uid)
+		if err != nil {
+			logger.FromContext(ctx, s.log).Error("query failed when marking final invoice", zap.Error(err))
+			return status.Error(codes.Internal, "could not issue final invoice")
+		}
+		return nil
+	})
+}
+
+// changeState moves a billing account to a new state once check allows it, and records the change
+func (s *server) changeState(ctx context.Context, req *ChangeBillingAccountStateRequest, to store.BillingAccountState,
+	check func(context.Context, store.Querier, store.BillingAccount) error) (*BillingAccount, error) {
+	if !resource.ValidResourceID(req.Id) {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+	err := validateDecisionReason(req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, txq, err := s.querier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	account, err := txq.SelectBillingAccountForUpdate(ctx, req.Id)
+	if err == pgx.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "billing account not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "could not find billing account")
+	}
+	err = check(ctx, txq, account)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := txq.UpdateBillingAccountState(ctx, store.UpdateBillingAccountStateParams{
+		ID:    account.ID,
+		State: to,
+	})
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when changing billing account state", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not change billing account state")
+	}
+	_, err = txq.CreateBillingAccountStateChange(ctx, store.CreateBillingAccountStateChangeParams{
+		BillingAccountID: account.ID,
+		FromState:        account.State,
+		ToState:          to,
+		Reason:           req.Reason,
+		ChangedBy:        principal.Actor(ctx),
+	})
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when recording billing account state change", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not change billing account state")
+	}
+	err = audit.Record(ctx, txq, "billing-accounts/"+account.ID, toBillingAccountPb(account), toBillingAccountPb(updated))
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when auditing billing account state change", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not change billing account state")
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("transaction failed when changing billing account state", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not change billing account state")
+	}
+	logger.FromContext(ctx, s.log).Info("changed billing account state",
+		zap.String("billingAccountId", account.ID),
+		zap.String("from", string(account.State)),
+		zap.String("to", string(to)),
+		zap.String("changedBy", principal.Actor(ctx)))
+	return toBillingAccountPb(updated), nil
+}
+
+func (s *server) ListBillingAccountStateChanges(ctx context.Context, req *ListBillingAccountStateChangesRequest) (*ListBillingAccountStateChangesResponse, error) {
+	var res ListBillingAccountStateChangesResponse
+
+	if !resource.ValidResourceID(req.Id) {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+	pageSize := pagination.PageSize(req.PageSize)
+	fingerprint := pagination.Fingerprint("ListBillingAccountStateChanges", principal.Fingerprint(ctx), req.Id)
+	cursor, err := pagination.Decode(req.PageToken, fingerprint)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+
+	tx, txq, err := s.querier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	account, err := findBillingAccount(ctx, txq, req.Id, PermissionBillingAccountGet)
+	if err != nil {
+		return nil, err
+	}
+
+	// fetch one extra row to find out whether there is another page
+	changes, err := txq.ListBillingAccountStateChanges(ctx, store.ListBillingAccountStateChangesParams{
+		BillingAccountID: account.ID,
+		RowLimit:         pageSize + 1,
+		RowOffset:        cursor.Offset,
+	})
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when listing billing account state changes", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not list billing account state changes")
+	}
+
+	if len(changes) > int(pageSize) {
+		changes = changes[:pageSize]
+		res.NextPageToken = pagination.Encode(cursor.Next(false, time.Time{}, "", pageSize), fingerprint)
+	}
+	res.PageSize = pageSize
+
+	res.StateChanges = make([]*BillingAccountStateChange, len(changes))
+	for i, row := range changes {
+		res.StateChanges[i] = &BillingAccountStateChange{
+			Id:               row.Uid.String(),
+			BillingAccountId: row.BillingAccountID,
+			FromState:        string(row.FromState),
+			ToState:          string(row.ToState),
+			Reason:           row.Reason,
+			ChangedBy:        row.ChangedBy,
+			ChangeTime:       timestamppb.New(row.ChangeTime),
+		}
+	}
+	return &res, nil
+}
@@ -0,0 +1,207 @@
+package billingaccount
+
+import (
+	"context"
+	"testing"
+
+	"biller/lib/pagination"
+	"biller/lib/principal"
+	"biller/svc/compute/store"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (txq FakeTxQuerier) UpdateBillingAccountState(ctx context.Context, arg store.UpdateBillingAccountStateParams) (store.BillingAccount, error) {
+	account := txq.getBillingAccount
+	account.State = arg.State
+	return account, nil
+}
+
+func (txq FakeTxQuerier) CreateBillingAccountStateChange(ctx context.Context, arg store.CreateBillingAccountStateChangeParams) (store.BillingAccountStateChange, error) {
+	if txq.stateChange != nil {
+		*txq.stateChange = arg
+	}
+	return store.BillingAccountStateChange{}, nil
+}
+
+func (txq FakeTxQuerier) ListBillingAccountStateChanges(ctx context.Context, arg store.ListBillingAccountStateChangesParams) ([]store.BillingAccountStateChange, error) {
+	changes := txq.stateChanges
+	if int(arg.RowOffset) > len(changes) {
+		return nil, nil
+	}
+	changes = changes[arg.RowOffset:]
+	if int(arg.RowLimit) < len(changes) {
+		changes = changes[:arg.RowLimit]
+	}
+	return changes, nil
+}
+
+func (txq FakeTxQuerier) CountProjectsByBillingAccountId(ctx context.Context, billingAccountID string) (int64, error) {
+	return txq.projectCount, nil
+}
+
+func (txq FakeTxQuerier) MarkBillingAccountSpendFinal(ctx context.Context, uid uuid.UUID) error {
+	*txq.finalSpend = uid
+	return nil
+}
+
+func Test_SuspendBillingAccount(t *testing.T) {
+	t.Run("should fail when the caller is not an admin", func(t *testing.T) {
+		server := NewServer(&FakeTxQuerier{}, zaptest.NewLogger(t))
+		ctx := principal.NewContext(context.Background(), &principal.Principal{ID: "user"})
+		_, err := server.SuspendBillingAccount(ctx, &ChangeBillingAccountStateRequest{Id: "billing-account-id", Reason: "unpaid"})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("expected: %s, got: %s", codes.PermissionDenied, status.Code(err))
+		}
+	})
+	t.Run("should fail when the account is closed", func(t *testing.T) {
+		querier := FakeTxQuerier{getBillingAccount: store.BillingAccount{ID: "billing-account-id", State: store.BillingAccountStateClosed}}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		_, err := server.SuspendBillingAccount(systemContext(), &ChangeBillingAccountStateRequest{Id: "billing-account-id", Reason: "unpaid"})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("expected: %s, got: %s", codes.FailedPrecondition, status.Code(err))
+		}
+	})
+	t.Run("should suspend the account and record the change", func(t *testing.T) {
+		var change store.CreateBillingAccountStateChangeParams
+		querier := FakeTxQuerier{
+			getBillingAccount: store.BillingAccount{ID: "billing-account-id", State: store.BillingAccountStateActive},
+			stateChange:       &change,
+		}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		ctx := principal.NewContext(context.Background(), &principal.Principal{ID: "admin", Admin: true})
+		res, err := server.SuspendBillingAccount(ctx, &ChangeBillingAccountStateRequest{Id: "billing-account-id", Reason: "unpaid"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if res.State != "suspended" {
+			t.Errorf("expected: %s, got: %s", "suspended", res.State)
+		}
+		if change.FromState != store.BillingAccountStateActive || change.ToState != store.BillingAccountStateSuspended || change.ChangedBy != "admin" || change.Reason != "unpaid" {
+			t.Errorf("unexpected state change: %+v", change)
+		}
+	})
+}
+
+func Test_ReactivateBillingAccount(t *testing.T) {
+	t.Run("should fail when the account is not suspended", func(t *testing.T) {
+		querier := FakeTxQuerier{getBillingAccount: store.BillingAccount{ID: "billing-account-id", State: store.BillingAccountStateActive}}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		_, err := server.ReactivateBillingAccount(systemContext(), &ChangeBillingAccountStateRequest{Id: "billing-account-id", Reason: "paid"})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("expected: %s, got: %s", codes.FailedPrecondition, status.Code(err))
+		}
+	})
+}
+
+func Test_CloseBillingAccount(t *testing.T) {
+	t.Run("should fail when the account still has projects", func(t *testing.T) {
+		querier := FakeTxQuerier{
+			getBillingAccount: store.BillingAccount{ID: "billing-account-id", State: store.BillingAccountStateActive},
+			projectCount:      2,
+		}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		_, err := server.CloseBillingAccount(systemContext(), &ChangeBillingAccountStateRequest{Id: "billing-account-id", Reason: "leaving"})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("expected: %s, got: %s", codes.FailedPrecondition, status.Code(err))
+		}
+	})
+	t.Run("should fail when the principal is not a member of the account", func(t *testing.T) {
+		server := NewServer(&FakeTxQuerier{}, zaptest.NewLogger(t))
+		ctx := principal.NewContext(context.Background(), &principal.Principal{ID: "user"})
+		_, err := server.CloseBillingAccount(ctx, &ChangeBillingAccountStateRequest{Id: "billing-account-id", Reason: "leaving"})
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("expected: %s, got: %s", codes.NotFound, status.Code(err))
+		}
+	})
+	t.Run("should issue a final invoice and close the account", func(t *testing.T) {
+		var change store.CreateBillingAccountStateChangeParams
+		var finalSpend uuid.UUID
+		spendUID := uuid.New()
+		querier := FakeTxQuerier{
+			getBillingAccount:         store.BillingAccount{ID: "billing-account-id", State: store.BillingAccountStateSuspended},
+			createBillingAccountSpend: store.BillingAccountSpend{Uid: spendUID},
+			stateChange:               &change,
+			finalSpend:                &finalSpend,
+		}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		res, err := server.CloseBillingAccount(systemContext(), &ChangeBillingAccountStateRequest{Id: "billing-account-id", Reason: "leaving"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if res.State != "closed" {
+			t.Errorf("expected: %s, got: %s", "closed", res.State)
+		}
+		if finalSpend != spendUID {
+			t.Errorf("expected the spend of the period to be the final invoice, got: %s", finalSpend)
+		}
+		if change.FromState != store.BillingAccountStateSuspended || change.ToState != store.BillingAccountStateClosed {
+			t.Errorf("unexpected state change: %+v", change)
+		}
+	})
+}
+
+func Test_ListBillingAccountStateChanges(t *testing.T) {
+	t.Run("should return a page of state changes and a token for the next one", func(t *testing.T) {
+		querier := FakeTxQuerier{getBillingAccount: store.BillingAccount{ID: "billing-account-id"}}
+		for i := 0; i < pagination.MinPageSize+1; i++ {
+			querier.stateChanges = append(querier.stateChanges, store.BillingAccountStateChange{Uid: uuid.New(), BillingAccountID: "billing-account-id"})
+		}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		res, err := server.ListBillingAccountStateChanges(systemContext(), &ListBillingAccountStateChangesRequest{Id: "billing-account-id"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if len(res.StateChanges) != pagination.MinPageSize {
+			t.Errorf("expected: %d, got: %d", pagination.MinPageSize, len(res.StateChanges))
+		}
+		if res.NextPageToken == "" {
+			t.Fatalf("expected a next page token")
+		}
+
+		res, err = server.ListBillingAccountStateChanges(systemContext(), &ListBillingAccountStateChangesRequest{Id: "billing-account-id", PageToken: res.NextPageToken})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if len(res.StateChanges) != 1 || res.StateChanges[0].Id != querier.stateChanges[pagination.MinPageSize].Uid.String() {
+			t.Errorf("expected the last state change, got: %v", res.StateChanges)
+		}
+		if res.NextPageToken != "" {
+			t.Errorf("expected no next page token, got: %s", res.NextPageToken)
+		}
+	})
+	t.Run("should fail when the page token is invalid", func(t *testing.T) {
+		server := NewServer(&FakeTxQuerier{}, zaptest.NewLogger(t))
+		_, err := server.ListBillingAccountStateChanges(systemContext(), &ListBillingAccountStateChangesRequest{Id: "billing-account-id", PageToken: "invalid"})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, status.Code(err))
+		}
+	})
+}
+
+func Test_EnsureDemandEnabled(t *testing.T) {
+	t.Run("should refuse a suspended account", func(t *testing.T) {
+		querier := FakeTxQuerier{getBillingAccount: store.BillingAccount{ID: "billing-account-id", DemandEnabled: true, State: store.BillingAccountStateSuspended}}
+		err := EnsureDemandEnabled(context.Background(), &querier, "billing-account-id")
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("expected: %s, got: %s", codes.FailedPrecondition, status.Code(err))
+		}
+	})
+	t.Run("should refuse a closed account", func(t *testing.T) {
+		querier := FakeTxQuerier{getBillingAccount: store.BillingAccount{ID: "billing-account-id", SupplyEnabled: true, State: store.BillingAccountStateClosed}}
+		err := EnsureSupplyEnabled(context.Background(), &querier, "billing-account-id")
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("expected: %s, got: %s", codes.FailedPrecondition, status.Code(err))
+		}
+	})
+	t.Run("should allow an active account", func(t *testing.T) {
+		querier := FakeTxQuerier{getBillingAccount: store.BillingAccount{ID: "billing-account-id", DemandEnabled: true, State: store.BillingAccountStateActive}}
+		err := EnsureDemandEnabled(context.Background(), &querier, "billing-account-id")
+		if err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+	})
+}
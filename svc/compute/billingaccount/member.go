@@ -0,0 +1,279 @@
+package billingaccount
+
+import (
+	"context"
+	"time"
+	"unicode/utf8"
+
+	"biller/lib/logger"
+	"biller/lib/pagination"
+	"biller/lib/principal"
+	"biller/lib/resource"
+	"biller/svc/compute/audit"
+	"biller/svc/compute/store"
+
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Members are managed by owners and admins of a billing account, only owners can make or unmake other owners. An
+// account always keeps at least one owner so it can't be left without anyone able to close it.
+
+const maxPrincipalIDLength = 255
+
+// ParseRole returns the billing account role named by role
+func ParseRole(role string) (store.BillingAccountRole, bool) {
+	switch store.BillingAccountRole(role) {
+	case store.BillingAccountRoleOwner, store.BillingAccountRoleAdmin, store.BillingAccountRoleViewer,
+		store.BillingAccountRoleBillingViewer:
+		return store.BillingAccountRole(role), true
+	}
+	return "", false
+}
+
+func memberResourceName(member store.BillingAccountMember) string {
+	return "billing-accounts/" + member.BillingAccountID + "/members/" + member.PrincipalID
+}
+
+func validatePrincipalID(id string) error {
+	if id == "" || utf8.RuneCountInString(id) > maxPrincipalIDLength {
+		return status.Error(codes.InvalidArgument, "invalid principal id")
+	}
+	return nil
+}
+
+func (s *server) ListBillingAccountMembers(ctx context.Context, req *ListBillingAccountMembersRequest) (*ListBillingAccountMembersResponse, error) {
+	var res ListBillingAccountMembersResponse
+
+	if !resource.ValidResourceID(req.BillingAccountId) {
+		return nil, status.Error(codes.InvalidArgument, "invalid billing account id")
+	}
+	pageSize := pagination.PageSize(req.PageSize)
+	fingerprint := pagination.Fingerprint("ListBillingAccountMembers", principal.Fingerprint(ctx), req.BillingAccountId)
+	cursor, err := pagination.Decode(req.PageToken, fingerprint)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+
+	tx, txq, err := s.querier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	account, err := findBillingAccount(ctx, txq, req.BillingAccountId, PermissionMembersList)
+	if err != nil {
+		return nil, err
+	}
+
+	// fetch one extra row to find out whether there is another page
+	members, err := txq.ListBillingAccountMembers(ctx, store.ListBillingAccountMembersParams{
+		BillingAccountID: account.ID,
+		RowLimit:         pageSize + 1,
+		RowOffset:        cursor.Offset,
+	})
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when listing billing account members", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not list billing account members")
+	}
+
+	if len(members) > int(pageSize) {
+		members = members[:pageSize]
+		res.NextPageToken = pagination.Encode(cursor.Next(false, time.Time{}, "", pageSize), fingerprint)
+	}
+	res.PageSize = pageSize
+
+	res.Members = make([]*BillingAccountMember, len(members))
+	for i, row := range members {
+		res.Members[i] = toBillingAccountMemberPb(row)
+	}
+	return &res, nil
+}
+
+func (s *server) SetBillingAccountMember(ctx context.Context, req *SetBillingAccountMemberRequest) (*BillingAccountMember, error) {
+	if !resource.ValidResourceID(req.BillingAccountId) {
+		return nil, status.Error(codes.InvalidArgument, "invalid billing account id")
+	}
+	err := validatePrincipalID(req.PrincipalId)
+	if err != nil {
+		return nil, err
+	}
+	role, ok := ParseRole(req.Role)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "role must be owner, admin, viewer or billing_viewer")
+	}
+
+	tx, txq, err := s.querier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// lock the billing account so concurrent changes can't remove its last owner
+	account, err := s.lockMembers(ctx, txq, req.BillingAccountId)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := txq.FindBillingAccountMember(ctx, store.FindBillingAccountMemberParams{
+		BillingAccountID: account.ID,
+		PrincipalID:      req.PrincipalId,
+	})
+	if err != nil && err != pgx.ErrNoRows {
+		logger.FromContext(ctx, s.log).Error("query failed when finding billing account member", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not set billing account member")
+	}
+	isOwner := err == nil && existing.Role == store.BillingAccountRoleOwner
+	var before *BillingAccountMember
+	if err == nil {
+		before = toBillingAccountMemberPb(existing)
+	}
+	if role == store.BillingAccountRoleOwner || isOwner {
+		err = Authorize(ctx, txq, account.ID, PermissionOwnersManage)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if isOwner && role != store.BillingAccountRoleOwner {
+		err = s.ensureAnotherOwner(ctx, txq, account.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	member, err := txq.SetBillingAccountMember(ctx, store.SetBillingAccountMemberParams{
+		BillingAccountID: account.ID,
+		PrincipalID:      req.PrincipalId,
+		Role:             role,
+	})
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when setting billing account member", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not set billing account member")
+	}
+	err = audit.Record(ctx, txq, memberResourceName(member), before, toBillingAccountMemberPb(member))
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when auditing billing account member change", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not set billing account member")
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("transaction failed when setting billing account member", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not set billing account member")
+	}
+	logger.FromContext(ctx, s.log).Info("set billing account member",
+		zap.String("billingAccountId", member.BillingAccountID),
+		zap.String("principalId", member.PrincipalID),
+		zap.String("role", string(member.Role)),
+		zap.String("changedBy", principal.Actor(ctx)))
+	return toBillingAccountMemberPb(member), nil
+}
+
+func (s *server) RemoveBillingAccountMember(ctx context.Context, req *RemoveBillingAccountMemberRequest) (*emptypb.Empty, error) {
+	if !resource.ValidResourceID(req.BillingAccountId) {
+		return nil, status.Error(codes.InvalidArgument, "invalid billing account id")
+	}
+	err := validatePrincipalID(req.PrincipalId)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, txq, err := s.querier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	account, err := s.lockMembers(ctx, txq, req.BillingAccountId)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := txq.FindBillingAccountMember(ctx, store.FindBillingAccountMemberParams{
+		BillingAccountID: account.ID,
+		PrincipalID:      req.PrincipalId,
+	})
+	if err == pgx.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "member not found")
+	}
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when finding billing account member", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not remove billing account member")
+	}
+	if existing.Role == store.BillingAccountRoleOwner {
+		err = Authorize(ctx, txq, account.ID, PermissionOwnersManage)
+		if err != nil {
+			return nil, err
+		}
+		err = s.ensureAnotherOwner(ctx, txq, account.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = txq.DeleteBillingAccountMember(ctx, store.DeleteBillingAccountMemberParams{
+		BillingAccountID: account.ID,
+		PrincipalID:      existing.PrincipalID,
+	})
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when removing billing account member", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not remove billing account member")
+	}
+	err = audit.Record(ctx, txq, memberResourceName(existing), toBillingAccountMemberPb(existing), nil)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when auditing billing account member removal", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not remove billing account member")
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("transaction failed when removing billing account member", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not remove billing account member")
+	}
+	logger.FromContext(ctx, s.log).Info("removed billing account member",
+		zap.String("billingAccountId", account.ID),
+		zap.String("principalId", existing.PrincipalID),
+		zap.String("changedBy", principal.Actor(ctx)))
+	return &emptypb.Empty{}, nil
+}
+
+// lockMembers locks the billing account whose members are being managed once the principal may manage them
+func (s *server) lockMembers(ctx context.Context, q store.Querier, billingAccountID string) (store.BillingAccount, error) {
+	err := Authorize(ctx, q, billingAccountID, PermissionMembersManage)
+	if err != nil {
+		return store.BillingAccount{}, err
+	}
+	account, err := q.SelectBillingAccountForUpdate(ctx, billingAccountID)
+	if err == pgx.ErrNoRows {
+		return account, status.Error(codes.NotFound, "billing account not found")
+	}
+	if err != nil {
+		return account, status.Error(codes.Internal, "could not find billing account")
+	}
+	return account, nil
+}
+
+// ensureAnotherOwner checks an owner can be removed or demoted without leaving the billing account without owners
+func (s *server) ensureAnotherOwner(ctx context.Context, q store.Querier, billingAccountID string) error {
+	owners, err := q.CountBillingAccountOwners(ctx, billingAccountID)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when counting billing account owners", zap.Error(err))
+		return status.Error(codes.Internal, "could not count billing account owners")
+	}
+	if owners <= 1 {
+		return status.Error(codes.FailedPrecondition, "the last owner of a billing account can't be removed")
+	}
+	return nil
+}
+
+func toBillingAccountMemberPb(in store.BillingAccountMember) *BillingAccountMember {
+	return &BillingAccountMember{
+		BillingAccountId: in.BillingAccountID,
+		PrincipalId:      in.PrincipalID,
+		Role:             string(in.Role),
+		CreateTime:       timestamppb.New(in.CreateTime),
+		UpdateTime:       timestamppb.New(in.UpdateTime),
+	}
+}
@@ -0,0 +1,279 @@
+package billingaccount
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"biller/lib/pagination"
+	"biller/lib/principal"
+	"biller/svc/compute/store"
+
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (txq FakeTxQuerier) FindBillingAccountMember(ctx context.Context, arg store.FindBillingAccountMemberParams) (store.BillingAccountMember, error) {
+	for _, member := range txq.members {
+		if member.BillingAccountID == arg.BillingAccountID && member.PrincipalID == arg.PrincipalID {
+			return member, nil
+		}
+	}
+	return store.BillingAccountMember{}, pgx.ErrNoRows
+}
+
+func (txq FakeTxQuerier) ListBillingAccountMembers(ctx context.Context, arg store.ListBillingAccountMembersParams) ([]store.BillingAccountMember, error) {
+	var members []store.BillingAccountMember
+	for _, member := range txq.members {
+		if member.BillingAccountID == arg.BillingAccountID {
+			members = append(members, member)
+		}
+	}
+	if int(arg.RowOffset) > len(members) {
+		return nil, nil
+	}
+	members = members[arg.RowOffset:]
+	if int(arg.RowLimit) < len(members) {
+		members = members[:arg.RowLimit]
+	}
+	return members, nil
+}
+
+func (txq FakeTxQuerier) ListBillingAccountMembershipsByPrincipalId(ctx context.Context, principalID string) ([]store.BillingAccountMember, error) {
+	var members []store.BillingAccountMember
+	for _, member := range txq.members {
+		if member.PrincipalID == principalID {
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+func (txq FakeTxQuerier) SetBillingAccountMember(ctx context.Context, arg store.SetBillingAccountMemberParams) (store.BillingAccountMember, error) {
+	if txq.setMember != nil {
+		*txq.setMember = arg
+	}
+	return store.BillingAccountMember{BillingAccountID: arg.BillingAccountID, PrincipalID: arg.PrincipalID, Role: arg.Role}, nil
+}
+
+func (txq FakeTxQuerier) DeleteBillingAccountMember(ctx context.Context, arg store.DeleteBillingAccountMemberParams) (int64, error) {
+	if txq.deletedMember != nil {
+		*txq.deletedMember = arg
+	}
+	return 1, nil
+}
+
+func (txq FakeTxQuerier) CountBillingAccountOwners(ctx context.Context, billingAccountID string) (int64, error) {
+	var owners int64
+	for _, member := range txq.members {
+		if member.BillingAccountID == billingAccountID && member.Role == store.BillingAccountRoleOwner {
+			owners++
+		}
+	}
+	return owners, nil
+}
+
+func member(principalID string, role store.BillingAccountRole) store.BillingAccountMember {
+	return store.BillingAccountMember{BillingAccountID: "billing-account-id", PrincipalID: principalID, Role: role}
+}
+
+func systemContext() context.Context {
+	return principal.NewContext(context.Background(), &principal.Principal{ID: "system", Admin: true})
+}
+
+func userContext(id string) context.Context {
+	return principal.NewContext(context.Background(), &principal.Principal{ID: id})
+}
+
+func keyContext(billingAccountID string, role store.BillingAccountRole) context.Context {
+	return principal.NewContext(context.Background(), &principal.Principal{ID: "apikey/abc", BillingAccountID: billingAccountID, Role: string(role)})
+}
+
+func Test_Authorize(t *testing.T) {
+	querier := FakeTxQuerier{members: []store.BillingAccountMember{
+		member("owner", store.BillingAccountRoleOwner),
+		member("viewer", store.BillingAccountRoleViewer),
+		member("billing-viewer", store.BillingAccountRoleBillingViewer),
+	}}
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		permission Permission
+		code       codes.Code
+	}{
+		{"requests without a principal have no permissions", context.Background(), PermissionBillingAccountClose, codes.Unauthenticated},
+		{"the system has every permission", systemContext(), PermissionBillingAccountClose, codes.OK},
+		{"admins have every permission", principal.NewContext(context.Background(), &principal.Principal{ID: "admin", Admin: true}), PermissionBillingAccountClose, codes.OK},
+		{"owners can close the account", userContext("owner"), PermissionBillingAccountClose, codes.OK},
+		{"viewers can get projects", userContext("viewer"), PermissionProjectsGet, codes.OK},
+		{"viewers can't get spend", userContext("viewer"), PermissionSpendGet, codes.PermissionDenied},
+		{"billing viewers can get spend", userContext("billing-viewer"), PermissionSpendGet, codes.OK},
+		{"billing viewers can't get projects", userContext("billing-viewer"), PermissionProjectsGet, codes.PermissionDenied},
+		{"non members are told the account doesn't exist", userContext("stranger"), PermissionBillingAccountGet, codes.NotFound},
+		{"keys have the permissions of their role", keyContext("billing-account-id", store.BillingAccountRoleViewer), PermissionProjectsGet, codes.OK},
+		{"keys don't have permissions their role lacks", keyContext("billing-account-id", store.BillingAccountRoleViewer), PermissionSpendGet, codes.PermissionDenied},
+		{"keys can't use other billing accounts", keyContext("other-account-id", store.BillingAccountRoleOwner), PermissionBillingAccountGet, codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Authorize(tt.ctx, &querier, "billing-account-id", tt.permission)
+			if status.Code(err) != tt.code {
+				t.Errorf("expected: %s, got: %s", tt.code, status.Code(err))
+			}
+		})
+	}
+}
+
+func Test_Scope(t *testing.T) {
+	t.Run("should only include billing accounts whose role grants the permission", func(t *testing.T) {
+		querier := FakeTxQuerier{members: []store.BillingAccountMember{
+			{BillingAccountID: "account-a", PrincipalID: "user", Role: store.BillingAccountRoleViewer},
+			{BillingAccountID: "account-b", PrincipalID: "user", Role: store.BillingAccountRoleBillingViewer},
+		}}
+		all, ids, err := Scope(userContext("user"), &querier, PermissionSpendGet)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if all || len(ids) != 1 || ids[0] != "account-b" {
+			t.Errorf("unexpected scope: %v %v", all, ids)
+		}
+	})
+}
+
+func Test_CreateBillingAccountOwner(t *testing.T) {
+	t.Run("should make the caller the owner of the billing account", func(t *testing.T) {
+		var set store.SetBillingAccountMemberParams
+		querier := FakeTxQuerier{createBillingAccount: store.BillingAccount{ID: "billing-account-id"}, setMember: &set}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		_, err := server.CreateBillingAccount(userContext("user"), &CreateBillingAccountRequest{})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if set.BillingAccountID != "billing-account-id" || set.PrincipalID != "user" || set.Role != store.BillingAccountRoleOwner {
+			t.Errorf("unexpected member: %+v", set)
+		}
+	})
+}
+
+func Test_ListBillingAccountMembers(t *testing.T) {
+	t.Run("should return a page of members and a token for the next one", func(t *testing.T) {
+		querier := FakeTxQuerier{getBillingAccount: store.BillingAccount{ID: "billing-account-id"}}
+		for i := 0; i < pagination.MinPageSize+1; i++ {
+			querier.members = append(querier.members, member(fmt.Sprintf("user-%d", i), store.BillingAccountRoleViewer))
+		}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		res, err := server.ListBillingAccountMembers(systemContext(), &ListBillingAccountMembersRequest{BillingAccountId: "billing-account-id"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if len(res.Members) != pagination.MinPageSize {
+			t.Errorf("expected: %d, got: %d", pagination.MinPageSize, len(res.Members))
+		}
+		if res.NextPageToken == "" {
+			t.Fatalf("expected a next page token")
+		}
+
+		res, err = server.ListBillingAccountMembers(systemContext(), &ListBillingAccountMembersRequest{BillingAccountId: "billing-account-id", PageToken: res.NextPageToken})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if len(res.Members) != 1 || res.Members[0].PrincipalId != fmt.Sprintf("user-%d", pagination.MinPageSize) {
+			t.Errorf("expected the last member, got: %v", res.Members)
+		}
+		if res.NextPageToken != "" {
+			t.Errorf("expected no next page token, got: %s", res.NextPageToken)
+		}
+	})
+	t.Run("should fail when the page token is invalid", func(t *testing.T) {
+		server := NewServer(&FakeTxQuerier{}, zaptest.NewLogger(t))
+		_, err := server.ListBillingAccountMembers(systemContext(), &ListBillingAccountMembersRequest{BillingAccountId: "billing-account-id", PageToken: "invalid"})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, status.Code(err))
+		}
+	})
+}
+
+func Test_SetBillingAccountMember(t *testing.T) {
+	members := []store.BillingAccountMember{
+		member("owner", store.BillingAccountRoleOwner),
+		member("admin", store.BillingAccountRoleAdmin),
+		member("viewer", store.BillingAccountRoleViewer),
+	}
+	t.Run("should fail for an unknown role", func(t *testing.T) {
+		server := NewServer(&FakeTxQuerier{getBillingAccount: store.BillingAccount{ID: "billing-account-id"}, members: members}, zaptest.NewLogger(t))
+		_, err := server.SetBillingAccountMember(userContext("owner"), &SetBillingAccountMemberRequest{BillingAccountId: "billing-account-id", PrincipalId: "user", Role: "superuser"})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, status.Code(err))
+		}
+	})
+	t.Run("should fail when a viewer manages members", func(t *testing.T) {
+		server := NewServer(&FakeTxQuerier{getBillingAccount: store.BillingAccount{ID: "billing-account-id"}, members: members}, zaptest.NewLogger(t))
+		_, err := server.SetBillingAccountMember(userContext("viewer"), &SetBillingAccountMemberRequest{BillingAccountId: "billing-account-id", PrincipalId: "user", Role: "viewer"})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("expected: %s, got: %s", codes.PermissionDenied, status.Code(err))
+		}
+	})
+	t.Run("should fail when an admin makes an owner", func(t *testing.T) {
+		server := NewServer(&FakeTxQuerier{getBillingAccount: store.BillingAccount{ID: "billing-account-id"}, members: members}, zaptest.NewLogger(t))
+		_, err := server.SetBillingAccountMember(userContext("admin"), &SetBillingAccountMemberRequest{BillingAccountId: "billing-account-id", PrincipalId: "user", Role: "owner"})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("expected: %s, got: %s", codes.PermissionDenied, status.Code(err))
+		}
+	})
+	t.Run("should fail when the last owner is demoted", func(t *testing.T) {
+		server := NewServer(&FakeTxQuerier{getBillingAccount: store.BillingAccount{ID: "billing-account-id"}, members: members}, zaptest.NewLogger(t))
+		_, err := server.SetBillingAccountMember(userContext("owner"), &SetBillingAccountMemberRequest{BillingAccountId: "billing-account-id", PrincipalId: "owner", Role: "admin"})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("expected: %s, got: %s", codes.FailedPrecondition, status.Code(err))
+		}
+	})
+	t.Run("should let an admin add a viewer", func(t *testing.T) {
+		var set store.SetBillingAccountMemberParams
+		server := NewServer(&FakeTxQuerier{getBillingAccount: store.BillingAccount{ID: "billing-account-id"}, members: members, setMember: &set}, zaptest.NewLogger(t))
+		res, err := server.SetBillingAccountMember(userContext("admin"), &SetBillingAccountMemberRequest{BillingAccountId: "billing-account-id", PrincipalId: "user", Role: "viewer"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if res.Role != "viewer" || set.PrincipalID != "user" || set.Role != store.BillingAccountRoleViewer {
+			t.Errorf("unexpected member: %v", res)
+		}
+	})
+}
+
+func Test_RemoveBillingAccountMember(t *testing.T) {
+	t.Run("should fail when the member doesn't exist", func(t *testing.T) {
+		server := NewServer(&FakeTxQuerier{getBillingAccount: store.BillingAccount{ID: "billing-account-id"}, members: []store.BillingAccountMember{member("owner", store.BillingAccountRoleOwner)}}, zaptest.NewLogger(t))
+		_, err := server.RemoveBillingAccountMember(userContext("owner"), &RemoveBillingAccountMemberRequest{BillingAccountId: "billing-account-id", PrincipalId: "user"})
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("expected: %s, got: %s", codes.NotFound, status.Code(err))
+		}
+	})
+	t.Run("should fail when the last owner is removed", func(t *testing.T) {
+		server := NewServer(&FakeTxQuerier{getBillingAccount: store.BillingAccount{ID: "billing-account-id"}, members: []store.BillingAccountMember{member("owner", store.BillingAccountRoleOwner)}}, zaptest.NewLogger(t))
+		_, err := server.RemoveBillingAccountMember(userContext("owner"), &RemoveBillingAccountMemberRequest{BillingAccountId: "billing-account-id", PrincipalId: "owner"})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("expected: %s, got: %s", codes.FailedPrecondition, status.Code(err))
+		}
+	})
+	t.Run("should remove an owner when another one is left", func(t *testing.T) {
+		var deleted store.DeleteBillingAccountMemberParams
+		querier := FakeTxQuerier{
+			getBillingAccount: store.BillingAccount{ID: "billing-account-id"},
+			members: []store.BillingAccountMember{
+				member("owner", store.BillingAccountRoleOwner),
+				member("other-owner", store.BillingAccountRoleOwner),
+			},
+			deletedMember: &deleted,
+		}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		_, err := server.RemoveBillingAccountMember(userContext("owner"), &RemoveBillingAccountMemberRequest{BillingAccountId: "billing-account-id", PrincipalId: "other-owner"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if deleted.PrincipalID != "other-owner" {
+			t.Errorf("unexpected removed member: %+v", deleted)
+		}
+	})
+}
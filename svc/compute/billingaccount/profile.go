@@ -0,0 +1,140 @@
+package billingaccount
+
+import (
+	"net/mail"
+	"regexp"
+	"time"
+	"unicode/utf8"
+
+	"biller/svc/compute/store"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The profile of a billing account holds what is needed to invoice it: who it is, where invoices are sent, its postal
+// address and tax id, and the currency and time zone it is billed in.
+
+const (
+	maxDisplayNameLength   = 100
+	maxContactEmails       = 10
+	maxAddressLineLength   = 200
+	defaultCurrency        = "USD"
+	defaultBillingTimezone = "UTC"
+)
+
+var (
+	countryCodeRegex = regexp.MustCompile(`^[A-Z]{2}$`)
+	currencyRegex    = regexp.MustCompile(`^[A-Z]{3}$`)
+	taxIDRegex       = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9 ./-]{0,31}$`)
+)
+
+// updatableBillingAccountFields are the paths of an update mask that UpdateBillingAccount can change
+var updatableBillingAccountFields = map[string]bool{
+	"display_name":   true,
+	"contact_emails": true,
+	"address":        true,
+	"tax_id":         true,
+	"currency":       true,
+	"timezone":       true,
+}
+
+func validateProfile(account *BillingAccount) error {
+	if utf8.RuneCountInString(account.DisplayName) > maxDisplayNameLength {
+		return status.Errorf(codes.InvalidArgument, "display name must be at most %d characters", maxDisplayNameLength)
+	}
+
+	if len(account.ContactEmails) > maxContactEmails {
+		return status.Errorf(codes.InvalidArgument, "at most %d contact emails are allowed", maxContactEmails)
+	}
+	seen := map[string]bool{}
+	for _, email := range account.ContactEmails {
+		address, err := mail.ParseAddress(email)
+		if err != nil || address.Address != email {
+			return status.Errorf(codes.InvalidArgument, "invalid contact email %q", email)
+		}
+		if seen[email] {
+			return status.Errorf(codes.InvalidArgument, "duplicate contact email %q", email)
+		}
+		seen[email] = true
+	}
+
+	err := validateAddress(account.Address)
+	if err != nil {
+		return err
+	}
+
+	if account.TaxId != "" && !taxIDRegex.MatchString(account.TaxId) {
+		return status.Error(codes.InvalidArgument, "invalid tax id")
+	}
+	if account.Currency != "" && !currencyRegex.MatchString(account.Currency) {
+		return status.Error(codes.InvalidArgument, "currency must be an ISO 4217 code")
+	}
+	if account.Timezone != "" {
+		_, err = time.LoadLocation(account.Timezone)
+		if err != nil || account.Timezone == "Local" {
+			return status.Error(codes.InvalidArgument, "timezone must be an IANA time zone")
+		}
+	}
+	return nil
+}
+
+// validateAddress allows an empty address, an address that is set needs at least a street, a city and a country
+func validateAddress(address *PostalAddress) error {
+	if address == nil || isEmptyAddress(address) {
+		return nil
+	}
+	for _, field := range []string{address.Line1, address.Line2, address.City, address.Region, address.PostalCode} {
+		if utf8.RuneCountInString(field) > maxAddressLineLength {
+			return status.Errorf(codes.InvalidArgument, "address fields must be at most %d characters", maxAddressLineLength)
+		}
+	}
+	if address.Line1 == "" || address.City == "" {
+		return status.Error(codes.InvalidArgument, "address line1 and city are required")
+	}
+	if !countryCodeRegex.MatchString(address.CountryCode) {
+		return status.Error(codes.InvalidArgument, "address country code must be an ISO 3166-1 alpha-2 code")
+	}
+	return nil
+}
+
+func isEmptyAddress(address *PostalAddress) bool {
+	return address.Line1 == "" && address.Line2 == "" && address.City == "" && address.Region == "" &&
+		address.PostalCode == "" && address.CountryCode == ""
+}
+
+// setProfileField copies one field of the profile in the request to the update
+func setProfileField(updates *store.UpdateBillingAccountProfileParams, account *BillingAccount, path string) {
+	switch path {
+	case "display_name":
+		updates.DisplayName = account.DisplayName
+	case "contact_emails":
+		updates.ContactEmails = account.ContactEmails
+		if updates.ContactEmails == nil {
+			updates.ContactEmails = []string{}
+		}
+	case "address":
+		address := account.Address
+		if address == nil {
+			address = &PostalAddress{}
+		}
+		updates.AddressLine1 = address.Line1
+		updates.AddressLine2 = address.Line2
+		updates.AddressCity = address.City
+		updates.AddressRegion = address.Region
+		updates.AddressPostalCode = address.PostalCode
+		updates.AddressCountryCode = address.CountryCode
+	case "tax_id":
+		updates.TaxID = account.TaxId
+	case "currency":
+		updates.Currency = account.Currency
+		if updates.Currency == "" {
+			updates.Currency = defaultCurrency
+		}
+	case "timezone":
+		updates.Timezone = account.Timezone
+		if updates.Timezone == "" {
+			updates.Timezone = defaultBillingTimezone
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package billingaccount
+
+import (
+	"testing"
+
+	"biller/svc/compute/store"
+
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func Test_validateProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		account *BillingAccount
+		valid   bool
+	}{
+		{"empty profile", &BillingAccount{}, true},
+		{"complete profile", &BillingAccount{
+			DisplayName:   "Acme",
+			ContactEmails: []string{"billing@acme.com", "finance@acme.com"},
+			Address:       &PostalAddress{Line1: "1 Main St", City: "London", PostalCode: "N1 1AA", CountryCode: "GB"},
+			TaxId:         "GB123456789",
+			Currency:      "GBP",
+			Timezone:      "Europe/London",
+		}, true},
+		{"invalid email", &BillingAccount{ContactEmails: []string{"not an email"}}, false},
+		{"email with a name", &BillingAccount{ContactEmails: []string{"Acme <billing@acme.com>"}}, false},
+		{"duplicate email", &BillingAccount{ContactEmails: []string{"billing@acme.com", "billing@acme.com"}}, false},
+		{"address without city", &BillingAccount{Address: &PostalAddress{Line1: "1 Main St", CountryCode: "GB"}}, false},
+		{"address with invalid country", &BillingAccount{Address: &PostalAddress{Line1: "1 Main St", City: "London", CountryCode: "gbr"}}, false},
+		{"invalid tax id", &BillingAccount{TaxId: "GB;123"}, false},
+		{"invalid currency", &BillingAccount{Currency: "pounds"}, false},
+		{"invalid timezone", &BillingAccount{Timezone: "Mars/Olympus"}, false},
+		{"local timezone", &BillingAccount{Timezone: "Local"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateProfile(tt.account)
+			if tt.valid && err != nil {
+				t.Errorf("expected no error, got: %v", err)
+			}
+			if !tt.valid && status.Code(err) != codes.InvalidArgument {
+				t.Errorf("expected: %s, got: %v", codes.InvalidArgument, err)
+			}
+		})
+	}
+}
+
+func Test_UpdateBillingAccount(t *testing.T) {
+	t.Run("should fail when a field can not be updated", func(t *testing.T) {
+		server := NewServer(&FakeTxQuerier{}, zaptest.NewLogger(t))
+		_, err := server.UpdateBillingAccount(systemContext(), &UpdateBillingAccountRequest{
+			BillingAccount: &BillingAccount{Id: "billing-account-id", DemandEnabled: true},
+			UpdateMask:     &fieldmaskpb.FieldMask{Paths: []string{"demand_enabled"}},
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, status.Code(err))
+		}
+	})
+	t.Run("should fail when the profile is invalid", func(t *testing.T) {
+		server := NewServer(&FakeTxQuerier{}, zaptest.NewLogger(t))
+		_, err := server.UpdateBillingAccount(systemContext(), &UpdateBillingAccountRequest{
+			BillingAccount: &BillingAccount{Id: "billing-account-id", Currency: "usd"},
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, status.Code(err))
+		}
+	})
+	t.Run("should only update the fields in the update mask", func(t *testing.T) {
+		var params store.UpdateBillingAccountProfileParams
+		querier := FakeTxQuerier{
+			getBillingAccount: store.BillingAccount{
+				ID:            "billing-account-id",
+				DisplayName:   "Acme",
+				ContactEmails: []string{"billing@acme.com"},
+				AddressLine1:  "1 Main St",
+				AddressCity:   "London",
+				Currency:      "USD",
+				Timezone:      "UTC",
+			},
+			updateBillingAccountParams: &params,
+		}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		res, err := server.UpdateBillingAccount(systemContext(), &UpdateBillingAccountRequest{
+			BillingAccount: &BillingAccount{Id: "billing-account-id", DisplayName: "Ignored", Currency: "EUR"},
+			UpdateMask:     &fieldmaskpb.FieldMask{Paths: []string{"currency", "address"}},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if params.Currency != "EUR" || params.DisplayName != "Acme" || params.Timezone != "UTC" || len(params.ContactEmails) != 1 {
+			t.Errorf("unexpected update: %+v", params)
+		}
+		if params.AddressLine1 != "" || params.AddressCity != "" {
+			t.Errorf("expected the address to be cleared, got: %+v", params)
+		}
+		if res.Currency != "EUR" {
+			t.Errorf("expected: %s, got: %s", "EUR", res.Currency)
+		}
+	})
+}
+
+func Test_CreateBillingAccountProfile(t *testing.T) {
+	t.Run("should default the currency and timezone", func(t *testing.T) {
+		var params store.CreateBillingAccountParams
+		querier := FakeTxQuerier{createBillingAccountParams: &params}
+		server := NewServer(&querier, zaptest.NewLogger(t))
+		_, err := server.CreateBillingAccount(systemContext(), &CreateBillingAccountRequest{
+			BillingAccount: &BillingAccount{DisplayName: "Acme", ContactEmails: []string{"billing@acme.com"}},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if params.DisplayName != "Acme" || params.Currency != "USD" || params.Timezone != "UTC" {
+			t.Errorf("unexpected billing account: %+v", params)
+		}
+	})
+	t.Run("should fail when the profile is invalid", func(t *testing.T) {
+		server := NewServer(&FakeTxQuerier{}, zaptest.NewLogger(t))
+		_, err := server.CreateBillingAccount(systemContext(), &CreateBillingAccountRequest{
+			BillingAccount: &BillingAccount{ContactEmails: []string{"billing"}},
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, status.Code(err))
+		}
+	})
+}
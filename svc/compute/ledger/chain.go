@@ -0,0 +1,196 @@
+package ledger
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"time"
+
+	"biller/svc/compute/store"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgtype"
+)
+
+// Records that can no longer change are sealed into a hash chain, so changing or removing one after the fact breaks
+// the chain from there on. Each sealed record stores its position in the chain, the hash of the record before it and
+// its own hash, the sha256 of the previous hash followed by the record content. The first record of a chain follows
+// an empty hash.
+//
+// Billing account spend is sealed once its period has ended or it was issued as a final invoice, audit events as soon
+// as they're committed. Order and project spend break the account spend down and are purged with their project so
+// they aren't chained.
+
+const (
+	ChainBillingAccountSpend = "billing_account_spend"
+	ChainAuditLog            = "audit_log"
+)
+
+// link is a record in a chain
+type link struct {
+	// id identifies the record in reports, the uid of spend or the id of an audit event
+	id       string
+	seq      int64
+	prevHash []byte
+	hash     []byte
+	content  []byte
+}
+
+// chain reads and seals the records of one table
+type chain struct {
+	name string
+	// head returns the position and hash of the last sealed record, pgx.ErrNoRows when nothing was sealed yet
+	head func(ctx context.Context, q store.Querier) (int64, []byte, error)
+	// unsealed returns records that are final but not sealed yet in the order they should be sealed
+	unsealed func(ctx context.Context, q store.Querier, now time.Time, limit int32) ([]link, error)
+	// seal stores the position and hashes of a record, it returns the number of rows changed
+	seal func(ctx context.Context, q store.Querier, l link) (int64, error)
+	// sealed returns the sealed records after a position in chain order
+	sealed func(ctx context.Context, q store.Querier, afterSeq int64, limit int32) ([]link, error)
+}
+
+var chains = []chain{
+	{
+		name: ChainBillingAccountSpend,
+		head: func(ctx context.Context, q store.Querier) (int64, []byte, error) {
+			row, err := q.FindLastBillingAccountSpendLink(ctx)
+			return row.ChainSeq, row.Hash, err
+		},
+		unsealed: func(ctx context.Context, q store.Querier, now time.Time, limit int32) ([]link, error) {
+			rows, err := q.ListUnsealedBillingAccountSpend(ctx, store.ListUnsealedBillingAccountSpendParams{
+				EndTime:  now,
+				RowLimit: limit,
+			})
+			return spendLinks(rows), err
+		},
+		seal: func(ctx context.Context, q store.Querier, l link) (int64, error) {
+			return q.SealBillingAccountSpend(ctx, store.SealBillingAccountSpendParams{
+				ChainSeq: l.seq,
+				PrevHash: l.prevHash,
+				Hash:     l.hash,
+				Uid:      uuid.MustParse(l.id),
+			})
+		},
+		sealed: func(ctx context.Context, q store.Querier, afterSeq int64, limit int32) ([]link, error) {
+			rows, err := q.ListBillingAccountSpendChain(ctx, store.ListBillingAccountSpendChainParams{
+				AfterSeq: afterSeq,
+				RowLimit: limit,
+			})
+			return spendLinks(rows), err
+		},
+	},
+	{
+		name: ChainAuditLog,
+		head: func(ctx context.Context, q store.Querier) (int64, []byte, error) {
+			row, err := q.FindLastAuditEventLink(ctx)
+			return row.ChainSeq, row.Hash, err
+		},
+		unsealed: func(ctx context.Context, q store.Querier, now time.Time, limit int32) ([]link, error) {
+			rows, err := q.ListUnsealedAuditEvents(ctx, limit)
+			return auditLinks(rows), err
+		},
+		seal: func(ctx context.Context, q store.Querier, l link) (int64, error) {
+			return q.SealAuditEvent(ctx, store.SealAuditEventParams{
+				ChainSeq: l.seq,
+				PrevHash: l.prevHash,
+				Hash:     l.hash,
+				ID:       l.id,
+			})
+		},
+		sealed: func(ctx context.Context, q store.Querier, afterSeq int64, limit int32) ([]link, error) {
+			rows, err := q.ListAuditEventChain(ctx, store.ListAuditEventChainParams{
+				AfterSeq: afterSeq,
+				RowLimit: limit,
+			})
+			return auditLinks(rows), err
+		},
+	},
+}
+
+// hash returns the hash of a record following prevHash
+func hash(prevHash []byte, content []byte) []byte {
+	h := sha256.New()
+	h.Write(prevHash)
+	h.Write(content)
+	return h.Sum(nil)
+}
+
+// formatTime formats times the same way whatever location the driver returned them in
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+type spendContent struct {
+	Uid              string `json:"uid"`
+	BillingAccountID string `json:"billing_account_id"`
+	Spend            string `json:"spend"`
+	Credit           string `json:"credit"`
+	StartTime        string `json:"start_time"`
+	EndTime          string `json:"end_time"`
+	Final            bool   `json:"final"`
+}
+
+func spendLinks(rows []store.BillingAccountSpend) []link {
+	links := make([]link, len(rows))
+	for i, row := range rows {
+		// marshalling strings and booleans can't fail
+		content, _ := json.Marshal(spendContent{
+			Uid:              row.Uid.String(),
+			BillingAccountID: row.BillingAccountID,
+			Spend:            row.Spend.Text('f'),
+			Credit:           row.Credit.Text('f'),
+			StartTime:        formatTime(row.StartTime),
+			EndTime:          formatTime(row.EndTime),
+			Final:            row.Final,
+		})
+		links[i] = link{
+			id:       row.Uid.String(),
+			seq:      row.ChainSeq.Int64,
+			prevHash: row.PrevHash,
+			hash:     row.Hash,
+			content:  content,
+		}
+	}
+	return links
+}
+
+type auditContent struct {
+	ID           string          `json:"id"`
+	CreateTime   string          `json:"create_time"`
+	Method       string          `json:"method"`
+	Principal    string          `json:"principal"`
+	ResourceName string          `json:"resource_name"`
+	RequestID    string          `json:"request_id"`
+	Diff         json.RawMessage `json:"diff"`
+	ResultCode   string          `json:"result_code"`
+}
+
+func auditLinks(rows []store.AuditLog) []link {
+	links := make([]link, len(rows))
+	for i, row := range rows {
+		// postgres returns the same text for a jsonb value every time it's read
+		diff := json.RawMessage("null")
+		if row.Diff.Status == pgtype.Present {
+			diff = row.Diff.Bytes
+		}
+		// the diff is valid json as it's read from a jsonb column
+		content, _ := json.Marshal(auditContent{
+			ID:           row.ID,
+			CreateTime:   formatTime(row.CreateTime),
+			Method:       row.Method,
+			Principal:    row.Principal,
+			ResourceName: row.ResourceName,
+			RequestID:    row.RequestID,
+			Diff:         diff,
+			ResultCode:   row.ResultCode,
+		})
+		links[i] = link{
+			id:       row.ID,
+			seq:      row.ChainSeq.Int64,
+			prevHash: row.PrevHash,
+			hash:     row.Hash,
+			content:  content,
+		}
+	}
+	return links
+}
@@ -0,0 +1,285 @@
+package ledger
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"biller/svc/compute/store"
+
+	"github.com/cockroachdb/apd/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap/zaptest"
+)
+
+// FakeTxQuerier keeps the chained tables in memory
+type FakeTxQuerier struct {
+	store.TxQuerier
+	spend  *[]store.BillingAccountSpend
+	events *[]store.AuditLog
+}
+
+func newFakeTxQuerier() FakeTxQuerier {
+	return FakeTxQuerier{
+		spend:  &[]store.BillingAccountSpend{},
+		events: &[]store.AuditLog{},
+	}
+}
+
+func (q FakeTxQuerier) ExecWithTx(ctx context.Context, _ pgx.TxOptions, fn func(store.QueryLister) error) error {
+	return fn(q)
+}
+
+func (q FakeTxQuerier) LockChain(ctx context.Context, chain string) error {
+	return nil
+}
+
+func (q FakeTxQuerier) FindLastBillingAccountSpendLink(ctx context.Context) (store.FindLastBillingAccountSpendLinkRow, error) {
+	var last store.FindLastBillingAccountSpendLinkRow
+	for _, row := range *q.spend {
+		if row.ChainSeq.Valid && row.ChainSeq.Int64 > last.ChainSeq {
+			last = store.FindLastBillingAccountSpendLinkRow{ChainSeq: row.ChainSeq.Int64, Hash: row.Hash}
+		}
+	}
+	if last.ChainSeq == 0 {
+		return last, pgx.ErrNoRows
+	}
+	return last, nil
+}
+
+func (q FakeTxQuerier) ListUnsealedBillingAccountSpend(ctx context.Context, arg store.ListUnsealedBillingAccountSpendParams) ([]store.BillingAccountSpend, error) {
+	var rows []store.BillingAccountSpend
+	for _, row := range *q.spend {
+		if row.Hash == nil && (row.Final || !row.EndTime.After(arg.EndTime)) {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func (q FakeTxQuerier) SealBillingAccountSpend(ctx context.Context, arg store.SealBillingAccountSpendParams) (int64, error) {
+	for i, row := range *q.spend {
+		if row.Uid == arg.Uid && row.Hash == nil {
+			(*q.spend)[i].ChainSeq = sql.NullInt64{Int64: arg.ChainSeq, Valid: true}
+			(*q.spend)[i].PrevHash = arg.PrevHash
+			(*q.spend)[i].Hash = arg.Hash
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func (q FakeTxQuerier) ListBillingAccountSpendChain(ctx context.Context, arg store.ListBillingAccountSpendChainParams) ([]store.BillingAccountSpend, error) {
+	var rows []store.BillingAccountSpend
+	for _, row := range *q.spend {
+		if row.ChainSeq.Valid && row.ChainSeq.Int64 > arg.AfterSeq {
+			rows = append(rows, row)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].ChainSeq.Int64 < rows[j].ChainSeq.Int64 })
+	return rows, nil
+}
+
+func (q FakeTxQuerier) FindLastAuditEventLink(ctx context.Context) (store.FindLastAuditEventLinkRow, error) {
+	var last store.FindLastAuditEventLinkRow
+	for _, row := range *q.events {
+		if row.ChainSeq.Valid && row.ChainSeq.Int64 > last.ChainSeq {
+			last = store.FindLastAuditEventLinkRow{ChainSeq: row.ChainSeq.Int64, Hash: row.Hash}
+		}
+	}
+	if last.ChainSeq == 0 {
+		return last, pgx.ErrNoRows
+	}
+	return last, nil
+}
+
+func (q FakeTxQuerier) ListUnsealedAuditEvents(ctx context.Context, rowLimit int32) ([]store.AuditLog, error) {
+	var rows []store.AuditLog
+	for _, row := range *q.events {
+		if row.Hash == nil {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func (q FakeTxQuerier) SealAuditEvent(ctx context.Context, arg store.SealAuditEventParams) (int64, error) {
+	for i, row := range *q.events {
+		if row.ID == arg.ID && row.Hash == nil {
+			(*q.events)[i].ChainSeq = sql.NullInt64{Int64: arg.ChainSeq, Valid: true}
+			(*q.events)[i].PrevHash = arg.PrevHash
+			(*q.events)[i].Hash = arg.Hash
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func (q FakeTxQuerier) ListAuditEventChain(ctx context.Context, arg store.ListAuditEventChainParams) ([]store.AuditLog, error) {
+	var rows []store.AuditLog
+	for _, row := range *q.events {
+		if row.ChainSeq.Valid && row.ChainSeq.Int64 > arg.AfterSeq {
+			rows = append(rows, row)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].ChainSeq.Int64 < rows[j].ChainSeq.Int64 })
+	return rows, nil
+}
+
+func spendRow(billingAccountID string, spend int64, startTime time.Time) store.BillingAccountSpend {
+	return store.BillingAccountSpend{
+		Uid:              uuid.New(),
+		BillingAccountID: billingAccountID,
+		Spend:            *apd.New(spend, 0),
+		Credit:           *apd.New(0, 0),
+		StartTime:        startTime,
+		EndTime:          startTime.AddDate(0, 1, 0),
+	}
+}
+
+func auditRow(id string) store.AuditLog {
+	return store.AuditLog{
+		ID:         id,
+		CreateTime: time.Now(),
+		Method:     "/org.cudo.compute.v1.ProjectService/CreateProject",
+		Principal:  "user",
+		Diff:       pgtype.JSONB{Bytes: []byte(`{"id": {"new": "a", "old": null}}`), Status: pgtype.Present},
+		ResultCode: "OK",
+	}
+}
+
+func TestSealer_Run(t *testing.T) {
+	// ended more than the settle time ago
+	lastMonth := time.Now().AddDate(0, -2, 0)
+	thisMonth := time.Now()
+
+	t.Run("should seal final records into chains that verify", func(t *testing.T) {
+		querier := newFakeTxQuerier()
+		*querier.spend = []store.BillingAccountSpend{
+			spendRow("1", 100, lastMonth),
+			spendRow("2", 200, lastMonth),
+			spendRow("1", 300, thisMonth),
+		}
+		*querier.events = []store.AuditLog{auditRow("a"), auditRow("b")}
+
+		err := NewSealer(querier, zaptest.NewLogger(t)).Run(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for i, row := range *querier.spend {
+			if sealed := row.Hash != nil; sealed != (i < 2) {
+				t.Errorf("spend %d: expected sealed to be %v", i, i < 2)
+			}
+		}
+		for _, row := range *querier.events {
+			if row.Hash == nil {
+				t.Errorf("expected audit event %s to be sealed", row.ID)
+			}
+		}
+		err = Verify(context.Background(), querier, zaptest.NewLogger(t))
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+	t.Run("should seal final invoices before their period ends", func(t *testing.T) {
+		querier := newFakeTxQuerier()
+		invoice := spendRow("1", 100, thisMonth)
+		invoice.Final = true
+		*querier.spend = []store.BillingAccountSpend{invoice}
+
+		err := NewSealer(querier, zaptest.NewLogger(t)).Run(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if (*querier.spend)[0].Hash == nil {
+			t.Errorf("expected the final invoice to be sealed")
+		}
+	})
+	t.Run("should leave spend that just ended unsealed for the biller", func(t *testing.T) {
+		querier := newFakeTxQuerier()
+		*querier.spend = []store.BillingAccountSpend{spendRow("1", 100, time.Now().Add(-time.Hour).AddDate(0, -1, 0))}
+
+		err := NewSealer(querier, zaptest.NewLogger(t)).Run(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if (*querier.spend)[0].Hash != nil {
+			t.Errorf("expected the spend not to be sealed")
+		}
+	})
+	t.Run("should continue the chain on later runs", func(t *testing.T) {
+		querier := newFakeTxQuerier()
+		*querier.events = []store.AuditLog{auditRow("a")}
+		sealer := NewSealer(querier, zaptest.NewLogger(t))
+		if err := sealer.Run(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		*querier.events = append(*querier.events, auditRow("b"))
+		if err := sealer.Run(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		events := *querier.events
+		if events[1].ChainSeq.Int64 != 2 || string(events[1].PrevHash) != string(events[0].Hash) {
+			t.Errorf("expected the second event to follow the first, got: %+v", events[1])
+		}
+		if err := Verify(context.Background(), querier, zaptest.NewLogger(t)); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestVerify(t *testing.T) {
+	// ended more than the settle time ago
+	lastMonth := time.Now().AddDate(0, -2, 0)
+	sealed := func(t *testing.T) FakeTxQuerier {
+		querier := newFakeTxQuerier()
+		*querier.spend = []store.BillingAccountSpend{
+			spendRow("1", 100, lastMonth),
+			spendRow("2", 200, lastMonth),
+			spendRow("3", 300, lastMonth),
+		}
+		*querier.events = []store.AuditLog{auditRow("a"), auditRow("b")}
+		if err := NewSealer(querier, zaptest.NewLogger(t)).Run(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return querier
+	}
+	brokenAt := func(t *testing.T, err error, chain string, seq int64) {
+		t.Helper()
+		var broken *BrokenLinkError
+		if !errors.As(err, &broken) {
+			t.Fatalf("expected a broken link, got: %v", err)
+		}
+		if broken.Chain != chain || broken.Seq != seq {
+			t.Errorf("expected the %s chain to break at %d, got: %v", chain, seq, broken)
+		}
+	}
+
+	t.Run("should find changed spend", func(t *testing.T) {
+		querier := sealed(t)
+		(*querier.spend)[1].Spend = *apd.New(1, 0)
+		brokenAt(t, Verify(context.Background(), querier, zaptest.NewLogger(t)), ChainBillingAccountSpend, 2)
+	})
+	t.Run("should find removed spend", func(t *testing.T) {
+		querier := sealed(t)
+		*querier.spend = append((*querier.spend)[:1], (*querier.spend)[2:]...)
+		brokenAt(t, Verify(context.Background(), querier, zaptest.NewLogger(t)), ChainBillingAccountSpend, 3)
+	})
+	t.Run("should find rehashed spend", func(t *testing.T) {
+		querier := sealed(t)
+		row := &(*querier.spend)[0]
+		row.Spend = *apd.New(1, 0)
+		row.Hash = hash(row.PrevHash, spendLinks([]store.BillingAccountSpend{*row})[0].content)
+		brokenAt(t, Verify(context.Background(), querier, zaptest.NewLogger(t)), ChainBillingAccountSpend, 2)
+	})
+	t.Run("should find changed audit events", func(t *testing.T) {
+		querier := sealed(t)
+		(*querier.events)[0].Principal = "someone else"
+		brokenAt(t, Verify(context.Background(), querier, zaptest.NewLogger(t)), ChainAuditLog, 1)
+	})
+}
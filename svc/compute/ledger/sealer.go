@@ -0,0 +1,93 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"biller/lib/logger"
+	"biller/svc/compute/store"
+
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+// sealBatchSize is the number of records sealed in a transaction
+const sealBatchSize = 500
+
+// settleTime is how long spend is left unsealed after its period ends, the biller bills a period for a day after it
+// ends to bill its last hours
+const settleTime = 48 * time.Hour
+
+// Sealer seals the records that have become final into their chains
+type Sealer struct {
+	querier store.TxQuerier
+	log     *zap.Logger
+}
+
+func NewSealer(querier store.TxQuerier, log *zap.Logger) *Sealer {
+	return &Sealer{
+		querier: querier,
+		log:     log,
+	}
+}
+
+func (s *Sealer) Run(ctx context.Context) error {
+	now := time.Now()
+	for _, c := range chains {
+		sealed := 0
+		for {
+			count, err := s.sealBatch(ctx, c, now.Add(-settleTime))
+			if err != nil {
+				logger.FromContext(ctx, s.log).Error("error sealing records", zap.String("chain", c.name), zap.Error(err))
+				return err
+			}
+			sealed += count
+			if count < sealBatchSize {
+				break
+			}
+		}
+		logger.FromContext(ctx, s.log).Info("sealed records", zap.String("chain", c.name), zap.Int("count", sealed))
+	}
+	return nil
+}
+
+// sealBatch appends a batch of unsealed records that ended by endTime to a chain and returns how many there were
+func (s *Sealer) sealBatch(ctx context.Context, c chain, endTime time.Time) (int, error) {
+	var count int
+	err := s.querier.ExecWithTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}, func(q store.QueryLister) error {
+		// sealers running at the same time would both append to the head of the chain
+		err := q.LockChain(ctx, c.name)
+		if err != nil {
+			return fmt.Errorf("lock chain failed: %w", err)
+		}
+		seq, prevHash, err := c.head(ctx, q)
+		if err != nil && err != pgx.ErrNoRows {
+			return fmt.Errorf("find chain head failed: %w", err)
+		}
+		links, err := c.unsealed(ctx, q, endTime, sealBatchSize)
+		if err != nil {
+			return fmt.Errorf("list unsealed records failed: %w", err)
+		}
+		for _, l := range links {
+			seq++
+			l.seq = seq
+			l.prevHash = prevHash
+			if l.prevHash == nil {
+				l.prevHash = []byte{}
+			}
+			l.hash = hash(prevHash, l.content)
+			affected, err := c.seal(ctx, q, l)
+			if err != nil {
+				return fmt.Errorf("seal %s failed: %w", l.id, err)
+			}
+			if affected != 1 {
+				return fmt.Errorf("seal %s failed: %d rows affected", l.id, affected)
+			}
+			prevHash = l.hash
+		}
+		count = len(links)
+		return nil
+	})
+	return count, err
+}
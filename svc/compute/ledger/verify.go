@@ -0,0 +1,74 @@
+package ledger
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"biller/svc/compute/store"
+
+	"go.uber.org/zap"
+)
+
+// verifyBatchSize is the number of records read at a time when verifying a chain
+const verifyBatchSize = 1000
+
+// BrokenLinkError is the first record of a chain that doesn't match the records before it. The record, or one before
+// it, was changed or removed after it was sealed.
+type BrokenLinkError struct {
+	Chain string
+	// Seq is the position of the record in the chain
+	Seq int64
+	// ID identifies the record, the uid of spend or the id of an audit event
+	ID     string
+	Reason string
+}
+
+func (e *BrokenLinkError) Error() string {
+	return fmt.Sprintf("chain %s is broken at %d (%s): %s", e.Chain, e.Seq, e.ID, e.Reason)
+}
+
+// Verify walks every chain from the start and returns a *BrokenLinkError for the first record that doesn't match.
+// The length and head hash of each chain are logged, records removed from the end of a chain can only be found by
+// comparing them with an earlier run.
+func Verify(ctx context.Context, querier store.Querier, log *zap.Logger) error {
+	for _, c := range chains {
+		seq, head, err := verifyChain(ctx, querier, c)
+		if err != nil {
+			return err
+		}
+		log.Info("verified chain", zap.String("chain", c.name), zap.Int64("length", seq), zap.String("head", hex.EncodeToString(head)))
+	}
+	return nil
+}
+
+// verifyChain returns the position and hash of the last record of a chain
+func verifyChain(ctx context.Context, querier store.Querier, c chain) (int64, []byte, error) {
+	var seq int64
+	prevHash := []byte{}
+	for {
+		links, err := c.sealed(ctx, querier, seq, verifyBatchSize)
+		if err != nil {
+			return 0, nil, fmt.Errorf("list %s chain failed: %w", c.name, err)
+		}
+		for _, l := range links {
+			broken := &BrokenLinkError{Chain: c.name, Seq: l.seq, ID: l.id}
+			switch {
+			case l.seq != seq+1:
+				broken.Reason = fmt.Sprintf("records %d to %d are missing", seq+1, l.seq-1)
+			case !bytes.Equal(l.prevHash, prevHash):
+				broken.Reason = "the previous hash doesn't match the record before it"
+			case !bytes.Equal(l.hash, hash(l.prevHash, l.content)):
+				broken.Reason = "the content doesn't match the hash"
+			default:
+				seq, prevHash = l.seq, l.hash
+				continue
+			}
+			return 0, nil, broken
+		}
+		if len(links) < verifyBatchSize {
+			return seq, prevHash, nil
+		}
+	}
+}
@@ -5,21 +5,32 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"time"
+	// billing account time zones are validated with time.LoadLocation, don't depend on the image having tzdata
+	_ "time/tzdata"
 
 	"biller/lib/ff"
+	"biller/lib/jwt"
 	"biller/lib/logger"
+	"biller/lib/pagination"
 	"biller/lib/postgresql"
 	"biller/lib/service"
 
+	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rs/cors"
 
+	"biller/svc/compute/apikey"
+	"biller/svc/compute/audit"
 	"biller/svc/compute/billingaccount"
+	"biller/svc/compute/ledger"
 	"biller/svc/compute/project"
+	"biller/svc/compute/sla"
 	"biller/svc/compute/store"
 
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 )
 
 func main() {
@@ -31,40 +42,95 @@ func main() {
 	}
 }
 
+// commands run once and exit instead of serving
+const (
+	commandMigrate      = "migrate"
+	commandVerifyLedger = "verify-ledger"
+)
+
 func run(ctx context.Context, args []string, logger *zap.Logger) error {
+	var command string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
+
 	var (
+		adminAPIKey         string
+		allowNoDB           bool
+		autoMigrate         bool
+		commandArgs         []string
 		environment         string
+		otlpEndpoint        string
+		pageTokenKey        string
 		pgDatabase          string
 		pgHost              string
 		pgPass              string
 		pgPort              int
 		pgUser              string
 		runner              string
+		runnerSchedule      string
+		sessionAudience     string
+		sessionIssuer       string
+		sessionJWKS         string
 		shutdownGracePeriod time.Duration
+		traceExporter       string
+		traceSampleRatio    float64
 	)
 
 	{
 		fs := flag.NewFlagSet("compute", flag.ExitOnError)
+		fs.StringVar(&adminAPIKey, "admin-api-key", "", "an api key with admin access that isn't stored, for creating the first billing accounts. No admin key is accepted when empty")
+		fs.BoolVar(&allowNoDB, "allow-start-without-db", false, "start even when Postgresql can't be reached, the service isn't ready until it can be")
+		fs.BoolVar(&autoMigrate, "auto-migrate", false, "apply the migrations the database is missing on startup, instances starting at the same time wait for each other")
 		fs.StringVar(&environment, "environment", "local", "")
+		fs.StringVar(&otlpEndpoint, "otlp-endpoint", "", "the host and port of the OpenTelemetry collector spans are sent to with the otlp trace exporter, localhost:4317 when empty")
+		fs.StringVar(&pageTokenKey, "page-token-key", "", "the key used to sign list page tokens, must be shared by all instances. A random key is used when empty")
 		fs.StringVar(&pgHost, "pg-host", "localhost", "the host to use when connecting to Postgresql")
 		fs.StringVar(&pgDatabase, "pg-database", "compute", "the database to use when connected to Postgresql")
 		fs.StringVar(&pgPass, "pg-pass", "", "the password to use when connecting to Postgresql")
 		fs.IntVar(&pgPort, "pg-port", 5432, "the port to use when connecting to Postgresql")
 		fs.StringVar(&pgUser, "pg-user", "compute", "the user to use when connecting to Postgresql")
+		fs.StringVar(&sessionAudience, "session-audience", "compute", "the audience session tokens must be issued for")
+		fs.StringVar(&sessionIssuer, "session-issuer", "", "the issuer of session tokens")
+		fs.StringVar(&sessionJWKS, "session-jwks", "", "a file or url with the JSON Web Key Set session tokens are signed by. Session tokens are not accepted when empty")
 		fs.DurationVar(&shutdownGracePeriod, "shutdown-grace-period", 0, "")
+		fs.StringVar(&traceExporter, "trace-exporter", "", `where to send spans, either "otlp" or "stdout". No spans are exported when empty`)
+		fs.Float64Var(&traceSampleRatio, "trace-sample-ratio", 0, "the fraction of traces to record, all traces when 0")
 		// TODO we need tasks for, polling one vm/host state, update demander balances, update supplier earnings, supplier payments/trasnactions to kill bill
-		fs.StringVar(&runner, "runner", "", `Choose which background task to run, either "onePoller" or "earningsRollup". Leave empty to run the market server itself.`)
+		fs.StringVar(&runner, "runner", "", `Choose which background task to run, one of "biller", "sla", "sealer" or "purger". Leave empty to run the compute server itself.`)
+		fs.StringVar(&runnerSchedule, "runner-schedule", "", `a cron expression of when the background task runs, such as "0 2 * * *", instead of its own schedule`)
 
 		err := ff.Fill(fs, args)
 
 		if err != nil {
 			return fmt.Errorf("failed to get configuration: %w", err)
 		}
+		commandArgs = fs.Args()
+	}
+
+	switch command {
+	case "", commandMigrate, commandVerifyLedger:
+	default:
+		return fmt.Errorf("unknown command %q", command)
+	}
+
+	if runnerSchedule != "" {
+		if err := service.ValidateSchedule(runnerSchedule); err != nil {
+			return fmt.Errorf("invalid -runner-schedule: %w", err)
+		}
+	}
+
+	if pageTokenKey != "" {
+		pagination.SetKey([]byte(pageTokenKey))
 	}
 
 	registry := prometheus.NewRegistry()
 
+	var postgresqlDb *pgxpool.Pool
 	var postgresqlQueries *store.TxQueries
+	var healthChecks map[string]service.HealthCheck
+	// whether the database could be reached on startup, it may not be when starting without it
+	dbConnected := true
 	{
 		postgresqlClientConfig := &postgresql.ClientConfig{
 			User:     pgUser,
@@ -75,40 +141,144 @@ func run(ctx context.Context, args []string, logger *zap.Logger) error {
 			Logger:   logger,
 		}
 
-		postgresqlDb, err := postgresql.NewClient(ctx, postgresqlClientConfig, registry)
+		var err error
+		postgresqlDb, err = postgresql.NewClient(ctx, postgresqlClientConfig, registry)
+		if err != nil && allowNoDB {
+			// connect once the database is up, the postgresql health check fails until then
+			logger.Warn("error connecting to postgresql, starting without it", zap.Error(err))
+			dbConnected = false
+			postgresqlClientConfig.LazyConnect = true
+			postgresqlDb, err = postgresql.NewClient(ctx, postgresqlClientConfig, registry)
+		}
+		if err != nil {
+			return fmt.Errorf("error connecting to postgresql: %w", err)
+		}
+		defer postgresqlDb.Close()
+		postgresqlQueries = store.NewTxQueries(postgresqlDb)
+
+		healthChecks = map[string]service.HealthCheck{
+			"postgresql": postgresqlDb.Ping,
+			"schema":     postgresqlQueries.CheckSchemaVersion,
+		}
+	}
+
+	if command == commandMigrate {
+		return runMigrate(postgresqlDb, commandArgs, logger)
+	}
+
+	if dbConnected {
+		if autoMigrate {
+			err := runMigrate(postgresqlDb, []string{"up"}, logger)
+			if err != nil {
+				return err
+			}
+		}
+		// queries written for another schema would fail at random, the schema health check covers a database that
+		// wasn't up on startup
+		err := postgresqlQueries.CheckSchemaVersion(ctx)
 		if err != nil {
-			logger.Error("error connecting to postgresql", zap.Error(err))
-		} else {
-			defer postgresqlDb.Close()
-			postgresqlQueries = store.NewTxQueries(postgresqlDb)
+			return fmt.Errorf("the database schema doesn't match this version, it may need to be migrated: %w", err)
 		}
 	}
 
+	if command == commandVerifyLedger {
+		return ledger.Verify(ctx, postgresqlQueries, logger)
+	}
+
+	authenticators := []service.Authenticator{
+		service.NewAPIKeyAuthenticator(apikey.NewAuthenticator(postgresqlQueries, adminAPIKey, logger)),
+	}
+	if sessionJWKS != "" {
+		if sessionIssuer == "" {
+			return fmt.Errorf("a session issuer is required to verify session tokens")
+		}
+		authenticators = append(authenticators, service.NewJWTAuthenticator(jwt.NewVerifier(jwt.NewKeySet(sessionJWKS), sessionIssuer, sessionAudience)))
+	}
+
 	promServerConfig := &service.PrometheusServerConfig{
 		ListenAddr: ":9090",
 	}
 
+	var tracingConfig *service.TracingConfig
+	if traceExporter != "" {
+		tracingConfig = &service.TracingConfig{
+			Exporter:     traceExporter,
+			OTLPEndpoint: otlpEndpoint,
+			SampleRatio:  traceSampleRatio,
+		}
+	}
+
 	if runner != "" {
 		var backgroundTaskConfig service.BackgroundServiceConfig
 
 		switch runner {
 		case "biller":
 			backgroundTaskConfig = service.BackgroundServiceConfig{
+				// run every hour, which bills the last hours of a month soon after it ends in the time zone of each
+				// billing account, and on start in case the run was missed
+				CatchUp:          service.CatchUpOnce,
 				Environment:      environment,
-				Interval:         time.Hour * 24,
+				Schedule:         "5 * * * *",
 				Name:             "biller",
 				PrometheusServer: promServerConfig,
+				Tracing:          tracingConfig,
 				Task:             billingaccount.NewBiller(postgresqlQueries, logger),
 			}
+		case "sla":
+			backgroundTaskConfig = service.BackgroundServiceConfig{
+				// run daily, credits for the previous month of a billing account are issued on the first run after
+				// the month ends in its time zone and the runs after it find them issued already
+				CatchUp:          service.CatchUpOnce,
+				Environment:      environment,
+				Schedule:         "30 0 * * *",
+				Name:             "sla",
+				PrometheusServer: promServerConfig,
+				Tracing:          tracingConfig,
+				Task:             sla.NewCalculator(postgresqlQueries, logger),
+			}
+		case "sealer":
+			backgroundTaskConfig = service.BackgroundServiceConfig{
+				// run every hour, records become final a while after their period ends so a missed run is caught up
+				// by the next one
+				CatchUp:          service.CatchUpOnce,
+				Environment:      environment,
+				Schedule:         "15 * * * *",
+				Name:             "sealer",
+				PrometheusServer: promServerConfig,
+				Tracing:          tracingConfig,
+				Task:             ledger.NewSealer(postgresqlQueries, logger),
+			}
+		case "purger":
+			backgroundTaskConfig = service.BackgroundServiceConfig{
+				// run daily at a quiet hour, projects past their retention window are kept until the next run
+				CatchUp:          service.CatchUpOnce,
+				Environment:      environment,
+				Schedule:         "0 3 * * *",
+				Name:             "purger",
+				PrometheusServer: promServerConfig,
+				Tracing:          tracingConfig,
+				Task:             project.NewPurger(postgresqlQueries, logger),
+			}
 
 		default:
 			return fmt.Errorf("incorrect task name %q", runner)
 		}
 
+		if runnerSchedule != "" {
+			backgroundTaskConfig.Interval = 0
+			backgroundTaskConfig.Sleep = 0
+			backgroundTaskConfig.Schedule = runnerSchedule
+		}
+		backgroundTaskConfig.HealthChecks = healthChecks
+		// replicas running the same task would bill and purge the same records at once
+		backgroundTaskConfig.Lock = postgresql.NewAdvisoryLock(postgresqlDb, "runner:"+runner)
 		backgroundTask := service.NewBackground(backgroundTaskConfig, registry, logger)
-		backgroundTask.Run(ctx)
+		return backgroundTask.Run(ctx)
 
 	} else {
+		if tracingConfig != nil {
+			tracingConfig.ServiceName = "compute"
+		}
 		svc := service.New(
 			service.ServiceConfig{
 				Environment: environment,
@@ -117,8 +287,8 @@ func run(ctx context.Context, args []string, logger *zap.Logger) error {
 						AllowedOrigins:     []string{"https://staging.compute.cudo.org"},
 						AllowCredentials:   true,
 						AllowedMethods:     []string{"DELETE", "GET", "PATCH", "POST", "PUT"},
-						AllowedHeaders:     []string{"Content-Type", "X-Session-Token"},
-						ExposedHeaders:     []string{},
+						AllowedHeaders:     []string{"Content-Type", "X-Session-Token", "X-Api-Key", "X-Request-Id"},
+						ExposedHeaders:     []string{"X-Request-Id"},
 						OptionsPassthrough: false,
 						MaxAge:             0,
 					},
@@ -127,12 +297,32 @@ func run(ctx context.Context, args []string, logger *zap.Logger) error {
 				},
 				GRPCServices: map[string]*service.GRPCServiceConfig{
 					"compute": {
-						ListenAddr: ":9000",
-						Name:       "compute",
+						Authenticators: authenticators,
+						ListenAddr:     ":9000",
+						Name:           "compute",
+						// after authentication so events are recorded with the caller
+						UnaryInterceptors: []grpc.UnaryServerInterceptor{
+							audit.UnaryServerInterceptor(postgresqlQueries, logger),
+						},
+						RateLimits: map[string]service.RateLimit{
+							"/org.cudo.compute.v1.": {Rate: 20, Burst: 40},
+							// listing and spend reports query a lot of rows
+							"/org.cudo.compute.v1.ProjectService/ListProjects":                          {Rate: 5, Burst: 10},
+							"/org.cudo.compute.v1.ProjectService/ListOrders":                            {Rate: 5, Burst: 10},
+							"/org.cudo.compute.v1.AuditService/ListAuditEvents":                         {Rate: 5, Burst: 10},
+							"/org.cudo.compute.v1.ProjectService/GetProjectSpendHistory":                {Rate: 2, Burst: 5},
+							"/org.cudo.compute.v1.ProjectService/GetProjectSpendByLabel":                {Rate: 2, Burst: 5},
+							"/org.cudo.compute.v1.ProjectService/GetProjectCurrentSpend":                {Rate: 2, Burst: 5},
+							"/org.cudo.compute.v1.BillingAccountService/GetBillingAccountSpend":         {Rate: 2, Burst: 5},
+							"/org.cudo.compute.v1.BillingAccountService/ListBillingAccountSpendHistory": {Rate: 2, Burst: 5},
+							"/org.cudo.compute.v1.BillingAccountService/ListOrderSpend":                 {Rate: 2, Burst: 5},
+						},
 					},
 				},
+				HealthChecks:        healthChecks,
 				PrometheusServer:    promServerConfig,
 				ShutdownGracePeriod: shutdownGracePeriod,
+				Tracing:             tracingConfig,
 			},
 			registry,
 			logger,
@@ -152,6 +342,20 @@ func run(ctx context.Context, args []string, logger *zap.Logger) error {
 			return fmt.Errorf("failed to register grpc-gateway service project handler: %w", err)
 		}
 
+		apiKeyServiceHandler := apikey.NewServer(postgresqlQueries, logger)
+		apikey.RegisterApiKeyServiceServer(svc.GRPCServices["compute"].GRPCServer, apiKeyServiceHandler)
+		err = apikey.RegisterApiKeyServiceHandler(ctx, svc.GRPCGateway.GatewayMux, svc.GRPCGateway.GRPCClientConn)
+		if err != nil {
+			return fmt.Errorf("failed to register grpc-gateway service api key handler: %w", err)
+		}
+
+		auditServiceHandler := audit.NewServer(postgresqlQueries, logger)
+		audit.RegisterAuditServiceServer(svc.GRPCServices["compute"].GRPCServer, auditServiceHandler)
+		err = audit.RegisterAuditServiceHandler(ctx, svc.GRPCGateway.GatewayMux, svc.GRPCGateway.GRPCClientConn)
+		if err != nil {
+			return fmt.Errorf("failed to register grpc-gateway service audit handler: %w", err)
+		}
+
 		svc.Run(ctx)
 	}
 
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zaptest"
+)
+
+func Test_run(t *testing.T) {
+	t.Run("should fail to start a runner with an invalid schedule", func(t *testing.T) {
+		err := run(context.Background(), []string{"-runner=biller", "-runner-schedule=every day"}, zaptest.NewLogger(t))
+		if err == nil || !strings.Contains(err.Error(), "invalid -runner-schedule") {
+			t.Errorf("expected an invalid schedule error, got: %v", err)
+		}
+	})
+	t.Run("should fail on unknown commands", func(t *testing.T) {
+		err := run(context.Background(), []string{"unknown"}, zaptest.NewLogger(t))
+		if err == nil || !strings.Contains(err.Error(), "unknown command") {
+			t.Errorf("expected an unknown command error, got: %v", err)
+		}
+	})
+}
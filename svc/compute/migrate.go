@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"biller/svc/compute/store"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"go.uber.org/zap"
+)
+
+const migrateUsage = "usage: compute migrate [flags] up | down N | version | force VERSION"
+
+// runMigrate runs the migrate command on the database pool connects to. up applies every migration the database is
+// missing, down reverts the last N, version shows the version of the database and force sets it without migrating.
+func runMigrate(pool *pgxpool.Pool, args []string, log *zap.Logger) error {
+	if len(args) == 0 {
+		return fmt.Errorf(migrateUsage)
+	}
+	var number int
+	switch args[0] {
+	case "up", "version":
+		if len(args) != 1 {
+			return fmt.Errorf(migrateUsage)
+		}
+	case "down", "force":
+		if len(args) != 2 {
+			return fmt.Errorf(migrateUsage)
+		}
+		var err error
+		number, err = strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("%s takes a number: %w", args[0], err)
+		}
+	default:
+		return fmt.Errorf(migrateUsage)
+	}
+
+	migrator, err := store.NewMigrator(pool, log)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := migrator.Close(); err != nil {
+			log.Warn("could not close migrator", zap.Error(err))
+		}
+	}()
+
+	switch args[0] {
+	case "up":
+		err = migrator.Up()
+	case "down":
+		err = migrator.Down(number)
+	case "force":
+		err = migrator.Force(number)
+	}
+	if err != nil {
+		return fmt.Errorf("%s failed: %w", args[0], err)
+	}
+
+	version, dirty, err := migrator.Version()
+	if err != nil {
+		return fmt.Errorf("could not get the schema version: %w", err)
+	}
+	log.Info("schema version", zap.Uint("version", version), zap.Bool("dirty", dirty), zap.Int64("expected", store.SchemaVersion))
+	return nil
+}
@@ -2,19 +2,41 @@ package project
 
 import (
 	"context"
-
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"biller/lib/filter"
+	"biller/lib/logger"
+	"biller/lib/pagination"
+	"biller/lib/principal"
 	"biller/lib/resource"
+	"biller/svc/compute/audit"
 	"biller/svc/compute/billingaccount"
 	"biller/svc/compute/store"
 
+	"github.com/cockroachdb/apd/v2"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v4"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// errConcurrentDelete is returned when another request deleted the project after it was read
+var errConcurrentDelete = errors.New("project was deleted by another request")
+
+var apdContext = apd.Context{
+	MaxExponent: 65,
+	MinExponent: -18,
+	Precision:   65,
+	Rounding:    apd.RoundHalfUp,
+}
+
 type server struct {
 	log     *zap.Logger
 	querier store.TxQuerier
@@ -42,6 +64,14 @@ func (s *server) CreateProject(ctx context.Context, req *CreateProjectRequest) (
 	if !resource.ValidResourceID(req.Project.BillingAccountId) {
 		return &res, status.Error(codes.InvalidArgument, "invalid account id")
 	}
+	err := validateProjectMetadata(req.Project)
+	if err != nil {
+		return &res, err
+	}
+	labels, err := store.NewLabels(req.Project.Labels)
+	if err != nil {
+		return &res, status.Error(codes.InvalidArgument, "invalid labels")
+	}
 
 	tx, txq, err := s.querier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
 	if err != nil {
@@ -49,6 +79,10 @@ func (s *server) CreateProject(ctx context.Context, req *CreateProjectRequest) (
 	}
 	defer tx.Rollback(ctx)
 
+	err = billingaccount.Authorize(ctx, txq, req.Project.BillingAccountId, billingaccount.PermissionProjectsCreate)
+	if err != nil {
+		return &res, err
+	}
 	err = billingaccount.EnsureDemandEnabled(ctx, txq, req.Project.BillingAccountId)
 	if err != nil {
 		return &res, err
@@ -65,23 +99,36 @@ func (s *server) CreateProject(ctx context.Context, req *CreateProjectRequest) (
 	newProject, err := txq.CreateProject(ctx, store.CreateProjectParams{
 		BillingAccountID: req.Project.BillingAccountId,
 		ID:               req.Project.Id,
+		DisplayName:      req.Project.DisplayName,
+		Description:      req.Project.Description,
+		Labels:           labels,
 	})
 	if err != nil {
 		return &res, status.Error(codes.Internal, "creation failed")
 	}
+	err = audit.Record(ctx, txq, "projects/"+newProject.ID, nil, toProjectPb(newProject))
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when auditing project creation", zap.Error(err))
+		return &res, status.Error(codes.Internal, "creation failed")
+	}
 
 	err = tx.Commit(ctx)
 	if err != nil {
-		s.log.Error("transaction failed when creating project", zap.Error(err))
+		logger.FromContext(ctx, s.log).Error("transaction failed when creating project", zap.Error(err))
 		return &res, status.Error(codes.Internal, "creation failed")
 	}
 	return toProjectPb(newProject), nil
 }
 
-// TODO this would need to delete all resources in project???
-// would still need to archive it for billing, auditing
-func (s *server) DeleteProject(ctx context.Context, req *DeleteProjectRequest) (*emptypb.Empty, error) {
-	var res emptypb.Empty
+// Deleted projects are kept for billing and audit, they can be undeleted until the retention window has passed and
+// are then archived and removed by the Purger.
+const retentionWindow = 30 * 24 * time.Hour
+
+// DeleteProject runs as an operation: the active leases and orders of the project are ended, the project gets its
+// final bill up to the deletion time and only then is it marked deleted. All of it happens in one transaction, if
+// any step fails nothing is changed and the operation is marked failed.
+func (s *server) DeleteProject(ctx context.Context, req *DeleteProjectRequest) (*Operation, error) {
+	var res Operation
 
 	if req.Id == "" {
 		return &res, status.Error(codes.InvalidArgument, "project name is required")
@@ -94,24 +141,191 @@ func (s *server) DeleteProject(ctx context.Context, req *DeleteProjectRequest) (
 	defer tx.Rollback(ctx)
 
 	project, err := txq.FindProjectById(ctx, req.Id)
+	if err == pgx.ErrNoRows {
+		return &res, status.Error(codes.NotFound, "project not found")
+	}
+	if err != nil {
+		return &res, err
+	}
+	err = authorize(ctx, txq, project, billingaccount.PermissionProjectsDelete)
 	if err != nil {
 		return &res, err
 	}
+	if project.DeleteTime.Valid {
+		return &res, status.Error(codes.FailedPrecondition, "project has already been deleted")
+	}
 
-	affected, err := txq.DeleteProject(ctx, project.ID)
+	// the operation is created outside the transaction so that a failure is still recorded
+	operation, err := s.querier.CreateOperation(ctx, store.CreateOperationParams{
+		Type:      store.OperationTypeDeleteProject,
+		ProjectID: project.ID,
+	})
 	if err != nil {
-		return &res, status.Errorf(codes.Internal, codes.Internal.String())
+		logger.FromContext(ctx, s.log).Error("query failed when creating delete operation", zap.Error(err))
+		return &res, status.Error(codes.Internal, "could not start delete operation")
 	}
-	if affected == 0 {
+
+	err = deleteProject(ctx, txq, project.ID, time.Now())
+	if err == nil {
+		err = audit.Record(ctx, txq, "projects/"+project.ID, toProjectPb(project), nil)
+	}
+	if err == nil {
+		operation, err = txq.EndOperation(ctx, store.EndOperationParams{
+			Uid:    operation.Uid,
+			Status: store.OperationStatusSucceeded,
+		})
+	}
+	if err == nil {
+		err = tx.Commit(ctx)
+	}
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("delete operation failed", zap.String("projectId", project.ID), zap.Error(err))
+		_, endErr := s.querier.EndOperation(ctx, store.EndOperationParams{
+			Uid:          operation.Uid,
+			Status:       store.OperationStatusFailed,
+			ErrorMessage: err.Error(),
+		})
+		if endErr != nil {
+			logger.FromContext(ctx, s.log).Error("query failed when marking delete operation failed", zap.Error(endErr))
+		}
+		if errors.Is(err, errConcurrentDelete) {
+			return &res, status.Error(codes.Aborted, "project was deleted by another request")
+		}
 		return &res, status.Error(codes.Internal, "project could not be deleted")
 	}
+	return toOperationPb(operation), nil
+}
+
+// deleteProject ends the project's active leases and orders at deleteTime, bills the project for the current
+// period and marks it deleted
+func deleteProject(ctx context.Context, q store.Querier, projectID string, deleteTime time.Time) error {
+	orders, err := q.ListOrdersByProjectId(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("list orders failed: %w", err)
+	}
+	for _, order := range orders {
+		if order.Status != store.OrderStatusActive {
+			continue
+		}
+		leases, err := q.ListActiveLeasesByOrderId(ctx, order.ID)
+		if err != nil {
+			return fmt.Errorf("list active leases for order %s failed: %w", order.ID, err)
+		}
+		for _, lease := range leases {
+			_, err = q.EndLease(ctx, store.EndLeaseParams{
+				ID:      lease.ID,
+				Status:  store.LeaseStatusComplete,
+				EndTime: deleteTime,
+			})
+			if err != nil {
+				return fmt.Errorf("end lease %s failed: %w", lease.ID, err)
+			}
+		}
+		_, err = q.EndOrder(ctx, store.EndOrderParams{
+			ID:      order.ID,
+			Status:  store.OrderStatusCanceled,
+			EndTime: sql.NullTime{Time: deleteTime, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("end order %s failed: %w", order.ID, err)
+		}
+	}
+
+	_, err = billingaccount.BillProject(ctx, q, projectID, deleteTime)
+	if err != nil {
+		return fmt.Errorf("final bill failed: %w", err)
+	}
+
+	affected, err := q.DeleteProject(ctx, store.DeleteProjectParams{
+		ID:         projectID,
+		DeleteTime: sql.NullTime{Time: deleteTime, Valid: true},
+		PurgeTime:  sql.NullTime{Time: deleteTime.Add(retentionWindow), Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("delete project failed: %w", err)
+	}
+	if affected == 0 {
+		return errConcurrentDelete
+	}
+	return nil
+}
+
+func (s *server) GetProjectOperation(ctx context.Context, req *GetProjectOperationRequest) (*Operation, error) {
+	var res Operation
+
+	if !resource.ValidResourceID(req.ProjectId) {
+		return &res, status.Error(codes.InvalidArgument, "invalid project id")
+	}
+	uid, err := uuid.Parse(req.Id)
+	if err != nil {
+		return &res, status.Error(codes.InvalidArgument, "invalid operation id")
+	}
+	err = authorizeProject(ctx, s.querier, req.ProjectId, billingaccount.PermissionProjectsGet)
+	if err != nil {
+		return &res, err
+	}
+
+	operation, err := s.querier.FindOperationById(ctx, store.FindOperationByIdParams{
+		Uid:       uid,
+		ProjectID: req.ProjectId,
+	})
+	if err == pgx.ErrNoRows {
+		return &res, status.Error(codes.NotFound, "operation not found")
+	}
+	if err != nil {
+		return &res, status.Error(codes.Internal, "could not find operation")
+	}
+	return toOperationPb(operation), nil
+}
+
+func (s *server) UndeleteProject(ctx context.Context, req *UndeleteProjectRequest) (*Project, error) {
+	var res Project
+
+	if !resource.ValidResourceID(req.Id) {
+		return &res, status.Error(codes.InvalidArgument, "invalid project id")
+	}
+
+	tx, txq, err := s.querier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return &res, err
+	}
+	defer tx.Rollback(ctx)
+
+	project, err := txq.SelectProjectForUpdate(ctx, req.Id)
+	if err == pgx.ErrNoRows {
+		return &res, status.Error(codes.NotFound, "project not found")
+	}
+	if err != nil {
+		return &res, status.Error(codes.Internal, "could not find project")
+	}
+	err = authorize(ctx, txq, project, billingaccount.PermissionProjectsDelete)
+	if err != nil {
+		return &res, err
+	}
+	if !project.DeleteTime.Valid {
+		return &res, status.Error(codes.FailedPrecondition, "project has not been deleted")
+	}
+	if !project.PurgeTime.Time.After(time.Now()) {
+		return &res, status.Error(codes.FailedPrecondition, "project can no longer be undeleted")
+	}
+
+	undeleted, err := txq.UndeleteProject(ctx, project.ID)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when undeleting project", zap.Error(err))
+		return &res, status.Error(codes.Internal, "undelete failed")
+	}
+	err = audit.Record(ctx, txq, "projects/"+project.ID, toProjectPb(project), toProjectPb(undeleted))
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when auditing project undelete", zap.Error(err))
+		return &res, status.Error(codes.Internal, "undelete failed")
+	}
 
 	err = tx.Commit(ctx)
 	if err != nil {
-		s.log.Error("transaction failed when updating project", zap.Error(err))
-		return &res, status.Error(codes.Internal, "update failed")
+		logger.FromContext(ctx, s.log).Error("transaction failed when undeleting project", zap.Error(err))
+		return &res, status.Error(codes.Internal, "undelete failed")
 	}
-	return &res, nil
+	return toProjectPb(undeleted), nil
 }
 
 func (s *server) GetProject(ctx context.Context, req *GetProjectRequest) (*Project, error) {
@@ -134,18 +348,37 @@ func (s *server) GetProject(ctx context.Context, req *GetProjectRequest) (*Proje
 	if err != nil {
 		return &res, status.Errorf(codes.Internal, codes.Internal.String())
 	}
+	err = authorize(ctx, txq, item, billingaccount.PermissionProjectsGet)
+	if err != nil {
+		return &res, err
+	}
 
 	return toProjectPb(item), nil
 }
 
+// projectFields are the fields projects can be filtered and ordered by
+var projectFields = filter.Schema{
+	"id":                 {Column: "id", Type: filter.String},
+	"billing_account_id": {Column: "billing_account_id", Type: filter.String},
+	"create_time":        {Column: "create_time", Type: filter.Time},
+}
+
 func (s *server) ListProjects(ctx context.Context, req *ListProjectsRequest) (*ListProjectsResponse, error) {
 	var res ListProjectsResponse
 
-	if req.PageSize > 100 {
-		req.PageSize = 100
+	pageSize := pagination.PageSize(req.PageSize)
+	listFilter, err := filter.Parse(req.Filter, projectFields)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
 	}
-	if req.PageSize < 10 {
-		req.PageSize = 10
+	orderBy, err := filter.ParseOrderBy(req.OrderBy, projectFields)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order_by: %v", err)
+	}
+	fingerprint := pagination.Fingerprint("ListProjects", principal.Fingerprint(ctx), req.Filter, req.OrderBy, strconv.FormatBool(req.ShowDeleted))
+	cursor, err := pagination.Decode(req.PageToken, fingerprint)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
 	}
 
 	tx, txq, err := s.querier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
@@ -154,36 +387,114 @@ func (s *server) ListProjects(ctx context.Context, req *ListProjectsRequest) (*L
 	}
 	defer tx.Rollback(ctx)
 
-	ids := make([]string, 100)
+	allBillingAccounts, billingAccountIDs, err := billingaccount.Scope(ctx, txq, billingaccount.PermissionProjectsGet)
+	if err != nil {
+		return nil, err
+	}
 
+	// fetch one extra row to find out whether there is another page
 	project, err := txq.ListProjects(ctx, store.ListProjectsParams{
-		Ids:   ids,
-		Limit: req.PageSize,
+		ListParams: store.ListParams{
+			Filter:  listFilter,
+			OrderBy: orderBy,
+			Cursor:  cursor,
+			Limit:   pageSize + 1,
+		},
+		AllBillingAccounts: allBillingAccounts,
+		BillingAccountIds:  billingAccountIDs,
+		ShowDeleted:        req.ShowDeleted,
 	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, codes.Internal.String())
 	}
 
-	res.PageSize = req.PageSize
+	if len(project) > int(pageSize) {
+		project = project[:pageSize]
+		last := project[len(project)-1]
+		res.NextPageToken = pagination.Encode(cursor.Next(orderBy == nil, last.CreateTime, last.ID, pageSize), fingerprint)
+	}
+	res.PageSize = pageSize
 
 	res.Projects = make([]*Project, len(project))
 	for i, row := range project {
-		res.Projects[i] = &Project{
-			BillingAccountId: row.BillingAccountID,
-			Id:               row.ID,
-		}
+		res.Projects[i] = toProjectPb(row)
 	}
 	return &res, nil
 }
 
+const (
+	maxDisplayNameLength = 100
+	maxDescriptionLength = 1024
+)
+
+func validateProjectMetadata(project *Project) error {
+	if utf8.RuneCountInString(project.DisplayName) > maxDisplayNameLength {
+		return status.Errorf(codes.InvalidArgument, "display name can be at most %d characters", maxDisplayNameLength)
+	}
+	if utf8.RuneCountInString(project.Description) > maxDescriptionLength {
+		return status.Errorf(codes.InvalidArgument, "description can be at most %d characters", maxDescriptionLength)
+	}
+	err := resource.ValidateLabels(project.Labels)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid labels: %v", err)
+	}
+	return nil
+}
+
+// projectEtag changes whenever the project is updated
+func projectEtag(project store.Project) string {
+	return strconv.FormatInt(project.Version, 10)
+}
+
+// updatableProjectFields are the paths of an update mask that UpdateProject can change
+var updatableProjectFields = map[string]bool{
+	"billing_account_id": true,
+	"display_name":       true,
+	"description":        true,
+	"labels":             true,
+}
+
+// UpdateProject changes the fields of the project named in the update mask, or all the fields set in the request when
+// there is no mask. A stale etag is rejected with Aborted. Changing the billing account transfers the project: its
+// open orders move with it and the transfer is recorded so the biller charges the old billing account up to the
+// transfer and the new one afterwards.
 func (s *server) UpdateProject(ctx context.Context, req *UpdateProjectRequest) (*Project, error) {
 	var res Project
 
-	if !resource.ValidResourceID(req.Project.Id) {
+	if req.Project == nil || !resource.ValidResourceID(req.Project.Id) {
 		return &res, status.Error(codes.InvalidArgument, "invalid id")
 	}
 
-	var updated store.Project
+	var paths []string
+	if len(req.UpdateMask.GetPaths()) == 0 {
+		if req.Project.BillingAccountId != "" {
+			paths = append(paths, "billing_account_id")
+		}
+		if req.Project.DisplayName != "" {
+			paths = append(paths, "display_name")
+		}
+		if req.Project.Description != "" {
+			paths = append(paths, "description")
+		}
+		if len(req.Project.Labels) > 0 {
+			paths = append(paths, "labels")
+		}
+	} else {
+		if !req.UpdateMask.IsValid(req.Project) {
+			return &res, status.Error(codes.InvalidArgument, "invalid update mask")
+		}
+		req.UpdateMask.Normalize()
+		for _, path := range req.UpdateMask.GetPaths() {
+			if !updatableProjectFields[path] {
+				return &res, status.Errorf(codes.InvalidArgument, "field %s can not be updated", path)
+			}
+			paths = append(paths, path)
+		}
+	}
+	err := validateProjectMetadata(req.Project)
+	if err != nil {
+		return &res, err
+	}
 
 	tx, txq, err := s.querier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
 	if err != nil {
@@ -192,71 +503,335 @@ func (s *server) UpdateProject(ctx context.Context, req *UpdateProjectRequest) (
 	defer tx.Rollback(ctx)
 
 	existing, err := txq.SelectProjectForUpdate(ctx, req.Project.Id)
+	if err == pgx.ErrNoRows {
+		return &res, status.Error(codes.NotFound, "project not found")
+	}
 	if err != nil {
 		return &res, err
 	}
+	err = authorize(ctx, txq, existing, billingaccount.PermissionProjectsUpdate)
+	if err != nil {
+		return &res, err
+	}
+	if existing.DeleteTime.Valid {
+		return &res, status.Error(codes.FailedPrecondition, "project has been deleted")
+	}
+	if req.Project.Etag != "" && req.Project.Etag != projectEtag(existing) {
+		return &res, status.Error(codes.Aborted, "project has been modified since it was read, etag is stale")
+	}
 
 	updates := store.UpdateProjectParams{
+		ID:               existing.ID,
 		BillingAccountID: existing.BillingAccountID,
+		DisplayName:      existing.DisplayName,
+		Description:      existing.Description,
+		Labels:           existing.Labels,
+	}
+	changed := false
+	for _, path := range paths {
+		switch path {
+		case "billing_account_id":
+			if req.Project.BillingAccountId == "" {
+				return &res, status.Error(codes.InvalidArgument, "billing account id is required")
+			}
+			changed = changed || req.Project.BillingAccountId != existing.BillingAccountID
+			updates.BillingAccountID = req.Project.BillingAccountId
+		case "display_name":
+			changed = changed || req.Project.DisplayName != existing.DisplayName
+			updates.DisplayName = req.Project.DisplayName
+		case "description":
+			changed = changed || req.Project.Description != existing.Description
+			updates.Description = req.Project.Description
+		case "labels":
+			updates.Labels, err = store.NewLabels(req.Project.Labels)
+			if err != nil {
+				return &res, status.Error(codes.InvalidArgument, "invalid labels")
+			}
+			changed = true
+		}
+	}
+
+	// nothing to change
+	if !changed {
+		return toProjectPb(existing), nil
 	}
 
-	if req.Project.BillingAccountId != existing.ID {
-		err := billingaccount.EnsureDemandEnabled(ctx, txq, req.Project.BillingAccountId)
+	if updates.BillingAccountID != existing.BillingAccountID {
+		// moving a project creates it in the billing account it is moved to
+		err = billingaccount.Authorize(ctx, txq, updates.BillingAccountID, billingaccount.PermissionProjectsCreate)
 		if err != nil {
 			return &res, err
 		}
-		updates.BillingAccountID = req.Project.BillingAccountId
+		err = billingaccount.EnsureDemandEnabled(ctx, txq, updates.BillingAccountID)
+		if err != nil {
+			return &res, err
+		}
+	}
+
+	updated, err := txq.UpdateProject(ctx, updates)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when updating project", zap.Error(err))
+		return &res, status.Error(codes.Internal, "update failed")
 	}
 
-	updated, err = txq.UpdateProject(ctx, updates)
+	if updated.BillingAccountID != existing.BillingAccountID {
+		err = transferProject(ctx, txq, existing.ID, existing.BillingAccountID, updated.BillingAccountID, time.Now())
+		if err != nil {
+			logger.FromContext(ctx, s.log).Error("query failed when transferring project", zap.String("projectId", existing.ID), zap.Error(err))
+			return &res, status.Error(codes.Internal, "update failed")
+		}
+	}
+	err = audit.Record(ctx, txq, "projects/"+existing.ID, toProjectPb(existing), toProjectPb(updated))
 	if err != nil {
-		s.log.Error("query failed when updating project", zap.Error(err))
+		logger.FromContext(ctx, s.log).Error("query failed when auditing project update", zap.Error(err))
 		return &res, status.Error(codes.Internal, "update failed")
 	}
 
 	err = tx.Commit(ctx)
 	if err != nil {
-		s.log.Error("transaction failed when updating project", zap.Error(err))
+		logger.FromContext(ctx, s.log).Error("transaction failed when updating project", zap.Error(err))
 		return &res, status.Error(codes.Internal, "update failed")
 	}
 
 	return toProjectPb(updated), nil
 }
 
-func (s *server) GetProjectCurrentSpend(ctx context.Context, req *GetProjectCurrentSpendRequest) (*ProjectSpend, error) {
-	var res ProjectSpend
+// transferProject moves the open orders of a project to its new billing account and records the transfer. Orders
+// that have ended stay with the billing account they were billed to.
+func transferProject(ctx context.Context, q store.Querier, projectID string, from string, to string, transferTime time.Time) error {
+	orderIDs, err := q.TransferProjectOrders(ctx, store.TransferProjectOrdersParams{
+		ProjectID:        projectID,
+		BillingAccountID: to,
+	})
+	if err != nil {
+		return fmt.Errorf("transfer project orders failed: %w", err)
+	}
+	if orderIDs == nil {
+		orderIDs = []string{}
+	}
 
-	if req.Id == "" {
-		return &res, status.Error(codes.InvalidArgument, "project name is required")
+	_, err = q.CreateProjectTransfer(ctx, store.CreateProjectTransferParams{
+		ProjectID:            projectID,
+		FromBillingAccountID: from,
+		ToBillingAccountID:   to,
+		OrderIds:             orderIDs,
+		TransferTime:         transferTime,
+	})
+	if err != nil {
+		return fmt.Errorf("create project transfer failed: %w", err)
+	}
+	return nil
+}
+
+// GetProjectCurrentSpend returns the spend of the latest billing period billed to the current billing account of
+// the project
+func (s *server) GetProjectCurrentSpend(ctx context.Context, req *GetProjectCurrentSpendRequest) (*ProjectSpend, error) {
+	if !resource.ValidResourceID(req.Id) {
+		return nil, status.Error(codes.InvalidArgument, "invalid project id")
 	}
 
 	tx, txq, err := s.querier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
 	if err != nil {
-		return &res, err
+		return nil, err
 	}
 	defer tx.Rollback(ctx)
 
 	project, err := txq.FindProjectById(ctx, req.Id)
+	if err == pgx.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "project not found")
+	}
 	if err != nil {
-		return &res, err
+		return nil, status.Error(codes.Internal, "could not find project")
+	}
+	err = authorize(ctx, txq, project, billingaccount.PermissionSpendGet)
+	if err != nil {
+		return nil, err
 	}
 
-	spend, err := txq.GetProjectCurrentSpend(ctx, project.ID)
+	spend, err := txq.GetProjectCurrentSpend(ctx, store.GetProjectCurrentSpendParams{
+		ProjectID:        project.ID,
+		BillingAccountID: project.BillingAccountID,
+	})
+	if err == pgx.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "project has no spend")
+	}
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when getting project current spend", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not get project spend")
+	}
 
-	return &ProjectSpend{
-		Uid:       spend.Uid.String(),
-		ProjectId: spend.ProjectID,
-		// Spend:     spend.Spend,
-		StartTime: timestamppb.New(spend.StartTime),
-		EndTime:   timestamppb.New(spend.EndTime),
-	}, nil
+	res, err := toProjectSpendPb(ctx, txq, spend)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when listing project order spend", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not get project spend")
+	}
+	return res, nil
+}
+
+// projectSpendFields are the fields the spend history of a project can be filtered and ordered by
+var projectSpendFields = filter.Schema{
+	"start_time": {Column: "start_time", Type: filter.Time},
+	"end_time":   {Column: "end_time", Type: filter.Time},
 }
 
 func (s *server) GetProjectSpendHistory(ctx context.Context, req *GetProjectSpendHistoryRequest) (*GetProjectSpendHistoryResponse, error) {
 	var res GetProjectSpendHistoryResponse
 
-	if req.Id == "" {
-		return &res, status.Error(codes.InvalidArgument, "project name is required")
+	if !resource.ValidResourceID(req.Id) {
+		return nil, status.Error(codes.InvalidArgument, "invalid project id")
+	}
+	var startTime, endTime time.Time
+	if req.StartTime != nil {
+		startTime = req.StartTime.AsTime()
+	}
+	if req.EndTime != nil {
+		endTime = req.EndTime.AsTime()
+	}
+	if req.StartTime != nil && req.EndTime != nil && !startTime.Before(endTime) {
+		return nil, status.Error(codes.InvalidArgument, "start time must be before end time")
+	}
+	pageSize := pagination.PageSize(req.PageSize)
+	spendFilter, err := filter.Parse(req.Filter, projectSpendFields)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
+	}
+	orderBy, err := filter.ParseOrderBy(req.OrderBy, projectSpendFields)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order_by: %v", err)
+	}
+	// the time range is part of the listing, a token can't be reused after changing it
+	fingerprint := pagination.Fingerprint("GetProjectSpendHistory", principal.Fingerprint(ctx), req.Id, req.Filter, req.OrderBy,
+		startTime.Format(time.RFC3339Nano), endTime.Format(time.RFC3339Nano))
+	cursor, err := pagination.Decode(req.PageToken, fingerprint)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+
+	tx, txq, err := s.querier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	project, err := txq.FindProjectById(ctx, req.Id)
+	if err == pgx.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "project not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "could not find project")
+	}
+	err = authorize(ctx, txq, project, billingaccount.PermissionSpendGet)
+	if err != nil {
+		return nil, err
+	}
+
+	// spend history is ordered by billing period rather than (create_time, id) so pages are found by offset,
+	// fetching one extra row to find out whether there is another page
+	spend, err := txq.ListProjectSpend(ctx, store.ListProjectSpendParams{
+		ProjectID: project.ID,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Filter:    spendFilter,
+		OrderBy:   orderBy,
+		Limit:     pageSize + 1,
+		Offset:    cursor.Offset,
+	})
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when listing project spend", zap.Error(err))
+		return nil, status.Error(codes.Internal, "could not list project spend")
+	}
+
+	if len(spend) > int(pageSize) {
+		spend = spend[:pageSize]
+		res.NextPageToken = pagination.Encode(cursor.Next(false, time.Time{}, "", pageSize), fingerprint)
+	}
+	res.PageSize = pageSize
+
+	res.ProjectSpendHistory = make([]*ProjectSpend, len(spend))
+	for i, row := range spend {
+		res.ProjectSpendHistory[i], err = toProjectSpendPb(ctx, txq, row)
+		if err != nil {
+			logger.FromContext(ctx, s.log).Error("query failed when listing project order spend", zap.Error(err))
+			return nil, status.Error(codes.Internal, "could not list project spend")
+		}
+	}
+	return &res, nil
+}
+
+// toProjectSpendPb converts project spend and breaks it down by the orders billed in the same period to the same
+// billing account, and by their infrastructure type
+func toProjectSpendPb(ctx context.Context, q store.Querier, spend store.ProjectSpend) (*ProjectSpend, error) {
+	orderSpend, err := q.ListOrderSpendForProjectSpend(ctx, store.ListOrderSpendForProjectSpendParams{
+		ProjectID:        spend.ProjectID,
+		BillingAccountID: spend.BillingAccountID,
+		StartTime:        spend.StartTime,
+		EndTime:          spend.EndTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ProjectSpend{
+		Uid:              spend.Uid.String(),
+		ProjectId:        spend.ProjectID,
+		BillingAccountId: spend.BillingAccountID,
+		Spend:            spend.Spend.String(),
+		StartTime:        timestamppb.New(spend.StartTime),
+		EndTime:          timestamppb.New(spend.EndTime),
+		OrderSpend:       make([]*OrderSpend, len(orderSpend)),
+	}
+
+	infraTypeSpend := map[store.InfrastructureType]*apd.Decimal{}
+	for i, row := range orderSpend {
+		res.OrderSpend[i] = &OrderSpend{
+			OrderId:   row.OrderID,
+			InfraType: string(row.InfraType),
+			Spend:     row.Spend.String(),
+		}
+		total, ok := infraTypeSpend[row.InfraType]
+		if !ok {
+			total = apd.New(0, 0)
+			infraTypeSpend[row.InfraType] = total
+		}
+		_, err = apdContext.Add(total, total, &row.Spend)
+		if err != nil {
+			return nil, fmt.Errorf("error adding order spend to infrastructure type spend: %w", err)
+		}
+	}
+	// infrastructure types in a fixed order so responses are stable
+	for _, infraType := range []store.InfrastructureType{store.InfrastructureTypeDedicated, store.InfrastructureTypeShared, store.InfrastructureTypeStorage} {
+		total, ok := infraTypeSpend[infraType]
+		if !ok {
+			continue
+		}
+		res.InfraTypeSpend = append(res.InfraTypeSpend, &InfraTypeSpend{
+			InfraType: string(infraType),
+			Spend:     total.String(),
+		})
+	}
+	return res, nil
+}
+
+// GetProjectSpendByLabel sums the spend of the orders of a project by the value of one of their labels
+func (s *server) GetProjectSpendByLabel(ctx context.Context, req *GetProjectSpendByLabelRequest) (*GetProjectSpendByLabelResponse, error) {
+	var res GetProjectSpendByLabelResponse
+
+	if !resource.ValidResourceID(req.Id) {
+		return &res, status.Error(codes.InvalidArgument, "invalid project id")
+	}
+	if !resource.ValidLabelKey(req.LabelKey) {
+		return &res, status.Error(codes.InvalidArgument, "invalid label key")
+	}
+	startTime := time.Unix(0, 0)
+	if req.StartTime != nil {
+		startTime = req.StartTime.AsTime()
+	}
+	endTime := time.Now().AddDate(100, 0, 0)
+	if req.EndTime != nil {
+		endTime = req.EndTime.AsTime()
+	}
+	if !startTime.Before(endTime) {
+		return &res, status.Error(codes.InvalidArgument, "start time must be before end time")
 	}
 
 	tx, txq, err := s.querier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
@@ -266,29 +841,183 @@ func (s *server) GetProjectSpendHistory(ctx context.Context, req *GetProjectSpen
 	defer tx.Rollback(ctx)
 
 	project, err := txq.FindProjectById(ctx, req.Id)
+	if err == pgx.ErrNoRows {
+		return &res, status.Error(codes.NotFound, "project not found")
+	}
+	if err != nil {
+		return &res, status.Error(codes.Internal, "could not find project")
+	}
+	err = authorize(ctx, txq, project, billingaccount.PermissionSpendGet)
 	if err != nil {
 		return &res, err
 	}
 
-	spend, err := txq.GetProjectSpendHistory(ctx, project.ID)
+	spend, err := txq.ListProjectSpendByLabel(ctx, store.ListProjectSpendByLabelParams{
+		ProjectID: project.ID,
+		LabelKey:  req.LabelKey,
+		StartTime: startTime,
+		EndTime:   endTime,
+	})
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("query failed when listing project spend by label", zap.Error(err))
+		return &res, status.Error(codes.Internal, "could not list project spend")
+	}
 
-	res.ProjectSpendHistory = make([]*ProjectSpend, len(spend))
+	res.LabelKey = req.LabelKey
+	res.Spend = make([]*LabelSpend, len(spend))
 	for i, row := range spend {
-		res.ProjectSpendHistory[i] = &ProjectSpend{
-			Uid:       row.Uid.String(),
-			ProjectId: row.ProjectID,
-			// Spend:     float32(row.Spend),
-			StartTime: timestamppb.New(row.StartTime),
-			EndTime:   timestamppb.New(row.EndTime),
+		res.Spend[i] = &LabelSpend{
+			LabelValue: row.LabelValue,
+			Spend:      row.Spend.String(),
 		}
 	}
 	return &res, nil
 }
 
+// orderFields are the fields the orders of a project can be filtered and ordered by
+var orderFields = filter.Schema{
+	"id":          {Column: "id", Type: filter.String},
+	"status":      {Column: "status", Type: filter.String, Values: []string{string(store.OrderStatusActive), string(store.OrderStatusCanceled), string(store.OrderStatusComplete), string(store.OrderStatusFailed)}},
+	"infra_type":  {Column: "infra_type", Type: filter.String, Values: []string{string(store.InfrastructureTypeDedicated), string(store.InfrastructureTypeShared), string(store.InfrastructureTypeStorage)}},
+	"quantity":    {Column: "quantity", Type: filter.Number},
+	"create_time": {Column: "create_time", Type: filter.Time},
+}
+
+func (s *server) ListOrders(ctx context.Context, req *ListOrdersRequest) (*ListOrdersResponse, error) {
+	var res ListOrdersResponse
+
+	if !resource.ValidResourceID(req.ProjectId) {
+		return nil, status.Error(codes.InvalidArgument, "invalid project id")
+	}
+	pageSize := pagination.PageSize(req.PageSize)
+	listFilter, err := filter.Parse(req.Filter, orderFields)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
+	}
+	orderBy, err := filter.ParseOrderBy(req.OrderBy, orderFields)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order_by: %v", err)
+	}
+	fingerprint := pagination.Fingerprint("ListOrders", principal.Fingerprint(ctx), req.ProjectId, req.Filter, req.OrderBy)
+	cursor, err := pagination.Decode(req.PageToken, fingerprint)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+
+	tx, txq, err := s.querier.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return &res, err
+	}
+	defer tx.Rollback(ctx)
+
+	allBillingAccounts, billingAccountIDs, err := billingaccount.Scope(ctx, txq, billingaccount.PermissionProjectsGet)
+	if err != nil {
+		return nil, err
+	}
+
+	// fetch one extra row to find out whether there is another page
+	orders, err := txq.ListOrders(ctx, store.ListOrdersParams{
+		ListParams: store.ListParams{
+			Filter:  listFilter,
+			OrderBy: orderBy,
+			Cursor:  cursor,
+			Limit:   pageSize + 1,
+		},
+		ProjectID:          req.ProjectId,
+		AllBillingAccounts: allBillingAccounts,
+		BillingAccountIds:  billingAccountIDs,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, codes.Internal.String())
+	}
+
+	if len(orders) > int(pageSize) {
+		orders = orders[:pageSize]
+		last := orders[len(orders)-1]
+		res.NextPageToken = pagination.Encode(cursor.Next(orderBy == nil, last.CreateTime, last.ID, pageSize), fingerprint)
+	}
+	res.PageSize = pageSize
+
+	res.Orders = make([]*Order, len(orders))
+	for i, row := range orders {
+		res.Orders[i] = toOrderPb(row)
+	}
+	return &res, nil
+}
+
+// authorize checks the principal has a permission on the billing account of a project, projects of billing accounts
+// the principal isn't a member of are reported as not found
+func authorize(ctx context.Context, q store.Querier, project store.Project, permission billingaccount.Permission) error {
+	err := billingaccount.Authorize(ctx, q, project.BillingAccountID, permission)
+	if status.Code(err) == codes.NotFound {
+		return status.Error(codes.NotFound, "project not found")
+	}
+	return err
+}
+
+// authorizeProject is authorize for requests that don't otherwise need to find the project
+func authorizeProject(ctx context.Context, q store.Querier, projectID string, permission billingaccount.Permission) error {
+	if principal.IsAdmin(ctx) {
+		return nil
+	}
+	project, err := q.FindProjectById(ctx, projectID)
+	if err == pgx.ErrNoRows {
+		return status.Error(codes.NotFound, "project not found")
+	}
+	if err != nil {
+		return status.Error(codes.Internal, "could not find project")
+	}
+	return authorize(ctx, q, project, permission)
+}
+
+func toOrderPb(in store.Order) *Order {
+	labels, _ := store.LabelsMap(in.Labels)
+	return &Order{
+		Id:               in.ID,
+		ProjectId:        in.ProjectID,
+		BillingAccountId: in.BillingAccountID,
+		InfraType:        string(in.InfraType),
+		Status:           string(in.Status),
+		Quantity:         in.Quantity,
+		Description:      in.Description,
+		PriceHr:          in.PriceHr,
+		CreateTime:       timestamppb.New(in.CreateTime),
+		Labels:           labels,
+	}
+}
+
 func toProjectPb(in store.Project) *Project {
+	labels, _ := store.LabelsMap(in.Labels)
 	out := Project{
 		BillingAccountId: in.BillingAccountID,
 		Id:               in.ID,
+		DisplayName:      in.DisplayName,
+		Description:      in.Description,
+		Labels:           labels,
+		CreateTime:       timestamppb.New(in.CreateTime),
+		UpdateTime:       timestamppb.New(in.UpdateTime),
+		Etag:             projectEtag(in),
+	}
+	if in.DeleteTime.Valid {
+		out.DeleteTime = timestamppb.New(in.DeleteTime.Time)
+	}
+	if in.PurgeTime.Valid {
+		out.PurgeTime = timestamppb.New(in.PurgeTime.Time)
+	}
+	return &out
+}
+
+func toOperationPb(in store.Operation) *Operation {
+	out := Operation{
+		Id:           in.Uid.String(),
+		ProjectId:    in.ProjectID,
+		Type:         string(in.Type),
+		Status:       string(in.Status),
+		ErrorMessage: in.ErrorMessage,
+		CreateTime:   timestamppb.New(in.CreateTime),
+	}
+	if in.EndTime.Valid {
+		out.EndTime = timestamppb.New(in.EndTime.Time)
 	}
 	return &out
 }
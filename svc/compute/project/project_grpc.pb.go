@@ -11,7 +11,6 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -24,12 +23,18 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ProjectServiceClient interface {
 	CreateProject(ctx context.Context, in *CreateProjectRequest, opts ...grpc.CallOption) (*Project, error)
-	DeleteProject(ctx context.Context, in *DeleteProjectRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// DeleteProject ends the active orders of the project, issues its final bill and marks it deleted. The returned
+	// operation records the outcome.
+	DeleteProject(ctx context.Context, in *DeleteProjectRequest, opts ...grpc.CallOption) (*Operation, error)
+	UndeleteProject(ctx context.Context, in *UndeleteProjectRequest, opts ...grpc.CallOption) (*Project, error)
 	GetProject(ctx context.Context, in *GetProjectRequest, opts ...grpc.CallOption) (*Project, error)
 	ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error)
 	UpdateProject(ctx context.Context, in *UpdateProjectRequest, opts ...grpc.CallOption) (*Project, error)
 	GetProjectSpendHistory(ctx context.Context, in *GetProjectSpendHistoryRequest, opts ...grpc.CallOption) (*GetProjectSpendHistoryResponse, error)
+	GetProjectSpendByLabel(ctx context.Context, in *GetProjectSpendByLabelRequest, opts ...grpc.CallOption) (*GetProjectSpendByLabelResponse, error)
 	GetProjectCurrentSpend(ctx context.Context, in *GetProjectCurrentSpendRequest, opts ...grpc.CallOption) (*ProjectSpend, error)
+	ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error)
+	GetProjectOperation(ctx context.Context, in *GetProjectOperationRequest, opts ...grpc.CallOption) (*Operation, error)
 }
 
 type projectServiceClient struct {
@@ -49,8 +54,8 @@ func (c *projectServiceClient) CreateProject(ctx context.Context, in *CreateProj
 	return out, nil
 }
 
-func (c *projectServiceClient) DeleteProject(ctx context.Context, in *DeleteProjectRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	out := new(emptypb.Empty)
+func (c *projectServiceClient) DeleteProject(ctx context.Context, in *DeleteProjectRequest, opts ...grpc.CallOption) (*Operation, error) {
+	out := new(Operation)
 	err := c.cc.Invoke(ctx, "/org.cudo.compute.v1.ProjectService/DeleteProject", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -58,6 +63,15 @@ func (c *projectServiceClient) DeleteProject(ctx context.Context, in *DeleteProj
 	return out, nil
 }
 
+func (c *projectServiceClient) UndeleteProject(ctx context.Context, in *UndeleteProjectRequest, opts ...grpc.CallOption) (*Project, error) {
+	out := new(Project)
+	err := c.cc.Invoke(ctx, "/org.cudo.compute.v1.ProjectService/UndeleteProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *projectServiceClient) GetProject(ctx context.Context, in *GetProjectRequest, opts ...grpc.CallOption) (*Project, error) {
 	out := new(Project)
 	err := c.cc.Invoke(ctx, "/org.cudo.compute.v1.ProjectService/GetProject", in, out, opts...)
@@ -94,6 +108,15 @@ func (c *projectServiceClient) GetProjectSpendHistory(ctx context.Context, in *G
 	return out, nil
 }
 
+func (c *projectServiceClient) GetProjectSpendByLabel(ctx context.Context, in *GetProjectSpendByLabelRequest, opts ...grpc.CallOption) (*GetProjectSpendByLabelResponse, error) {
+	out := new(GetProjectSpendByLabelResponse)
+	err := c.cc.Invoke(ctx, "/org.cudo.compute.v1.ProjectService/GetProjectSpendByLabel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *projectServiceClient) GetProjectCurrentSpend(ctx context.Context, in *GetProjectCurrentSpendRequest, opts ...grpc.CallOption) (*ProjectSpend, error) {
 	out := new(ProjectSpend)
 	err := c.cc.Invoke(ctx, "/org.cudo.compute.v1.ProjectService/GetProjectCurrentSpend", in, out, opts...)
@@ -103,17 +126,41 @@ func (c *projectServiceClient) GetProjectCurrentSpend(ctx context.Context, in *G
 	return out, nil
 }
 
+func (c *projectServiceClient) ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error) {
+	out := new(ListOrdersResponse)
+	err := c.cc.Invoke(ctx, "/org.cudo.compute.v1.ProjectService/ListOrders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) GetProjectOperation(ctx context.Context, in *GetProjectOperationRequest, opts ...grpc.CallOption) (*Operation, error) {
+	out := new(Operation)
+	err := c.cc.Invoke(ctx, "/org.cudo.compute.v1.ProjectService/GetProjectOperation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProjectServiceServer is the server API for ProjectService service.
 // All implementations must embed UnimplementedProjectServiceServer
 // for forward compatibility
 type ProjectServiceServer interface {
 	CreateProject(context.Context, *CreateProjectRequest) (*Project, error)
-	DeleteProject(context.Context, *DeleteProjectRequest) (*emptypb.Empty, error)
+	// DeleteProject ends the active orders of the project, issues its final bill and marks it deleted. The returned
+	// operation records the outcome.
+	DeleteProject(context.Context, *DeleteProjectRequest) (*Operation, error)
+	UndeleteProject(context.Context, *UndeleteProjectRequest) (*Project, error)
 	GetProject(context.Context, *GetProjectRequest) (*Project, error)
 	ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error)
 	UpdateProject(context.Context, *UpdateProjectRequest) (*Project, error)
 	GetProjectSpendHistory(context.Context, *GetProjectSpendHistoryRequest) (*GetProjectSpendHistoryResponse, error)
+	GetProjectSpendByLabel(context.Context, *GetProjectSpendByLabelRequest) (*GetProjectSpendByLabelResponse, error)
 	GetProjectCurrentSpend(context.Context, *GetProjectCurrentSpendRequest) (*ProjectSpend, error)
+	ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error)
+	GetProjectOperation(context.Context, *GetProjectOperationRequest) (*Operation, error)
 	mustEmbedUnimplementedProjectServiceServer()
 }
 
@@ -124,9 +171,12 @@ type UnimplementedProjectServiceServer struct {
 func (UnimplementedProjectServiceServer) CreateProject(context.Context, *CreateProjectRequest) (*Project, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateProject not implemented")
 }
-func (UnimplementedProjectServiceServer) DeleteProject(context.Context, *DeleteProjectRequest) (*emptypb.Empty, error) {
+func (UnimplementedProjectServiceServer) DeleteProject(context.Context, *DeleteProjectRequest) (*Operation, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteProject not implemented")
 }
+func (UnimplementedProjectServiceServer) UndeleteProject(context.Context, *UndeleteProjectRequest) (*Project, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndeleteProject not implemented")
+}
 func (UnimplementedProjectServiceServer) GetProject(context.Context, *GetProjectRequest) (*Project, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProject not implemented")
 }
@@ -139,9 +189,18 @@ func (UnimplementedProjectServiceServer) UpdateProject(context.Context, *UpdateP
 func (UnimplementedProjectServiceServer) GetProjectSpendHistory(context.Context, *GetProjectSpendHistoryRequest) (*GetProjectSpendHistoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProjectSpendHistory not implemented")
 }
+func (UnimplementedProjectServiceServer) GetProjectSpendByLabel(context.Context, *GetProjectSpendByLabelRequest) (*GetProjectSpendByLabelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProjectSpendByLabel not implemented")
+}
 func (UnimplementedProjectServiceServer) GetProjectCurrentSpend(context.Context, *GetProjectCurrentSpendRequest) (*ProjectSpend, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProjectCurrentSpend not implemented")
 }
+func (UnimplementedProjectServiceServer) ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrders not implemented")
+}
+func (UnimplementedProjectServiceServer) GetProjectOperation(context.Context, *GetProjectOperationRequest) (*Operation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProjectOperation not implemented")
+}
 func (UnimplementedProjectServiceServer) mustEmbedUnimplementedProjectServiceServer() {}
 
 // UnsafeProjectServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -191,6 +250,24 @@ func _ProjectService_DeleteProject_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProjectService_UndeleteProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeleteProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).UndeleteProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.cudo.compute.v1.ProjectService/UndeleteProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).UndeleteProject(ctx, req.(*UndeleteProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProjectService_GetProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetProjectRequest)
 	if err := dec(in); err != nil {
@@ -263,6 +340,24 @@ func _ProjectService_GetProjectSpendHistory_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProjectService_GetProjectSpendByLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProjectSpendByLabelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).GetProjectSpendByLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.cudo.compute.v1.ProjectService/GetProjectSpendByLabel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).GetProjectSpendByLabel(ctx, req.(*GetProjectSpendByLabelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProjectService_GetProjectCurrentSpend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetProjectCurrentSpendRequest)
 	if err := dec(in); err != nil {
@@ -281,6 +376,42 @@ func _ProjectService_GetProjectCurrentSpend_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProjectService_ListOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).ListOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.cudo.compute.v1.ProjectService/ListOrders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).ListOrders(ctx, req.(*ListOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_GetProjectOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProjectOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).GetProjectOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.cudo.compute.v1.ProjectService/GetProjectOperation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).GetProjectOperation(ctx, req.(*GetProjectOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProjectService_ServiceDesc is the grpc.ServiceDesc for ProjectService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -296,6 +427,10 @@ var ProjectService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteProject",
 			Handler:    _ProjectService_DeleteProject_Handler,
 		},
+		{
+			MethodName: "UndeleteProject",
+			Handler:    _ProjectService_UndeleteProject_Handler,
+		},
 		{
 			MethodName: "GetProject",
 			Handler:    _ProjectService_GetProject_Handler,
@@ -312,10 +447,22 @@ var ProjectService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProjectSpendHistory",
 			Handler:    _ProjectService_GetProjectSpendHistory_Handler,
 		},
+		{
+			MethodName: "GetProjectSpendByLabel",
+			Handler:    _ProjectService_GetProjectSpendByLabel_Handler,
+		},
 		{
 			MethodName: "GetProjectCurrentSpend",
 			Handler:    _ProjectService_GetProjectCurrentSpend_Handler,
 		},
+		{
+			MethodName: "ListOrders",
+			Handler:    _ProjectService_ListOrders_Handler,
+		},
+		{
+			MethodName: "GetProjectOperation",
+			Handler:    _ProjectService_GetProjectOperation_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "svc/compute/project/project.proto",
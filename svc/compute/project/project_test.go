@@ -2,18 +2,24 @@ package project
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"testing"
 	"time"
 
+	"biller/lib/pagination"
+	"biller/lib/principal"
 	"biller/svc/compute/store"
 
+	"github.com/cockroachdb/apd/v2"
 	"github.com/gogo/status"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v4"
 	"go.uber.org/zap/zaptest"
 	"google.golang.org/grpc/codes"
 	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type FakeFieldMask struct {
@@ -49,27 +55,62 @@ type FakeTxQuerier struct {
 	billingAccountError    error
 	createdProject         store.Project
 	createProjectError     error
+	createOperationError   error
 	deleteProjectInt       int64
 	deleteProjectError     error
 	exists                 bool
 	existsError            error
 	listProjects           []store.Project
 	listProjectsError      error
+	listProjectsParams     *store.ListProjectsParams
+	listOrders             []store.Order
+	listOrdersParams       *store.ListOrdersParams
+	projectOrders          []store.Order
+	activeLeases           []store.Lease
+	endedLeases            *[]store.EndLeaseParams
+	endedOrders            *[]store.EndOrderParams
+	endedOperations        *[]store.EndOperationParams
+	operation              store.Operation
+	findOperationError     error
+	projectSpend           *store.CreateProjectSpendParams
+	accountSpend           *store.CreateBillingAccountSpendParams
+	transferredOrders      []string
+	projectTransfer        *store.CreateProjectTransferParams
 	findProjectByIdError   error
 	project                store.Project
 	selectProjectForUpdate store.Project
 	selectError            error
 	tx                     FakeTx
 	txErr                  error
+	undeleteProject        store.Project
+	undeleteProjectError   error
 	updateProject          store.Project
 	updateProjectError     error
+	updateProjectParams    *store.UpdateProjectParams
+	spendByLabel           []store.ListProjectSpendByLabelRow
+	currentSpend           store.ProjectSpend
+	currentSpendError      error
+	spendHistory           []store.ProjectSpend
+	orderSpend             []store.ListOrderSpendForProjectSpendRow
+	members                []store.BillingAccountMember
+	// sealedBillingAccounts have their spend of the period sealed
+	sealedBillingAccounts []string
 }
 
-func (q FakeTxQuerier) BeginTx(context.Context, pgx.TxOptions) (pgx.Tx, store.Querier, error) {
+func (q FakeTxQuerier) IsBillingAccountSpendSealed(ctx context.Context, arg store.IsBillingAccountSpendSealedParams) (bool, error) {
+	for _, id := range q.sealedBillingAccounts {
+		if id == arg.BillingAccountID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (q FakeTxQuerier) BeginTx(context.Context, pgx.TxOptions) (pgx.Tx, store.QueryLister, error) {
 	return q.tx, q, q.txErr
 }
 
-func (q FakeTxQuerier) ExecWithTx(context.Context, pgx.TxOptions, func(store.Querier) error) error {
+func (q FakeTxQuerier) ExecWithTx(context.Context, pgx.TxOptions, func(store.QueryLister) error) error {
 	return nil
 }
 
@@ -85,30 +126,212 @@ func (q FakeTxQuerier) FindProjectById(ctx context.Context, id string) (store.Pr
 	return q.project, q.findProjectByIdError
 }
 
+func (q FakeTxQuerier) FindBillingAccountMember(ctx context.Context, arg store.FindBillingAccountMemberParams) (store.BillingAccountMember, error) {
+	for _, member := range q.members {
+		if member.BillingAccountID == arg.BillingAccountID && member.PrincipalID == arg.PrincipalID {
+			return member, nil
+		}
+	}
+	return store.BillingAccountMember{}, pgx.ErrNoRows
+}
+
+func (q FakeTxQuerier) ListBillingAccountMembershipsByPrincipalId(ctx context.Context, principalID string) ([]store.BillingAccountMember, error) {
+	var members []store.BillingAccountMember
+	for _, member := range q.members {
+		if member.PrincipalID == principalID {
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
 func (q FakeTxQuerier) FindProjectExistsById(ctx context.Context, id string) (bool, error) {
 	return q.exists, q.existsError
 }
 
-func (q FakeTxQuerier) DeleteProject(ctx context.Context, id string) (int64, error) {
+func (q FakeTxQuerier) DeleteProject(ctx context.Context, arg store.DeleteProjectParams) (int64, error) {
 	return q.deleteProjectInt, q.deleteProjectError
 }
 
+func (q FakeTxQuerier) CreateOperation(ctx context.Context, arg store.CreateOperationParams) (store.Operation, error) {
+	return store.Operation{
+		Uid:       q.operation.Uid,
+		Type:      arg.Type,
+		ProjectID: arg.ProjectID,
+		Status:    store.OperationStatusRunning,
+	}, q.createOperationError
+}
+
+func (q FakeTxQuerier) EndOperation(ctx context.Context, arg store.EndOperationParams) (store.Operation, error) {
+	if q.endedOperations != nil {
+		*q.endedOperations = append(*q.endedOperations, arg)
+	}
+	return store.Operation{
+		Uid:          arg.Uid,
+		Type:         store.OperationTypeDeleteProject,
+		Status:       arg.Status,
+		ErrorMessage: arg.ErrorMessage,
+		EndTime:      sql.NullTime{Time: time.Now(), Valid: true},
+	}, nil
+}
+
+func (q FakeTxQuerier) FindOperationById(ctx context.Context, arg store.FindOperationByIdParams) (store.Operation, error) {
+	return q.operation, q.findOperationError
+}
+
+func (q FakeTxQuerier) ListOrdersByProjectId(ctx context.Context, projectID string) ([]store.Order, error) {
+	return q.projectOrders, nil
+}
+
+func (q FakeTxQuerier) ListActiveLeasesByOrderId(ctx context.Context, orderID string) ([]store.Lease, error) {
+	var leases []store.Lease
+	for _, lease := range q.activeLeases {
+		if lease.OrderID == orderID {
+			leases = append(leases, lease)
+		}
+	}
+	return leases, nil
+}
+
+func (q FakeTxQuerier) ListLeasesForTimeRangeByOrderId(ctx context.Context, arg store.ListLeasesForTimeRangeByOrderIdParams) ([]store.Lease, error) {
+	var leases []store.Lease
+	for _, lease := range q.activeLeases {
+		if lease.OrderID == arg.OrderID {
+			if q.endedLeases != nil {
+				for _, ended := range *q.endedLeases {
+					if ended.ID == lease.ID {
+						lease.EndTime = sql.NullTime{Time: ended.EndTime, Valid: true}
+					}
+				}
+			}
+			leases = append(leases, lease)
+		}
+	}
+	return leases, nil
+}
+
+func (q FakeTxQuerier) ListOrderTransfers(ctx context.Context, arg store.ListOrderTransfersParams) ([]store.ListOrderTransfersRow, error) {
+	return nil, nil
+}
+
+func (q FakeTxQuerier) EndLease(ctx context.Context, arg store.EndLeaseParams) (store.Lease, error) {
+	if q.endedLeases != nil {
+		*q.endedLeases = append(*q.endedLeases, arg)
+	}
+	return store.Lease{ID: arg.ID}, nil
+}
+
+func (q FakeTxQuerier) EndOrder(ctx context.Context, arg store.EndOrderParams) (store.Order, error) {
+	if q.endedOrders != nil {
+		*q.endedOrders = append(*q.endedOrders, arg)
+	}
+	return store.Order{ID: arg.ID}, nil
+}
+
+func (q FakeTxQuerier) CreateOrderSpend(ctx context.Context, arg store.CreateOrderSpendParams) (store.OrderSpend, error) {
+	return store.OrderSpend{}, nil
+}
+
+func (q FakeTxQuerier) CreateProjectSpend(ctx context.Context, arg store.CreateProjectSpendParams) (store.ProjectSpend, error) {
+	if q.projectSpend != nil {
+		*q.projectSpend = arg
+	}
+	return store.ProjectSpend{}, nil
+}
+
+func (q FakeTxQuerier) ListOrdersByBillingAccountId(ctx context.Context, billingAccountID string) ([]store.Order, error) {
+	var orders []store.Order
+	for _, order := range q.projectOrders {
+		if order.BillingAccountID == billingAccountID {
+			orders = append(orders, order)
+		}
+	}
+	return orders, nil
+}
+
+func (q FakeTxQuerier) ListOrdersTransferredFromBillingAccount(ctx context.Context, arg store.ListOrdersTransferredFromBillingAccountParams) ([]store.Order, error) {
+	return nil, nil
+}
+
+func (q FakeTxQuerier) SumSLACreditsForBillingAccount(ctx context.Context, arg store.SumSLACreditsForBillingAccountParams) (apd.Decimal, error) {
+	return apd.Decimal{}, nil
+}
+
+func (q FakeTxQuerier) CreateBillingAccountSpend(ctx context.Context, arg store.CreateBillingAccountSpendParams) (store.BillingAccountSpend, error) {
+	if q.accountSpend != nil {
+		*q.accountSpend = arg
+	}
+	return store.BillingAccountSpend{}, nil
+}
+
 func (q FakeTxQuerier) ListProjects(ctx context.Context, arg store.ListProjectsParams) ([]store.Project, error) {
+	if q.listProjectsParams != nil {
+		*q.listProjectsParams = arg
+	}
 	return q.listProjects, q.listProjectsError
 }
 
+func (q FakeTxQuerier) ListOrders(ctx context.Context, arg store.ListOrdersParams) ([]store.Order, error) {
+	if q.listOrdersParams != nil {
+		*q.listOrdersParams = arg
+	}
+	return q.listOrders, nil
+}
+
 func (q FakeTxQuerier) SelectProjectForUpdate(ctx context.Context, id string) (store.Project, error) {
 	return q.selectProjectForUpdate, q.selectError
 }
 
+func (q FakeTxQuerier) TransferProjectOrders(ctx context.Context, arg store.TransferProjectOrdersParams) ([]string, error) {
+	return q.transferredOrders, nil
+}
+
+func (q FakeTxQuerier) CreateProjectTransfer(ctx context.Context, arg store.CreateProjectTransferParams) (store.ProjectTransfer, error) {
+	if q.projectTransfer != nil {
+		*q.projectTransfer = arg
+	}
+	return store.ProjectTransfer{}, nil
+}
+
+func (q FakeTxQuerier) UndeleteProject(ctx context.Context, id string) (store.Project, error) {
+	return q.undeleteProject, q.undeleteProjectError
+}
+
 func (q FakeTxQuerier) UpdateProject(ctx context.Context, arg store.UpdateProjectParams) (store.Project, error) {
+	if q.updateProjectParams != nil {
+		*q.updateProjectParams = arg
+	}
 	return q.updateProject, q.updateProjectError
 }
+
+func (q FakeTxQuerier) GetProjectCurrentSpend(ctx context.Context, arg store.GetProjectCurrentSpendParams) (store.ProjectSpend, error) {
+	return q.currentSpend, q.currentSpendError
+}
+
+func (q FakeTxQuerier) ListProjectSpend(ctx context.Context, arg store.ListProjectSpendParams) ([]store.ProjectSpend, error) {
+	if int(arg.Limit) < len(q.spendHistory) {
+		return q.spendHistory[:arg.Limit], nil
+	}
+	return q.spendHistory, nil
+}
+
+func (q FakeTxQuerier) ListOrderSpendForProjectSpend(ctx context.Context, arg store.ListOrderSpendForProjectSpendParams) ([]store.ListOrderSpendForProjectSpendRow, error) {
+	return q.orderSpend, nil
+}
+
+func (q FakeTxQuerier) ListProjectSpendByLabel(ctx context.Context, arg store.ListProjectSpendByLabelParams) ([]store.ListProjectSpendByLabelRow, error) {
+	return q.spendByLabel, nil
+}
+
+func systemContext() context.Context {
+	return principal.NewContext(context.Background(), &principal.Principal{ID: "system", Admin: true})
+}
+
 func Test_CreateProject(t *testing.T) {
 	t.Run("should fail if no id is passed in the request", func(t *testing.T) {
 		querier := FakeTxQuerier{}
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.CreateProject(context.Background(), &CreateProjectRequest{
+		_, err := server.CreateProject(systemContext(), &CreateProjectRequest{
 			Project: &Project{
 				Id: "",
 			},
@@ -127,7 +350,7 @@ func Test_CreateProject(t *testing.T) {
 	t.Run("should fail if the name is invalid", func(t *testing.T) {
 		querier := FakeTxQuerier{}
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.CreateProject(context.Background(), &CreateProjectRequest{
+		_, err := server.CreateProject(systemContext(), &CreateProjectRequest{
 			Project: &Project{
 				Id: "invalid&$!@",
 			},
@@ -146,7 +369,7 @@ func Test_CreateProject(t *testing.T) {
 	t.Run("should fail if the billing account id is invalid", func(t *testing.T) {
 		querier := FakeTxQuerier{}
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.CreateProject(context.Background(), &CreateProjectRequest{
+		_, err := server.CreateProject(systemContext(), &CreateProjectRequest{
 			Project: &Project{
 				Id:               "test",
 				BillingAccountId: "invalid£$^",
@@ -167,7 +390,7 @@ func Test_CreateProject(t *testing.T) {
 		querier := FakeTxQuerier{}
 		querier.txErr = errors.New("failure to begin transaction")
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.CreateProject(context.Background(), &CreateProjectRequest{
+		_, err := server.CreateProject(systemContext(), &CreateProjectRequest{
 			Project: &Project{
 				Id:               "test",
 				BillingAccountId: "b1b391aa-5755-4ca8-966e-8ef8b0f30664",
@@ -191,7 +414,7 @@ func Test_CreateProject(t *testing.T) {
 		querier := FakeTxQuerier{}
 		querier.billingAccountError = errors.New("failure to find billing account")
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.CreateProject(context.Background(), &CreateProjectRequest{
+		_, err := server.CreateProject(systemContext(), &CreateProjectRequest{
 			Project: &Project{
 				Id:               "test",
 				BillingAccountId: "b1b391aa-5755-4ca8-966e-8ef8b0f30664",
@@ -212,7 +435,7 @@ func Test_CreateProject(t *testing.T) {
 		querier := FakeTxQuerier{}
 		querier.billingAccountError = pgx.ErrNoRows
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.CreateProject(context.Background(), &CreateProjectRequest{
+		_, err := server.CreateProject(systemContext(), &CreateProjectRequest{
 			Project: &Project{
 				Id:               "test",
 				BillingAccountId: "b1b391aa-5755-4ca8-966e-8ef8b0f30664",
@@ -236,7 +459,7 @@ func Test_CreateProject(t *testing.T) {
 			DemandEnabled: false,
 		}
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.CreateProject(context.Background(), &CreateProjectRequest{
+		_, err := server.CreateProject(systemContext(), &CreateProjectRequest{
 			Project: &Project{
 				Id:               "test",
 				BillingAccountId: "b1b391aa-5755-4ca8-966e-8ef8b0f30664",
@@ -260,7 +483,7 @@ func Test_CreateProject(t *testing.T) {
 		}
 		querier.existsError = errors.New("failure to check project exists")
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.CreateProject(context.Background(), &CreateProjectRequest{
+		_, err := server.CreateProject(systemContext(), &CreateProjectRequest{
 			Project: &Project{
 				Id:               "test",
 				BillingAccountId: "valid-billing-account-id",
@@ -284,7 +507,7 @@ func Test_CreateProject(t *testing.T) {
 		}
 		querier.exists = true
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.CreateProject(context.Background(), &CreateProjectRequest{
+		_, err := server.CreateProject(systemContext(), &CreateProjectRequest{
 			Project: &Project{
 				Id:               "test",
 				BillingAccountId: "valid-billing-account-id",
@@ -308,7 +531,7 @@ func Test_CreateProject(t *testing.T) {
 		}
 		querier.createProjectError = errors.New("failure to create project")
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.CreateProject(context.Background(), &CreateProjectRequest{
+		_, err := server.CreateProject(systemContext(), &CreateProjectRequest{
 			Project: &Project{
 				Id:               "test",
 				BillingAccountId: "valid-billing-account-id",
@@ -338,7 +561,7 @@ func Test_CreateProject(t *testing.T) {
 			err: errors.New("failure to commit transaction"),
 		}
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.CreateProject(context.Background(), &CreateProjectRequest{
+		_, err := server.CreateProject(systemContext(), &CreateProjectRequest{
 			Project: &Project{
 				Id:               "test",
 				BillingAccountId: "test-billing-id",
@@ -365,7 +588,7 @@ func Test_CreateProject(t *testing.T) {
 		}
 		querier.tx = FakeTx{}
 		server := NewServer(querier, zaptest.NewLogger(t))
-		resp, err := server.CreateProject(context.Background(), &CreateProjectRequest{
+		resp, err := server.CreateProject(systemContext(), &CreateProjectRequest{
 			Project: &Project{
 				Id:               "test",
 				BillingAccountId: "b1b391aa-5755-4ca8-966e-8ef8b0f30664",
@@ -384,12 +607,62 @@ func Test_CreateProject(t *testing.T) {
 			t.Errorf("expected: %s, got: %s", querier.createdProject.ID, resp.Id)
 		}
 	})
+	t.Run("should fail when the labels are invalid", func(t *testing.T) {
+		server := NewServer(FakeTxQuerier{}, zaptest.NewLogger(t))
+		_, err := server.CreateProject(systemContext(), &CreateProjectRequest{
+			Project: &Project{
+				Id:               "test",
+				BillingAccountId: "billing-account-id",
+				Labels:           map[string]string{"Team": "billing"},
+			},
+		})
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Fatalf("expected a grpc error, got: %v", err)
+		}
+		if st.Code() != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, st.Code())
+		}
+	})
+	t.Run("should return the metadata of the created project", func(t *testing.T) {
+		labels, _ := store.NewLabels(map[string]string{"team": "billing"})
+		querier := FakeTxQuerier{}
+		querier.billingAccount = store.BillingAccount{
+			DemandEnabled: true,
+		}
+		querier.createdProject = store.Project{
+			ID:          "test",
+			DisplayName: "Test",
+			Description: "A test project",
+			Labels:      labels,
+			Version:     1,
+		}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		resp, err := server.CreateProject(systemContext(), &CreateProjectRequest{
+			Project: &Project{
+				Id:               "test",
+				BillingAccountId: "billing-account-id",
+				DisplayName:      "Test",
+				Description:      "A test project",
+				Labels:           map[string]string{"team": "billing"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got: %s", err.Error())
+		}
+		if resp.DisplayName != "Test" || resp.Description != "A test project" || resp.Labels["team"] != "billing" {
+			t.Errorf("unexpected project: %v", resp)
+		}
+		if resp.Etag != "1" {
+			t.Errorf("expected: %s, got: %s", "1", resp.Etag)
+		}
+	})
 }
 
 func Test_DeleteProject(t *testing.T) {
 	t.Run("should fail when the project has no Id passed in the request", func(t *testing.T) {
 		server := NewServer(nil, zaptest.NewLogger(t))
-		_, err := server.DeleteProject(context.Background(), &DeleteProjectRequest{
+		_, err := server.DeleteProject(systemContext(), &DeleteProjectRequest{
 			Id: "",
 		})
 		if err == nil {
@@ -407,7 +680,7 @@ func Test_DeleteProject(t *testing.T) {
 		querier := FakeTxQuerier{}
 		querier.txErr = errors.New("failure to begin transaction")
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.DeleteProject(context.Background(), &DeleteProjectRequest{
+		_, err := server.DeleteProject(systemContext(), &DeleteProjectRequest{
 			Id: "test",
 		})
 		if err == nil {
@@ -424,11 +697,36 @@ func Test_DeleteProject(t *testing.T) {
 			t.Errorf("expected: %s, got: %s", "failure to begin transaction", err.Error())
 		}
 	})
+	t.Run("should fail when the role of the caller can't delete projects", func(t *testing.T) {
+		querier := FakeTxQuerier{
+			project: store.Project{ID: "test", BillingAccountID: "billing-account-id"},
+			members: []store.BillingAccountMember{
+				{BillingAccountID: "billing-account-id", PrincipalID: "user-1", Role: store.BillingAccountRoleViewer},
+			},
+		}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		ctx := principal.NewContext(context.Background(), &principal.Principal{ID: "user-1"})
+		_, err := server.DeleteProject(ctx, &DeleteProjectRequest{Id: "test"})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("expected: %s, got: %s", codes.PermissionDenied, status.Code(err))
+		}
+	})
+	t.Run("should fail when the project does not exist", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		querier.findProjectByIdError = pgx.ErrNoRows
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.DeleteProject(systemContext(), &DeleteProjectRequest{
+			Id: "test",
+		})
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("expected: %s, got: %s", codes.NotFound, status.Code(err))
+		}
+	})
 	t.Run("should fail when FindProjectById query returns an error", func(t *testing.T) {
 		querier := FakeTxQuerier{}
 		querier.findProjectByIdError = errors.New("failure to find project")
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.DeleteProject(context.Background(), &DeleteProjectRequest{
+		_, err := server.DeleteProject(systemContext(), &DeleteProjectRequest{
 			Id: "test",
 		})
 		if err == nil {
@@ -449,7 +747,7 @@ func Test_DeleteProject(t *testing.T) {
 		querier := FakeTxQuerier{}
 		querier.deleteProjectError = errors.New("failure to delete project")
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.DeleteProject(context.Background(), &DeleteProjectRequest{
+		_, err := server.DeleteProject(systemContext(), &DeleteProjectRequest{
 			Id: "test",
 		})
 		if err == nil {
@@ -463,11 +761,11 @@ func Test_DeleteProject(t *testing.T) {
 			t.Errorf("expected: %s, got: %s", codes.Internal, st.Code())
 		}
 	})
-	t.Run("should fail when DeleteProject returns 0 deleted rows", func(t *testing.T) {
+	t.Run("should abort when another request deleted the project first", func(t *testing.T) {
 		querier := FakeTxQuerier{}
 		querier.deleteProjectInt = 0
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.DeleteProject(context.Background(), &DeleteProjectRequest{
+		_, err := server.DeleteProject(systemContext(), &DeleteProjectRequest{
 			Id: "test",
 		})
 		if err == nil {
@@ -477,17 +775,18 @@ func Test_DeleteProject(t *testing.T) {
 		if !ok {
 			t.Fatalf("expected a grpc error, got: %s", err.Error())
 		}
-		if st.Code() != codes.Internal {
-			t.Errorf("expected: %s, got: %s", codes.Internal, st.Code())
+		if st.Code() != codes.Aborted {
+			t.Errorf("expected: %s, got: %s", codes.Aborted, st.Code())
 		}
 	})
 	t.Run("should fail when commiting the transaction returns an error", func(t *testing.T) {
 		querier := FakeTxQuerier{}
+		querier.deleteProjectInt = 1
 		querier.tx = FakeTx{
 			err: errors.New("failure to commit transaction"),
 		}
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.DeleteProject(context.Background(), &DeleteProjectRequest{
+		_, err := server.DeleteProject(systemContext(), &DeleteProjectRequest{
 			Id: "test",
 		})
 		if err == nil {
@@ -505,190 +804,917 @@ func Test_DeleteProject(t *testing.T) {
 		querier := FakeTxQuerier{}
 		querier.deleteProjectInt = 1
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.DeleteProject(context.Background(), &DeleteProjectRequest{
+		_, err := server.DeleteProject(systemContext(), &DeleteProjectRequest{
 			Id: "test",
 		})
 		if err != nil {
 			t.Errorf("expected no error, got: %s", err.Error())
 		}
 	})
-}
-
-func Test_GetProject(t *testing.T) {
-	t.Run("should fail when the name passed in the request is empty", func(t *testing.T) {
-		querier := FakeTxQuerier{}
+	t.Run("should end active orders and issue a final bill before deleting the project", func(t *testing.T) {
+		var (
+			endedLeases     []store.EndLeaseParams
+			endedOrders     []store.EndOrderParams
+			endedOperations []store.EndOperationParams
+			projectSpend    store.CreateProjectSpendParams
+			accountSpend    store.CreateBillingAccountSpendParams
+		)
+		querier := FakeTxQuerier{
+			endedLeases:     &endedLeases,
+			endedOrders:     &endedOrders,
+			endedOperations: &endedOperations,
+			projectSpend:    &projectSpend,
+			accountSpend:    &accountSpend,
+		}
+		querier.deleteProjectInt = 1
+		querier.project = store.Project{ID: "test"}
+		querier.operation = store.Operation{Uid: uuid.New()}
+		querier.projectOrders = []store.Order{
+			{ID: "order-1", ProjectID: "test", BillingAccountID: "billing-account-id", Status: store.OrderStatusActive},
+			{ID: "order-2", ProjectID: "test", BillingAccountID: "billing-account-id", Status: store.OrderStatusComplete},
+		}
+		querier.activeLeases = []store.Lease{
+			{ID: "lease-1", OrderID: "order-1", PriceHr: 2, CreateTime: time.Now().Add(-90 * time.Minute)},
+		}
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.GetProject(context.Background(), &GetProjectRequest{
-			Id: "",
+		operation, err := server.DeleteProject(systemContext(), &DeleteProjectRequest{
+			Id: "test",
 		})
-		if err == nil {
-			t.Errorf("expected error, got nil")
+		if err != nil {
+			t.Fatalf("expected no error, got: %s", err.Error())
 		}
-		st, ok := status.FromError(err)
-		if !ok {
-			t.Fatalf("expected a grpc error, got: %s", err.Error())
+		if operation.Id != querier.operation.Uid.String() || operation.Status != "succeeded" || operation.EndTime == nil {
+			t.Errorf("unexpected operation: %v", operation)
 		}
-		if st.Code() != codes.InvalidArgument {
-			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, st.Code())
+		if len(endedLeases) != 1 || endedLeases[0].ID != "lease-1" || endedLeases[0].Status != store.LeaseStatusComplete {
+			t.Errorf("expected lease-1 to be ended, got: %v", endedLeases)
+		}
+		if len(endedOrders) != 1 || endedOrders[0].ID != "order-1" || endedOrders[0].Status != store.OrderStatusCanceled || !endedOrders[0].EndTime.Valid {
+			t.Errorf("expected order-1 to be canceled, got: %v", endedOrders)
+		}
+		// 1.5 hours at 2 per hour, billed up to the deletion
+		if projectSpend.ProjectID != "test" {
+			t.Errorf("expected a final bill for the project, got: %v", projectSpend)
+		}
+		spend, _ := projectSpend.Spend.Float64()
+		if spend < 2.99 || spend > 3.01 {
+			t.Errorf("expected: %v, got: %v", 3, spend)
+		}
+		// the account total is brought up to date with the final bill
+		if accountSpend.BillingAccountID != "billing-account-id" {
+			t.Errorf("expected the spend of the billing account to be written, got: %v", accountSpend)
+		}
+		spend, _ = accountSpend.Spend.Float64()
+		if spend < 2.99 || spend > 3.01 {
+			t.Errorf("expected: %v, got: %v", 3, spend)
+		}
+		if len(endedOperations) != 1 || endedOperations[0].Status != store.OperationStatusSucceeded {
+			t.Errorf("expected the operation to succeed, got: %v", endedOperations)
 		}
 	})
-	t.Run("should fail when the BeginTx query returns an error", func(t *testing.T) {
-		querier := FakeTxQuerier{}
-		querier.txErr = errors.New("failure to begin transaction")
+	t.Run("should bill the project for the period of its billing account's time zone", func(t *testing.T) {
+		var projectSpend store.CreateProjectSpendParams
+		querier := FakeTxQuerier{
+			endedLeases:     &[]store.EndLeaseParams{},
+			endedOrders:     &[]store.EndOrderParams{},
+			endedOperations: &[]store.EndOperationParams{},
+			projectSpend:    &projectSpend,
+		}
+		querier.deleteProjectInt = 1
+		querier.project = store.Project{ID: "test", BillingAccountID: "billing-account-id"}
+		querier.billingAccount = store.BillingAccount{ID: "billing-account-id", Timezone: "Pacific/Kiritimati"}
+		querier.operation = store.Operation{Uid: uuid.New()}
+		querier.projectOrders = []store.Order{
+			{ID: "order-1", ProjectID: "test", BillingAccountID: "billing-account-id", Status: store.OrderStatusActive},
+		}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.DeleteProject(systemContext(), &DeleteProjectRequest{Id: "test"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %s", err.Error())
+		}
+		kiritimati, err := time.LoadLocation("Pacific/Kiritimati")
+		if err != nil {
+			t.Fatal(err)
+		}
+		start := projectSpend.StartTime.In(kiritimati)
+		if start.Day() != 1 || start.Hour() != 0 || !projectSpend.EndTime.Equal(start.AddDate(0, 1, 0)) {
+			t.Errorf("expected the period to be a month in Pacific/Kiritimati, got: %v to %v", projectSpend.StartTime, projectSpend.EndTime)
+		}
+	})
+	t.Run("should not bill the project to a billing account whose spend of the period is sealed", func(t *testing.T) {
+		var (
+			endedOperations []store.EndOperationParams
+			projectSpend    store.CreateProjectSpendParams
+		)
+		querier := FakeTxQuerier{
+			endedLeases:           &[]store.EndLeaseParams{},
+			endedOrders:           &[]store.EndOrderParams{},
+			endedOperations:       &endedOperations,
+			projectSpend:          &projectSpend,
+			sealedBillingAccounts: []string{"sealed"},
+		}
+		querier.deleteProjectInt = 1
+		querier.project = store.Project{ID: "test"}
+		querier.operation = store.Operation{Uid: uuid.New()}
+		querier.projectOrders = []store.Order{
+			{ID: "order-1", ProjectID: "test", BillingAccountID: "sealed", Status: store.OrderStatusActive},
+		}
+		querier.activeLeases = []store.Lease{
+			{ID: "lease-1", OrderID: "order-1", PriceHr: 2, CreateTime: time.Now().Add(-90 * time.Minute)},
+		}
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.GetProject(context.Background(), &GetProjectRequest{
+		_, err := server.DeleteProject(systemContext(), &DeleteProjectRequest{
 			Id: "test",
 		})
-		if err == nil {
-			t.Errorf("expected error, got nil")
-		}
-		st, ok := status.FromError(err)
-		if ok {
-			t.Errorf("expected a non grpc error, got: %s", err.Error())
+		if err != nil {
+			t.Fatalf("expected no error, got: %s", err.Error())
 		}
-		if st.Code() != codes.Unknown {
-			t.Errorf("expected: %s, got: %s", codes.Unknown, st.Code())
+		if projectSpend.ProjectID != "" {
+			t.Errorf("expected no project spend to be written, got: %v", projectSpend)
 		}
-		if err.Error() != "failure to begin transaction" {
-			t.Errorf("expected: %s, got: %s", "failure to begin transaction", err.Error())
+		if len(endedOperations) != 1 || endedOperations[0].Status != store.OperationStatusSucceeded {
+			t.Errorf("expected the operation to succeed, got: %v", endedOperations)
 		}
 	})
-	t.Run("should fail when the FindProjectById query returns an error", func(t *testing.T) {
-		querier := FakeTxQuerier{}
-		querier.findProjectByIdError = errors.New("failure to find project")
+	t.Run("should mark the operation failed when the delete fails", func(t *testing.T) {
+		var endedOperations []store.EndOperationParams
+		querier := FakeTxQuerier{endedOperations: &endedOperations}
+		querier.deleteProjectError = errors.New("failure to delete project")
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.GetProject(context.Background(), &GetProjectRequest{
+		_, err := server.DeleteProject(systemContext(), &DeleteProjectRequest{
 			Id: "test",
 		})
-		if err == nil {
-			t.Errorf("expected error, got nil")
-		}
 		st, ok := status.FromError(err)
 		if !ok {
-			t.Fatalf("expected a grpc error, got: %s", err.Error())
+			t.Fatalf("expected a grpc error, got: %v", err)
 		}
 		if st.Code() != codes.Internal {
 			t.Errorf("expected: %s, got: %s", codes.Internal, st.Code())
 		}
+		if len(endedOperations) != 1 || endedOperations[0].Status != store.OperationStatusFailed || endedOperations[0].ErrorMessage == "" {
+			t.Errorf("expected the operation to fail, got: %v", endedOperations)
+		}
 	})
-	t.Run("should fail when the FindProjectById query returns no rows", func(t *testing.T) {
+	t.Run("should fail when the project has already been deleted", func(t *testing.T) {
 		querier := FakeTxQuerier{}
-		querier.findProjectByIdError = pgx.ErrNoRows
+		querier.deleteProjectInt = 1
+		querier.project = store.Project{
+			ID:         "test",
+			DeleteTime: sql.NullTime{Time: time.Now(), Valid: true},
+		}
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.GetProject(context.Background(), &GetProjectRequest{
+		_, err := server.DeleteProject(systemContext(), &DeleteProjectRequest{
 			Id: "test",
 		})
-		if err == nil {
-			t.Errorf("expected error, got nil")
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Fatalf("expected a grpc error, got: %v", err)
+		}
+		if st.Code() != codes.FailedPrecondition {
+			t.Errorf("expected: %s, got: %s", codes.FailedPrecondition, st.Code())
+		}
+	})
+}
+
+func Test_GetProjectOperation(t *testing.T) {
+	t.Run("should fail when the operation id is invalid", func(t *testing.T) {
+		server := NewServer(FakeTxQuerier{}, zaptest.NewLogger(t))
+		_, err := server.GetProjectOperation(systemContext(), &GetProjectOperationRequest{ProjectId: "test", Id: "invalid"})
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Fatalf("expected a grpc error, got: %v", err)
+		}
+		if st.Code() != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, st.Code())
 		}
+	})
+	t.Run("should fail when the operation does not exist", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		querier.findOperationError = pgx.ErrNoRows
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.GetProjectOperation(systemContext(), &GetProjectOperationRequest{ProjectId: "test", Id: uuid.NewString()})
 		st, ok := status.FromError(err)
 		if !ok {
-			t.Errorf("expected a grpc error, got: %s", err.Error())
+			t.Fatalf("expected a grpc error, got: %v", err)
 		}
 		if st.Code() != codes.NotFound {
 			t.Errorf("expected: %s, got: %s", codes.NotFound, st.Code())
 		}
 	})
-	t.Run("should successfully return a project", func(t *testing.T) {
+	t.Run("should return the operation", func(t *testing.T) {
 		querier := FakeTxQuerier{}
-		querier.project = store.Project{
-			ID:               "test",
-			BillingAccountID: "billing-account-id",
+		querier.operation = store.Operation{
+			Uid:          uuid.New(),
+			ProjectID:    "test",
+			Type:         store.OperationTypeDeleteProject,
+			Status:       store.OperationStatusFailed,
+			ErrorMessage: "final bill failed",
 		}
 		server := NewServer(querier, zaptest.NewLogger(t))
-		project, err := server.GetProject(context.Background(), &GetProjectRequest{
-			Id: "test",
-		})
+		operation, err := server.GetProjectOperation(systemContext(), &GetProjectOperationRequest{ProjectId: "test", Id: querier.operation.Uid.String()})
 		if err != nil {
-			t.Errorf("expected no error, got: %s", err.Error())
+			t.Fatalf("expected no error, got: %s", err.Error())
 		}
-		if project.Id != "test" {
-			t.Errorf("expected: %s, got: %s", "test", project.Id)
+		if operation.Status != "failed" || operation.Type != "delete_project" || operation.ErrorMessage != "final bill failed" {
+			t.Errorf("unexpected operation: %v", operation)
 		}
-		if project.BillingAccountId != "billing-account-id" {
-			t.Errorf("expected: %s, got: %s", "billing-account-id", project.BillingAccountId)
+		if operation.EndTime != nil {
+			t.Errorf("expected no end time, got: %v", operation.EndTime)
 		}
 	})
 }
 
-func Test_ListProjects(t *testing.T) {
-	t.Run("should return an error when BeginTx returns an error", func(t *testing.T) {
+func Test_GetProjectCurrentSpend(t *testing.T) {
+	t.Run("should fail when the project does not exist", func(t *testing.T) {
 		querier := FakeTxQuerier{}
-		querier.txErr = errors.New("failure to begin transaction")
+		querier.findProjectByIdError = pgx.ErrNoRows
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.ListProjects(context.Background(), &ListProjectsRequest{})
-		if err == nil {
-			t.Errorf("expected error, got nil")
-		}
+		_, err := server.GetProjectCurrentSpend(systemContext(), &GetProjectCurrentSpendRequest{Id: "test"})
 		st, ok := status.FromError(err)
-		if ok {
-			t.Errorf("expected a non grpc error, got: %s", err.Error())
-		}
-		if st.Code() != codes.Unknown {
-			t.Errorf("expected: %s, got: %s", codes.Unknown, st.Code())
+		if !ok {
+			t.Fatalf("expected a grpc error, got: %v", err)
 		}
-		if err.Error() != "failure to begin transaction" {
-			t.Errorf("expected: %s, got: %s", "failure to begin transaction", err.Error())
+		if st.Code() != codes.NotFound {
+			t.Errorf("expected: %s, got: %s", codes.NotFound, st.Code())
 		}
 	})
-	t.Run("should fail when ListProjects query returns an error", func(t *testing.T) {
+	t.Run("should fail when the project has no spend", func(t *testing.T) {
 		querier := FakeTxQuerier{}
-		querier.listProjectsError = errors.New("failure to list projects")
+		querier.project = store.Project{ID: "test"}
+		querier.currentSpendError = pgx.ErrNoRows
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.ListProjects(context.Background(), &ListProjectsRequest{})
-		if err == nil {
-			t.Errorf("expected error, got nil")
+		_, err := server.GetProjectCurrentSpend(systemContext(), &GetProjectCurrentSpendRequest{Id: "test"})
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Fatalf("expected a grpc error, got: %v", err)
 		}
+		if st.Code() != codes.NotFound {
+			t.Errorf("expected: %s, got: %s", codes.NotFound, st.Code())
+		}
+	})
+	t.Run("should fail when the spend query fails", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		querier.project = store.Project{ID: "test"}
+		querier.currentSpendError = errors.New("failure to query spend")
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.GetProjectCurrentSpend(systemContext(), &GetProjectCurrentSpendRequest{Id: "test"})
 		st, ok := status.FromError(err)
 		if !ok {
-			t.Errorf("expected a grpc error, got: %s", err.Error())
+			t.Fatalf("expected a grpc error, got: %v", err)
 		}
 		if st.Code() != codes.Internal {
 			t.Errorf("expected: %s, got: %s", codes.Internal, st.Code())
 		}
 	})
-	t.Run("should successfully return a List of Projects", func(t *testing.T) {
+	t.Run("should return the spend broken down by order and infrastructure type", func(t *testing.T) {
 		querier := FakeTxQuerier{}
-		querier.listProjects = []store.Project{
-			{
-				ID:               "test-1",
-				BillingAccountID: "billing-account-id",
-				CreateTime:       time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
-			},
-			{
-				ID:               "test-2",
-				BillingAccountID: "billing-account-id",
-				CreateTime:       time.Date(2020, time.January, 1, 0, 0, 0, 1, time.UTC),
-			},
+		querier.project = store.Project{ID: "test", BillingAccountID: "billing-account-id"}
+		querier.currentSpend = store.ProjectSpend{
+			ProjectID:        "test",
+			BillingAccountID: "billing-account-id",
+			Spend:            *apd.New(3050, -2),
+		}
+		querier.orderSpend = []store.ListOrderSpendForProjectSpendRow{
+			{OrderID: "order-1", InfraType: store.InfrastructureTypeShared, Spend: *apd.New(1000, -2)},
+			{OrderID: "order-2", InfraType: store.InfrastructureTypeDedicated, Spend: *apd.New(2000, -2)},
+			{OrderID: "order-3", InfraType: store.InfrastructureTypeShared, Spend: *apd.New(50, -2)},
 		}
 		server := NewServer(querier, zaptest.NewLogger(t))
-		projects, err := server.ListProjects(context.Background(), &ListProjectsRequest{})
+		res, err := server.GetProjectCurrentSpend(systemContext(), &GetProjectCurrentSpendRequest{Id: "test"})
 		if err != nil {
-			t.Errorf("expected no error, got: %s", err.Error())
+			t.Fatalf("expected no error, got: %s", err.Error())
 		}
-		if len(projects.Projects) != 2 {
-			t.Errorf("expected: %d, got: %d", 2, len(projects.Projects))
+		if res.Spend != "30.50" || res.BillingAccountId != "billing-account-id" {
+			t.Errorf("unexpected spend: %v", res)
 		}
-		if projects.Projects[0].Id != "test-1" {
-			t.Errorf("expected: %s, got: %s", "test-1", projects.Projects[0].Id)
+		if len(res.OrderSpend) != 3 || res.OrderSpend[1].Spend != "20.00" {
+			t.Errorf("unexpected order spend: %v", res.OrderSpend)
 		}
-		if projects.Projects[0].BillingAccountId != "billing-account-id" {
-			t.Errorf("expected: %s, got: %s", "billing-account-id", projects.Projects[0].BillingAccountId)
+		if len(res.InfraTypeSpend) != 2 {
+			t.Fatalf("expected 2 infrastructure types, got: %v", res.InfraTypeSpend)
 		}
-		if projects.Projects[1].Id != "test-2" {
-			t.Errorf("expected: %s, got: %s", "test-2", projects.Projects[1].Id)
+		if res.InfraTypeSpend[0].InfraType != "dedicated" || res.InfraTypeSpend[0].Spend != "20.00" {
+			t.Errorf("unexpected infrastructure type spend: %v", res.InfraTypeSpend[0])
 		}
-		if projects.Projects[1].BillingAccountId != "billing-account-id" {
-			t.Errorf("expected: %s, got: %s", "billing-account-id", projects.Projects[1].BillingAccountId)
+		if res.InfraTypeSpend[1].InfraType != "shared" || res.InfraTypeSpend[1].Spend != "10.50" {
+			t.Errorf("unexpected infrastructure type spend: %v", res.InfraTypeSpend[1])
 		}
 	})
 }
 
-func Test_UpdateProject(t *testing.T) {
-	t.Run("should fail when an invalid project uid is passed in the request", func(t *testing.T) {
-		querier := FakeTxQuerier{}
-		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.UpdateProject(context.Background(), &UpdateProjectRequest{
+func Test_GetProjectSpendHistory(t *testing.T) {
+	t.Run("should fail when the time range is empty", func(t *testing.T) {
+		now := time.Now()
+		server := NewServer(FakeTxQuerier{}, zaptest.NewLogger(t))
+		_, err := server.GetProjectSpendHistory(systemContext(), &GetProjectSpendHistoryRequest{
+			Id:        "test",
+			StartTime: timestamppb.New(now),
+			EndTime:   timestamppb.New(now),
+		})
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Fatalf("expected a grpc error, got: %v", err)
+		}
+		if st.Code() != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, st.Code())
+		}
+	})
+	t.Run("should fail when the project does not exist", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		querier.findProjectByIdError = pgx.ErrNoRows
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.GetProjectSpendHistory(systemContext(), &GetProjectSpendHistoryRequest{Id: "test"})
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Fatalf("expected a grpc error, got: %v", err)
+		}
+		if st.Code() != codes.NotFound {
+			t.Errorf("expected: %s, got: %s", codes.NotFound, st.Code())
+		}
+	})
+	t.Run("should return a page of spend and a token for the next one", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		querier.project = store.Project{ID: "test"}
+		for i := 0; i < pagination.MinPageSize+1; i++ {
+			querier.spendHistory = append(querier.spendHistory, store.ProjectSpend{ProjectID: "test", Spend: *apd.New(int64(i), 0)})
+		}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		res, err := server.GetProjectSpendHistory(systemContext(), &GetProjectSpendHistoryRequest{Id: "test"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %s", err.Error())
+		}
+		if len(res.ProjectSpendHistory) != pagination.MinPageSize {
+			t.Errorf("expected: %d, got: %d", pagination.MinPageSize, len(res.ProjectSpendHistory))
+		}
+		if res.ProjectSpendHistory[1].Spend != "1" {
+			t.Errorf("expected: %s, got: %s", "1", res.ProjectSpendHistory[1].Spend)
+		}
+		if res.NextPageToken == "" {
+			t.Errorf("expected a next page token")
+		}
+	})
+}
+
+func Test_GetProjectSpendByLabel(t *testing.T) {
+	t.Run("should fail when the label key is invalid", func(t *testing.T) {
+		server := NewServer(FakeTxQuerier{}, zaptest.NewLogger(t))
+		_, err := server.GetProjectSpendByLabel(systemContext(), &GetProjectSpendByLabelRequest{Id: "test", LabelKey: "Team"})
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Fatalf("expected a grpc error, got: %v", err)
+		}
+		if st.Code() != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, st.Code())
+		}
+	})
+	t.Run("should fail when the project does not exist", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		querier.findProjectByIdError = pgx.ErrNoRows
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.GetProjectSpendByLabel(systemContext(), &GetProjectSpendByLabelRequest{Id: "test", LabelKey: "team"})
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Fatalf("expected a grpc error, got: %v", err)
+		}
+		if st.Code() != codes.NotFound {
+			t.Errorf("expected: %s, got: %s", codes.NotFound, st.Code())
+		}
+	})
+	t.Run("should return the spend by label value", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		querier.project = store.Project{ID: "test"}
+		querier.spendByLabel = []store.ListProjectSpendByLabelRow{
+			{LabelValue: "", Spend: *apd.New(5, -1)},
+			{LabelValue: "billing", Spend: *apd.New(1234, -2)},
+		}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		res, err := server.GetProjectSpendByLabel(systemContext(), &GetProjectSpendByLabelRequest{Id: "test", LabelKey: "team"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %s", err.Error())
+		}
+		if res.LabelKey != "team" || len(res.Spend) != 2 {
+			t.Fatalf("unexpected response: %v", res)
+		}
+		if res.Spend[1].LabelValue != "billing" || res.Spend[1].Spend != "12.34" {
+			t.Errorf("unexpected spend: %v", res.Spend[1])
+		}
+	})
+}
+
+func Test_UndeleteProject(t *testing.T) {
+	deleted := store.Project{
+		ID:               "test",
+		BillingAccountID: "billing-account-id",
+		DeleteTime:       sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true},
+		PurgeTime:        sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+	}
+
+	t.Run("should fail when the project id is invalid", func(t *testing.T) {
+		server := NewServer(FakeTxQuerier{}, zaptest.NewLogger(t))
+		_, err := server.UndeleteProject(systemContext(), &UndeleteProjectRequest{Id: "invalid-uid^&*"})
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Fatalf("expected a grpc error, got: %v", err)
+		}
+		if st.Code() != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, st.Code())
+		}
+	})
+	t.Run("should fail when the project does not exist", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		querier.selectError = pgx.ErrNoRows
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.UndeleteProject(systemContext(), &UndeleteProjectRequest{Id: "test"})
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Fatalf("expected a grpc error, got: %v", err)
+		}
+		if st.Code() != codes.NotFound {
+			t.Errorf("expected: %s, got: %s", codes.NotFound, st.Code())
+		}
+	})
+	t.Run("should fail when the project has not been deleted", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		querier.selectProjectForUpdate = store.Project{ID: "test"}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.UndeleteProject(systemContext(), &UndeleteProjectRequest{Id: "test"})
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Fatalf("expected a grpc error, got: %v", err)
+		}
+		if st.Code() != codes.FailedPrecondition {
+			t.Errorf("expected: %s, got: %s", codes.FailedPrecondition, st.Code())
+		}
+	})
+	t.Run("should fail when the retention window has passed", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		querier.selectProjectForUpdate = deleted
+		querier.selectProjectForUpdate.PurgeTime = sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.UndeleteProject(systemContext(), &UndeleteProjectRequest{Id: "test"})
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Fatalf("expected a grpc error, got: %v", err)
+		}
+		if st.Code() != codes.FailedPrecondition {
+			t.Errorf("expected: %s, got: %s", codes.FailedPrecondition, st.Code())
+		}
+	})
+	t.Run("should fail when UndeleteProject query returns an error", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		querier.selectProjectForUpdate = deleted
+		querier.undeleteProjectError = errors.New("failure to undelete project")
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.UndeleteProject(systemContext(), &UndeleteProjectRequest{Id: "test"})
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Fatalf("expected a grpc error, got: %v", err)
+		}
+		if st.Code() != codes.Internal {
+			t.Errorf("expected: %s, got: %s", codes.Internal, st.Code())
+		}
+	})
+	t.Run("should successfully undelete a project", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		querier.selectProjectForUpdate = deleted
+		querier.undeleteProject = store.Project{ID: "test", BillingAccountID: "billing-account-id"}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		project, err := server.UndeleteProject(systemContext(), &UndeleteProjectRequest{Id: "test"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %s", err.Error())
+		}
+		if project.Id != "test" {
+			t.Errorf("expected: %s, got: %s", "test", project.Id)
+		}
+		if project.DeleteTime != nil || project.PurgeTime != nil {
+			t.Errorf("expected delete and purge time to be cleared, got: %v, %v", project.DeleteTime, project.PurgeTime)
+		}
+	})
+}
+
+func Test_GetProject(t *testing.T) {
+	t.Run("should fail when the name passed in the request is empty", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.GetProject(systemContext(), &GetProjectRequest{
+			Id: "",
+		})
+		if err == nil {
+			t.Errorf("expected error, got nil")
+		}
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Fatalf("expected a grpc error, got: %s", err.Error())
+		}
+		if st.Code() != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, st.Code())
+		}
+	})
+	t.Run("should fail when the BeginTx query returns an error", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		querier.txErr = errors.New("failure to begin transaction")
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.GetProject(systemContext(), &GetProjectRequest{
+			Id: "test",
+		})
+		if err == nil {
+			t.Errorf("expected error, got nil")
+		}
+		st, ok := status.FromError(err)
+		if ok {
+			t.Errorf("expected a non grpc error, got: %s", err.Error())
+		}
+		if st.Code() != codes.Unknown {
+			t.Errorf("expected: %s, got: %s", codes.Unknown, st.Code())
+		}
+		if err.Error() != "failure to begin transaction" {
+			t.Errorf("expected: %s, got: %s", "failure to begin transaction", err.Error())
+		}
+	})
+	t.Run("should fail when the FindProjectById query returns an error", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		querier.findProjectByIdError = errors.New("failure to find project")
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.GetProject(systemContext(), &GetProjectRequest{
+			Id: "test",
+		})
+		if err == nil {
+			t.Errorf("expected error, got nil")
+		}
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Fatalf("expected a grpc error, got: %s", err.Error())
+		}
+		if st.Code() != codes.Internal {
+			t.Errorf("expected: %s, got: %s", codes.Internal, st.Code())
+		}
+	})
+	t.Run("should fail when the caller is not a member of the billing account of the project", func(t *testing.T) {
+		querier := FakeTxQuerier{project: store.Project{ID: "test", BillingAccountID: "billing-account-id"}}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		ctx := principal.NewContext(context.Background(), &principal.Principal{ID: "user-1"})
+		_, err := server.GetProject(ctx, &GetProjectRequest{Id: "test"})
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("expected: %s, got: %s", codes.NotFound, status.Code(err))
+		}
+	})
+	t.Run("should fail when the role of the caller can't get projects", func(t *testing.T) {
+		querier := FakeTxQuerier{
+			project: store.Project{ID: "test", BillingAccountID: "billing-account-id"},
+			members: []store.BillingAccountMember{
+				{BillingAccountID: "billing-account-id", PrincipalID: "user-1", Role: store.BillingAccountRoleBillingViewer},
+			},
+		}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		ctx := principal.NewContext(context.Background(), &principal.Principal{ID: "user-1"})
+		_, err := server.GetProject(ctx, &GetProjectRequest{Id: "test"})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("expected: %s, got: %s", codes.PermissionDenied, status.Code(err))
+		}
+	})
+	t.Run("should fail when the FindProjectById query returns no rows", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		querier.findProjectByIdError = pgx.ErrNoRows
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.GetProject(systemContext(), &GetProjectRequest{
+			Id: "test",
+		})
+		if err == nil {
+			t.Errorf("expected error, got nil")
+		}
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Errorf("expected a grpc error, got: %s", err.Error())
+		}
+		if st.Code() != codes.NotFound {
+			t.Errorf("expected: %s, got: %s", codes.NotFound, st.Code())
+		}
+	})
+	t.Run("should successfully return a project", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		querier.project = store.Project{
+			ID:               "test",
+			BillingAccountID: "billing-account-id",
+		}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		project, err := server.GetProject(systemContext(), &GetProjectRequest{
+			Id: "test",
+		})
+		if err != nil {
+			t.Errorf("expected no error, got: %s", err.Error())
+		}
+		if project.Id != "test" {
+			t.Errorf("expected: %s, got: %s", "test", project.Id)
+		}
+		if project.BillingAccountId != "billing-account-id" {
+			t.Errorf("expected: %s, got: %s", "billing-account-id", project.BillingAccountId)
+		}
+	})
+}
+
+func Test_ListProjects(t *testing.T) {
+	t.Run("should return an error when BeginTx returns an error", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		querier.txErr = errors.New("failure to begin transaction")
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.ListProjects(systemContext(), &ListProjectsRequest{})
+		if err == nil {
+			t.Errorf("expected error, got nil")
+		}
+		st, ok := status.FromError(err)
+		if ok {
+			t.Errorf("expected a non grpc error, got: %s", err.Error())
+		}
+		if st.Code() != codes.Unknown {
+			t.Errorf("expected: %s, got: %s", codes.Unknown, st.Code())
+		}
+		if err.Error() != "failure to begin transaction" {
+			t.Errorf("expected: %s, got: %s", "failure to begin transaction", err.Error())
+		}
+	})
+	t.Run("should fail when ListProjects query returns an error", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		querier.listProjectsError = errors.New("failure to list projects")
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.ListProjects(systemContext(), &ListProjectsRequest{})
+		if err == nil {
+			t.Errorf("expected error, got nil")
+		}
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Errorf("expected a grpc error, got: %s", err.Error())
+		}
+		if st.Code() != codes.Internal {
+			t.Errorf("expected: %s, got: %s", codes.Internal, st.Code())
+		}
+	})
+	t.Run("should successfully return a List of Projects", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		querier.listProjects = []store.Project{
+			{
+				ID:               "test-1",
+				BillingAccountID: "billing-account-id",
+				CreateTime:       time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			},
+			{
+				ID:               "test-2",
+				BillingAccountID: "billing-account-id",
+				CreateTime:       time.Date(2020, time.January, 1, 0, 0, 0, 1, time.UTC),
+			},
+		}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		projects, err := server.ListProjects(systemContext(), &ListProjectsRequest{})
+		if err != nil {
+			t.Errorf("expected no error, got: %s", err.Error())
+		}
+		if len(projects.Projects) != 2 {
+			t.Errorf("expected: %d, got: %d", 2, len(projects.Projects))
+		}
+		if projects.Projects[0].Id != "test-1" {
+			t.Errorf("expected: %s, got: %s", "test-1", projects.Projects[0].Id)
+		}
+		if projects.Projects[0].BillingAccountId != "billing-account-id" {
+			t.Errorf("expected: %s, got: %s", "billing-account-id", projects.Projects[0].BillingAccountId)
+		}
+		if projects.Projects[1].Id != "test-2" {
+			t.Errorf("expected: %s, got: %s", "test-2", projects.Projects[1].Id)
+		}
+		if projects.Projects[1].BillingAccountId != "billing-account-id" {
+			t.Errorf("expected: %s, got: %s", "billing-account-id", projects.Projects[1].BillingAccountId)
+		}
+		if projects.NextPageToken != "" {
+			t.Errorf("expected no next page token, got: %s", projects.NextPageToken)
+		}
+	})
+	t.Run("should fail when the page token is invalid", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.ListProjects(systemContext(), &ListProjectsRequest{PageToken: "invalid"})
+		if err == nil {
+			t.Errorf("expected error, got nil")
+		}
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Errorf("expected a grpc error, got: %s", err.Error())
+		}
+		if st.Code() != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, st.Code())
+		}
+	})
+	t.Run("should return a next page token that continues after the last project", func(t *testing.T) {
+		var params store.ListProjectsParams
+		querier := FakeTxQuerier{listProjectsParams: &params}
+		for i := 0; i < 11; i++ {
+			querier.listProjects = append(querier.listProjects, store.Project{
+				ID:               fmt.Sprintf("test-%d", i),
+				BillingAccountID: "billing-account-id",
+				CreateTime:       time.Date(2020, time.January, 1, 0, 0, i, 0, time.UTC),
+			})
+		}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		projects, err := server.ListProjects(systemContext(), &ListProjectsRequest{PageSize: 10})
+		if err != nil {
+			t.Fatalf("expected no error, got: %s", err.Error())
+		}
+		if params.Limit != 11 {
+			t.Errorf("expected limit: %d, got: %d", 11, params.Limit)
+		}
+		if len(projects.Projects) != 10 {
+			t.Errorf("expected: %d, got: %d", 10, len(projects.Projects))
+		}
+		if projects.NextPageToken == "" {
+			t.Fatal("expected a next page token, got none")
+		}
+
+		querier.listProjects = nil
+		_, err = server.ListProjects(systemContext(), &ListProjectsRequest{PageSize: 10, PageToken: projects.NextPageToken})
+		if err != nil {
+			t.Fatalf("expected no error, got: %s", err.Error())
+		}
+		if params.Cursor.ID != "test-9" {
+			t.Errorf("expected: %s, got: %s", "test-9", params.Cursor.ID)
+		}
+		if !params.Cursor.CreateTime.Equal(time.Date(2020, time.January, 1, 0, 0, 9, 0, time.UTC)) {
+			t.Errorf("expected: %v, got: %v", time.Date(2020, time.January, 1, 0, 0, 9, 0, time.UTC), params.Cursor.CreateTime)
+		}
+	})
+	t.Run("should scope the listing to the billing accounts of the caller", func(t *testing.T) {
+		var params store.ListProjectsParams
+		querier := FakeTxQuerier{
+			listProjectsParams: &params,
+			members: []store.BillingAccountMember{
+				{BillingAccountID: "billing-account-id", PrincipalID: "user-1", Role: store.BillingAccountRoleViewer},
+				{BillingAccountID: "other-account-id", PrincipalID: "user-1", Role: store.BillingAccountRoleBillingViewer},
+			},
+		}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		ctx := principal.NewContext(context.Background(), &principal.Principal{ID: "user-1"})
+		_, err := server.ListProjects(ctx, &ListProjectsRequest{})
+		if err != nil {
+			t.Fatalf("expected no error, got: %s", err.Error())
+		}
+		if params.AllBillingAccounts {
+			t.Errorf("expected listing to be scoped")
+		}
+		if len(params.BillingAccountIds) != 1 || params.BillingAccountIds[0] != "billing-account-id" {
+			t.Errorf("expected: %v, got: %v", []string{"billing-account-id"}, params.BillingAccountIds)
+		}
+	})
+	t.Run("should hide deleted projects unless show_deleted is set", func(t *testing.T) {
+		var params store.ListProjectsParams
+		querier := FakeTxQuerier{listProjectsParams: &params}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.ListProjects(systemContext(), &ListProjectsRequest{})
+		if err != nil {
+			t.Fatalf("expected no error, got: %s", err.Error())
+		}
+		if params.ShowDeleted {
+			t.Errorf("expected deleted projects to be hidden")
+		}
+		_, err = server.ListProjects(systemContext(), &ListProjectsRequest{ShowDeleted: true})
+		if err != nil {
+			t.Fatalf("expected no error, got: %s", err.Error())
+		}
+		if !params.ShowDeleted {
+			t.Errorf("expected deleted projects to be shown")
+		}
+	})
+	t.Run("should fail when the filter uses an unknown field", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.ListProjects(systemContext(), &ListProjectsRequest{Filter: `secret = "abc"`})
+		if err == nil {
+			t.Errorf("expected error, got nil")
+		}
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Errorf("expected a grpc error, got: %s", err.Error())
+		}
+		if st.Code() != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, st.Code())
+		}
+	})
+	t.Run("should fail when ordering by an unknown field", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.ListProjects(systemContext(), &ListProjectsRequest{OrderBy: "secret desc"})
+		if err == nil {
+			t.Errorf("expected error, got nil")
+		}
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Errorf("expected a grpc error, got: %s", err.Error())
+		}
+		if st.Code() != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, st.Code())
+		}
+	})
+	t.Run("should page by offset when ordering by other fields", func(t *testing.T) {
+		var params store.ListProjectsParams
+		querier := FakeTxQuerier{listProjectsParams: &params}
+		for i := 0; i < 11; i++ {
+			querier.listProjects = append(querier.listProjects, store.Project{ID: fmt.Sprintf("test-%d", i)})
+		}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		req := &ListProjectsRequest{
+			Filter:  `billing_account_id = "billing-account-id"`,
+			OrderBy: "create_time desc",
+		}
+		projects, err := server.ListProjects(systemContext(), req)
+		if err != nil {
+			t.Fatalf("expected no error, got: %s", err.Error())
+		}
+		if params.Filter == nil || len(params.OrderBy) != 1 {
+			t.Errorf("expected filter and order to be passed to the query, got: %+v", params)
+		}
+
+		req.PageToken = projects.NextPageToken
+		_, err = server.ListProjects(systemContext(), req)
+		if err != nil {
+			t.Fatalf("expected no error, got: %s", err.Error())
+		}
+		if params.Cursor.Offset != 10 {
+			t.Errorf("expected offset: %d, got: %d", 10, params.Cursor.Offset)
+		}
+
+		// the token can't be reused once the filter changes
+		req.Filter = `billing_account_id = "other"`
+		_, err = server.ListProjects(systemContext(), req)
+		st, _ := status.FromError(err)
+		if st.Code() != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, st.Code())
+		}
+	})
+}
+
+func Test_ListOrders(t *testing.T) {
+	t.Run("should fail when the project id is invalid", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.ListOrders(systemContext(), &ListOrdersRequest{ProjectId: "invalid-uid^&*"})
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Errorf("expected a grpc error, got: %v", err)
+		}
+		if st.Code() != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, st.Code())
+		}
+	})
+	t.Run("should fail when filtering by an unknown status", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.ListOrders(systemContext(), &ListOrdersRequest{ProjectId: "project-1", Filter: "status = deleted"})
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Errorf("expected a grpc error, got: %v", err)
+		}
+		if st.Code() != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, st.Code())
+		}
+	})
+	t.Run("should list the orders of a project", func(t *testing.T) {
+		var params store.ListOrdersParams
+		querier := FakeTxQuerier{listOrdersParams: &params}
+		querier.listOrders = []store.Order{
+			{
+				ID:               "order-1",
+				ProjectID:        "project-1",
+				BillingAccountID: "billing-account-id",
+				InfraType:        store.InfrastructureTypeDedicated,
+				Status:           store.OrderStatusActive,
+				Quantity:         2,
+				PriceHr:          1.5,
+				CreateTime:       time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			},
+		}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		res, err := server.ListOrders(systemContext(), &ListOrdersRequest{
+			ProjectId: "project-1",
+			Filter:    "status = active AND infra_type = dedicated",
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got: %s", err.Error())
+		}
+		if params.ProjectID != "project-1" {
+			t.Errorf("expected: %s, got: %s", "project-1", params.ProjectID)
+		}
+		if len(res.Orders) != 1 {
+			t.Fatalf("expected: %d, got: %d", 1, len(res.Orders))
+		}
+		if res.Orders[0].Status != "active" || res.Orders[0].InfraType != "dedicated" || res.Orders[0].Quantity != 2 {
+			t.Errorf("unexpected order: %v", res.Orders[0])
+		}
+		if res.NextPageToken != "" {
+			t.Errorf("expected no next page token, got: %s", res.NextPageToken)
+		}
+	})
+}
+
+func Test_UpdateProject(t *testing.T) {
+	t.Run("should fail when an invalid project uid is passed in the request", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.UpdateProject(systemContext(), &UpdateProjectRequest{
 			Project: &Project{
 				Id: "invalid-uid^&*",
 			},
@@ -704,11 +1730,24 @@ func Test_UpdateProject(t *testing.T) {
 			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, st.Code())
 		}
 	})
+	t.Run("should fail when the project does not exist", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		querier.selectError = pgx.ErrNoRows
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.UpdateProject(systemContext(), &UpdateProjectRequest{
+			Project: &Project{
+				Id: "f863f339-9f9c-4863-aa02-73e059060b9a",
+			},
+		})
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("expected: %s, got: %s", codes.NotFound, status.Code(err))
+		}
+	})
 	t.Run("should fail when the BeginTx query returns an error", func(t *testing.T) {
 		querier := FakeTxQuerier{}
 		querier.txErr = fmt.Errorf("test error")
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.UpdateProject(context.Background(), &UpdateProjectRequest{
+		_, err := server.UpdateProject(systemContext(), &UpdateProjectRequest{
 			Project: &Project{
 				Id: "f863f339-9f9c-4863-aa02-73e059060b9a",
 			},
@@ -731,7 +1770,7 @@ func Test_UpdateProject(t *testing.T) {
 		querier := FakeTxQuerier{}
 		querier.selectError = fmt.Errorf("test error")
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.UpdateProject(context.Background(), &UpdateProjectRequest{
+		_, err := server.UpdateProject(systemContext(), &UpdateProjectRequest{
 			Project: &Project{
 				Id: "test-id",
 			},
@@ -754,13 +1793,13 @@ func Test_UpdateProject(t *testing.T) {
 		querier := FakeTxQuerier{}
 		querier.billingAccountError = fmt.Errorf("test error")
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.UpdateProject(context.Background(), &UpdateProjectRequest{
+		_, err := server.UpdateProject(systemContext(), &UpdateProjectRequest{
 			Project: &Project{
 				Id:               "test-id",
 				BillingAccountId: "billing-account-id",
 			},
 			UpdateMask: &fieldmaskpb.FieldMask{
-				Paths: []string{"account_id"},
+				Paths: []string{"billing_account_id"},
 			},
 		})
 		if err == nil {
@@ -778,13 +1817,13 @@ func Test_UpdateProject(t *testing.T) {
 		querier := FakeTxQuerier{}
 		querier.billingAccountError = pgx.ErrNoRows
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.UpdateProject(context.Background(), &UpdateProjectRequest{
+		_, err := server.UpdateProject(systemContext(), &UpdateProjectRequest{
 			Project: &Project{
 				Id:               "test-id",
 				BillingAccountId: "billing-account-id",
 			},
 			UpdateMask: &fieldmaskpb.FieldMask{
-				Paths: []string{"account_id"},
+				Paths: []string{"billing_account_id"},
 			},
 		})
 		if err == nil {
@@ -805,13 +1844,13 @@ func Test_UpdateProject(t *testing.T) {
 			DemandEnabled: false,
 		}
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.UpdateProject(context.Background(), &UpdateProjectRequest{
+		_, err := server.UpdateProject(systemContext(), &UpdateProjectRequest{
 			Project: &Project{
 				Id:               "test-id",
 				BillingAccountId: "billing-account-id",
 			},
 			UpdateMask: &fieldmaskpb.FieldMask{
-				Paths: []string{"account_id"},
+				Paths: []string{"billing_account_id"},
 			},
 		})
 		if err == nil {
@@ -833,13 +1872,13 @@ func Test_UpdateProject(t *testing.T) {
 		}
 		querier.updateProjectError = fmt.Errorf("update project error")
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.UpdateProject(context.Background(), &UpdateProjectRequest{
+		_, err := server.UpdateProject(systemContext(), &UpdateProjectRequest{
 			Project: &Project{
 				Id:               "test",
 				BillingAccountId: "billing-account-id",
 			},
 			UpdateMask: &fieldmaskpb.FieldMask{
-				Paths: []string{"account_id", "name"},
+				Paths: []string{"billing_account_id"},
 			},
 		})
 		if err == nil {
@@ -864,13 +1903,13 @@ func Test_UpdateProject(t *testing.T) {
 			err: fmt.Errorf("commit error"),
 		}
 		server := NewServer(querier, zaptest.NewLogger(t))
-		_, err := server.UpdateProject(context.Background(), &UpdateProjectRequest{
+		_, err := server.UpdateProject(systemContext(), &UpdateProjectRequest{
 			Project: &Project{
 				Id:               "test",
 				BillingAccountId: "f863f339-9f9c-4863-aa02-73e059060b9a",
 			},
 			UpdateMask: &fieldmaskpb.FieldMask{
-				Paths: []string{"account_id", "name"},
+				Paths: []string{"billing_account_id"},
 			},
 		})
 		if err == nil {
@@ -895,13 +1934,13 @@ func Test_UpdateProject(t *testing.T) {
 			BillingAccountID: "billing-account-id-update",
 		}
 		server := NewServer(querier, zaptest.NewLogger(t))
-		updatedProject, err := server.UpdateProject(context.Background(), &UpdateProjectRequest{
+		updatedProject, err := server.UpdateProject(systemContext(), &UpdateProjectRequest{
 			Project: &Project{
 				Id:               "test",
 				BillingAccountId: "billing-account-id-update",
 			},
 			UpdateMask: &fieldmaskpb.FieldMask{
-				Paths: []string{"account_id", "name"},
+				Paths: []string{"billing_account_id"},
 			},
 		})
 		if err != nil {
@@ -917,4 +1956,155 @@ func Test_UpdateProject(t *testing.T) {
 			t.Errorf("expected project billing account id to be billing-account-id-update, got: %s", updatedProject.BillingAccountId)
 		}
 	})
+	t.Run("should fail when the update mask has an unknown field", func(t *testing.T) {
+		server := NewServer(FakeTxQuerier{}, zaptest.NewLogger(t))
+		_, err := server.UpdateProject(systemContext(), &UpdateProjectRequest{
+			Project: &Project{
+				Id:               "test",
+				BillingAccountId: "billing-account-id",
+			},
+			UpdateMask: &fieldmaskpb.FieldMask{
+				Paths: []string{"account_id"},
+			},
+		})
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Fatalf("expected a grpc error, got: %v", err)
+		}
+		if st.Code() != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, st.Code())
+		}
+	})
+	t.Run("should fail when the update mask has a field that can not be updated", func(t *testing.T) {
+		server := NewServer(FakeTxQuerier{}, zaptest.NewLogger(t))
+		_, err := server.UpdateProject(systemContext(), &UpdateProjectRequest{
+			Project: &Project{
+				Id: "test",
+			},
+			UpdateMask: &fieldmaskpb.FieldMask{
+				Paths: []string{"id"},
+			},
+		})
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Fatalf("expected a grpc error, got: %v", err)
+		}
+		if st.Code() != codes.InvalidArgument {
+			t.Errorf("expected: %s, got: %s", codes.InvalidArgument, st.Code())
+		}
+	})
+	t.Run("should not update the project when the billing account is unchanged", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		querier.selectProjectForUpdate = store.Project{
+			ID:               "test",
+			BillingAccountID: "billing-account-id",
+		}
+		querier.updateProjectError = fmt.Errorf("update project error")
+		server := NewServer(querier, zaptest.NewLogger(t))
+		updatedProject, err := server.UpdateProject(systemContext(), &UpdateProjectRequest{
+			Project: &Project{
+				Id:               "test",
+				BillingAccountId: "billing-account-id",
+			},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got: %s", err.Error())
+		}
+		if updatedProject.BillingAccountId != "billing-account-id" {
+			t.Errorf("expected: %s, got: %s", "billing-account-id", updatedProject.BillingAccountId)
+		}
+	})
+	t.Run("should fail when the etag is stale", func(t *testing.T) {
+		querier := FakeTxQuerier{}
+		querier.selectProjectForUpdate = store.Project{
+			ID:      "test",
+			Version: 3,
+		}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.UpdateProject(systemContext(), &UpdateProjectRequest{
+			Project: &Project{
+				Id:          "test",
+				DisplayName: "Renamed",
+				Etag:        "2",
+			},
+		})
+		st, ok := status.FromError(err)
+		if !ok {
+			t.Fatalf("expected a grpc error, got: %v", err)
+		}
+		if st.Code() != codes.Aborted {
+			t.Errorf("expected: %s, got: %s", codes.Aborted, st.Code())
+		}
+	})
+	t.Run("should only update the fields in the update mask", func(t *testing.T) {
+		var params store.UpdateProjectParams
+		labels, _ := store.NewLabels(map[string]string{"team": "billing"})
+		querier := FakeTxQuerier{updateProjectParams: &params}
+		querier.selectProjectForUpdate = store.Project{
+			ID:               "test",
+			BillingAccountID: "billing-account-id",
+			DisplayName:      "Test",
+			Description:      "A test project",
+			Labels:           labels,
+			Version:          3,
+		}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.UpdateProject(systemContext(), &UpdateProjectRequest{
+			Project: &Project{
+				Id:          "test",
+				DisplayName: "Renamed",
+				Description: "ignored",
+				Labels:      map[string]string{"team": "platform"},
+				Etag:        "3",
+			},
+			UpdateMask: &fieldmaskpb.FieldMask{
+				Paths: []string{"display_name", "labels"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got: %s", err.Error())
+		}
+		if params.DisplayName != "Renamed" || params.Description != "A test project" || params.BillingAccountID != "billing-account-id" {
+			t.Errorf("unexpected update: %+v", params)
+		}
+		updatedLabels, _ := store.LabelsMap(params.Labels)
+		if updatedLabels["team"] != "platform" {
+			t.Errorf("expected: %s, got: %s", "platform", updatedLabels["team"])
+		}
+	})
+	t.Run("should record the transfer and move the open orders to the new billing account", func(t *testing.T) {
+		var transfer store.CreateProjectTransferParams
+		querier := FakeTxQuerier{projectTransfer: &transfer}
+		querier.billingAccount = store.BillingAccount{
+			DemandEnabled: true,
+		}
+		querier.selectProjectForUpdate = store.Project{
+			ID:               "test",
+			BillingAccountID: "old-billing-account-id",
+		}
+		querier.updateProject = store.Project{
+			ID:               "test",
+			BillingAccountID: "new-billing-account-id",
+		}
+		querier.transferredOrders = []string{"order-1", "order-2"}
+		server := NewServer(querier, zaptest.NewLogger(t))
+		_, err := server.UpdateProject(systemContext(), &UpdateProjectRequest{
+			Project: &Project{
+				Id:               "test",
+				BillingAccountId: "new-billing-account-id",
+			},
+			UpdateMask: &fieldmaskpb.FieldMask{
+				Paths: []string{"billing_account_id"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got: %s", err.Error())
+		}
+		if transfer.ProjectID != "test" || transfer.FromBillingAccountID != "old-billing-account-id" || transfer.ToBillingAccountID != "new-billing-account-id" {
+			t.Errorf("unexpected transfer: %+v", transfer)
+		}
+		if len(transfer.OrderIds) != 2 || transfer.TransferTime.IsZero() {
+			t.Errorf("unexpected transfer: %+v", transfer)
+		}
+	})
 }
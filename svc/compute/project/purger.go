@@ -0,0 +1,102 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"biller/lib/logger"
+	"biller/svc/compute/store"
+
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+// Purger removes projects whose retention window has passed. The project, its orders, leases, order spend and sla
+// credits are archived as a single record and the project spend is copied to project_spend_archive before the rows
+// are deleted, so billing history survives the purge.
+type Purger struct {
+	querier store.TxQuerier
+	log     *zap.Logger
+}
+
+func NewPurger(querier store.TxQuerier, log *zap.Logger) *Purger {
+	return &Purger{
+		querier: querier,
+		log:     log,
+	}
+}
+
+func (p *Purger) Run(ctx context.Context) error {
+	projects, err := p.querier.ListProjectsToPurge(ctx, time.Now())
+	if err != nil {
+		logger.FromContext(ctx, p.log).Error("list projects to purge failed", zap.Error(err))
+		return err
+	}
+
+	// a project that can't be purged is left for the next run, it doesn't hold up the others
+	failed := 0
+	for _, project := range projects {
+		err = p.querier.ExecWithTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}, func(q store.QueryLister) error {
+			return purge(ctx, q, project.ID)
+		})
+		if err != nil {
+			logger.FromContext(ctx, p.log).Error("error purging project", zap.String("projectId", project.ID), zap.Error(err))
+			failed++
+			continue
+		}
+		logger.FromContext(ctx, p.log).Info("purged project", zap.String("projectId", project.ID))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d projects could not be purged", failed, len(projects))
+	}
+	return nil
+}
+
+func purge(ctx context.Context, q store.Querier, projectID string) error {
+	archiveUid, err := q.ArchiveProject(ctx, projectID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("project %s was undeleted before it could be purged", projectID)
+	}
+	if err != nil {
+		return fmt.Errorf("archive project failed: %w", err)
+	}
+	err = q.ArchiveProjectSpend(ctx, store.ArchiveProjectSpendParams{
+		ProjectArchiveUid: archiveUid,
+		ProjectID:         projectID,
+	})
+	if err != nil {
+		return fmt.Errorf("archive project spend failed: %w", err)
+	}
+
+	err = q.PurgeProjectLeases(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("purge project leases failed: %w", err)
+	}
+	err = q.PurgeProjectOrderSpend(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("purge project order spend failed: %w", err)
+	}
+	err = q.PurgeProjectSLACredits(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("purge project sla credits failed: %w", err)
+	}
+	err = q.PurgeProjectOrders(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("purge project orders failed: %w", err)
+	}
+	err = q.PurgeProjectSpend(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("purge project spend failed: %w", err)
+	}
+
+	affected, err := q.PurgeProject(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("purge project failed: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("project %s was undeleted before it could be purged", projectID)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"biller/svc/compute/store"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap/zaptest"
+)
+
+type FakePurgeQuerier struct {
+	store.TxQuerier
+	projects     []store.Project
+	purged       *[]string
+	archiveError error
+	// archiveErrorID is the project that fails to archive, every project does when empty
+	archiveErrorID string
+	purgeInt       int64
+}
+
+func (q FakePurgeQuerier) ListProjectsToPurge(ctx context.Context, purgeTime time.Time) ([]store.Project, error) {
+	return q.projects, nil
+}
+
+func (q FakePurgeQuerier) ExecWithTx(ctx context.Context, _ pgx.TxOptions, fn func(store.QueryLister) error) error {
+	return fn(q)
+}
+
+func (q FakePurgeQuerier) ArchiveProject(ctx context.Context, id string) (uuid.UUID, error) {
+	if q.archiveError != nil && (q.archiveErrorID == "" || q.archiveErrorID == id) {
+		return uuid.UUID{}, q.archiveError
+	}
+	return uuid.New(), nil
+}
+
+func (q FakePurgeQuerier) ArchiveProjectSpend(ctx context.Context, arg store.ArchiveProjectSpendParams) error {
+	if arg.ProjectArchiveUid == (uuid.UUID{}) {
+		return errors.New("project spend archived without its project archive")
+	}
+	return nil
+}
+
+func (q FakePurgeQuerier) PurgeProjectLeases(ctx context.Context, projectID string) error {
+	return nil
+}
+
+func (q FakePurgeQuerier) PurgeProjectOrderSpend(ctx context.Context, projectID string) error {
+	return nil
+}
+
+func (q FakePurgeQuerier) PurgeProjectSLACredits(ctx context.Context, projectID string) error {
+	return nil
+}
+
+func (q FakePurgeQuerier) PurgeProjectOrders(ctx context.Context, projectID string) error {
+	return nil
+}
+
+func (q FakePurgeQuerier) PurgeProjectSpend(ctx context.Context, projectID string) error {
+	return nil
+}
+
+func (q FakePurgeQuerier) PurgeProject(ctx context.Context, id string) (int64, error) {
+	*q.purged = append(*q.purged, id)
+	return q.purgeInt, nil
+}
+
+func TestPurger_Run(t *testing.T) {
+	t.Run("should purge every expired project", func(t *testing.T) {
+		var purged []string
+		querier := FakePurgeQuerier{
+			projects: []store.Project{{ID: "project-1"}, {ID: "project-2"}},
+			purged:   &purged,
+			purgeInt: 1,
+		}
+		err := NewPurger(querier, zaptest.NewLogger(t)).Run(context.Background())
+		if err != nil {
+			t.Fatalf("expected no error, got: %s", err.Error())
+		}
+		if len(purged) != 2 || purged[0] != "project-1" || purged[1] != "project-2" {
+			t.Errorf("expected: %v, got: %v", []string{"project-1", "project-2"}, purged)
+		}
+	})
+	t.Run("should not purge a project that could not be archived", func(t *testing.T) {
+		var purged []string
+		querier := FakePurgeQuerier{
+			projects:     []store.Project{{ID: "project-1"}},
+			purged:       &purged,
+			archiveError: errors.New("failure to archive project"),
+			purgeInt:     1,
+		}
+		err := NewPurger(querier, zaptest.NewLogger(t)).Run(context.Background())
+		if err == nil {
+			t.Errorf("expected error, got nil")
+		}
+		if len(purged) != 0 {
+			t.Errorf("expected no purged projects, got: %v", purged)
+		}
+	})
+	t.Run("should purge the other projects when one can't be archived", func(t *testing.T) {
+		var purged []string
+		querier := FakePurgeQuerier{
+			projects:       []store.Project{{ID: "project-1"}, {ID: "project-2"}},
+			purged:         &purged,
+			archiveError:   errors.New(`duplicate key value violates unique constraint "project_archive_pkey"`),
+			archiveErrorID: "project-1",
+			purgeInt:       1,
+		}
+		err := NewPurger(querier, zaptest.NewLogger(t)).Run(context.Background())
+		if err == nil {
+			t.Errorf("expected error, got nil")
+		}
+		if len(purged) != 1 || purged[0] != "project-2" {
+			t.Errorf("expected: %v, got: %v", []string{"project-2"}, purged)
+		}
+	})
+	t.Run("should fail when the project was undeleted before it was archived", func(t *testing.T) {
+		var purged []string
+		querier := FakePurgeQuerier{
+			projects:     []store.Project{{ID: "project-1"}},
+			purged:       &purged,
+			archiveError: pgx.ErrNoRows,
+		}
+		err := NewPurger(querier, zaptest.NewLogger(t)).Run(context.Background())
+		if err == nil {
+			t.Errorf("expected error, got nil")
+		}
+		if len(purged) != 0 {
+			t.Errorf("expected no purged projects, got: %v", purged)
+		}
+	})
+	t.Run("should fail when the project was undeleted", func(t *testing.T) {
+		var purged []string
+		querier := FakePurgeQuerier{
+			projects: []store.Project{{ID: "project-1"}},
+			purged:   &purged,
+		}
+		err := NewPurger(querier, zaptest.NewLogger(t)).Run(context.Background())
+		if err == nil {
+			t.Errorf("expected error, got nil")
+		}
+	})
+}
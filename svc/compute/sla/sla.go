@@ -0,0 +1,320 @@
+package sla
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"biller/lib/logger"
+	"biller/svc/compute/billingaccount"
+	"biller/svc/compute/store"
+
+	"github.com/cockroachdb/apd/v2"
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+// SLA credits for demand customers
+// When a lease fails the order it belongs to is down until a replacement lease is created. The downtime over a
+// month gives the availability of the order, which is mapped to a credit percentage by the SLA tiers configured
+// for its infrastructure type. Credits are issued against the following billing period and picked up by the biller,
+// what the account is due for that period is its spend less the credit.
+
+var apdContext = apd.Context{
+	MaxExponent: 65,
+	MinExponent: -18,
+	Precision:   65,
+	Rounding:    apd.RoundHalfUp,
+}
+
+type Calculator struct {
+	querier store.TxQuerier
+	log     *zap.Logger
+}
+
+func NewCalculator(querier store.TxQuerier, log *zap.Logger) *Calculator {
+	return &Calculator{
+		querier: querier,
+		log:     log,
+	}
+}
+
+func (c *Calculator) Run(ctx context.Context) error {
+	billingAccounts, err := c.querier.ListAllBillingAccounts(ctx)
+	if err != nil {
+		logger.FromContext(ctx, c.log).Error("list billing accounts failed", zap.Error(err))
+		return err
+	}
+
+	// availability is measured over the previous month and the credit is issued against the current month, the
+	// months of each billing account's time zone so they match the periods it was billed for
+	now := time.Now()
+	for _, group := range billingaccount.GroupByBillingLocation(billingAccounts) {
+		applyStartTime, _ := billingaccount.BillingPeriod(now.In(group.Location))
+		startTime := applyStartTime.AddDate(0, -1, 0)
+
+		err = c.issueCredits(ctx, group.BillingAccounts, startTime, applyStartTime)
+		if err != nil {
+			logger.FromContext(ctx, c.log).Error("error issuing sla credits", zap.String("location", group.Location.String()), zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+type Outage struct {
+	LeaseID            string    `json:"lease_id"`
+	StartTime          time.Time `json:"start_time"`
+	EndTime            time.Time `json:"end_time"`
+	ReplacementLeaseID string    `json:"replacement_lease_id,omitempty"`
+}
+
+type Availability struct {
+	WindowStart     time.Time `json:"window_start"`
+	WindowEnd       time.Time `json:"window_end"`
+	Units           int32     `json:"units"`
+	ExpectedSeconds int64     `json:"expected_seconds"`
+	DowntimeSeconds int64     `json:"downtime_seconds"`
+	Outages         []Outage  `json:"outages"`
+}
+
+// Percent returns the availability as a percentage rounded to 4 decimal places
+func (a Availability) Percent() (apd.Decimal, error) {
+	var res apd.Decimal
+	if a.ExpectedSeconds <= 0 || a.DowntimeSeconds <= 0 {
+		res.SetInt64(100)
+		return res, nil
+	}
+	up := apd.New(a.ExpectedSeconds-a.DowntimeSeconds, 0)
+	_, err := apdContext.Mul(up, up, apd.New(100, 0))
+	if err != nil {
+		return res, fmt.Errorf("error calculating availability: %w", err)
+	}
+	_, err = apdContext.Quo(&res, up, apd.New(a.ExpectedSeconds, 0))
+	if err != nil {
+		return res, fmt.Errorf("error calculating availability: %w", err)
+	}
+	_, err = apdContext.Quantize(&res, &res, -4)
+	if err != nil {
+		return res, fmt.Errorf("error rounding availability: %w", err)
+	}
+	return res, nil
+}
+
+// CalculateAvailability works out the downtime of an order within [startTime, endTime), or the part of it the order
+// was active for when it was created or ended within it.
+// Each failed lease counts as an outage of one unit from the time it ended until the next unused lease on the order
+// was created, or until the end of the window if it was never replaced.
+func CalculateAvailability(order store.Order, leases []store.Lease, startTime time.Time, endTime time.Time) Availability {
+	windowStart := startTime
+	if order.CreateTime.After(windowStart) {
+		windowStart = order.CreateTime
+	}
+	// a canceled or completed order isn't expected to be available after it ended
+	windowEnd := endTime
+	if order.EndTime.Valid && order.EndTime.Time.Before(windowEnd) {
+		windowEnd = order.EndTime.Time
+	}
+
+	units := order.Quantity
+	if units < 1 {
+		units = 1
+	}
+
+	res := Availability{
+		WindowStart: windowStart,
+		WindowEnd:   windowEnd,
+		Units:       units,
+		Outages:     []Outage{},
+	}
+	if !windowStart.Before(windowEnd) {
+		return res
+	}
+	res.ExpectedSeconds = int64(windowEnd.Sub(windowStart).Seconds()) * int64(units)
+
+	sorted := make([]store.Lease, len(leases))
+	copy(sorted, leases)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreateTime.Before(sorted[j].CreateTime)
+	})
+
+	var failed []store.Lease
+	for _, lease := range sorted {
+		if lease.Status == store.LeaseStatusFailed && lease.EndTime.Valid {
+			failed = append(failed, lease)
+		}
+	}
+	sort.SliceStable(failed, func(i, j int) bool {
+		return failed[i].EndTime.Time.Before(failed[j].EndTime.Time)
+	})
+
+	used := make(map[string]bool, len(sorted))
+	for _, lease := range failed {
+		outage := Outage{
+			LeaseID:   lease.ID,
+			StartTime: lease.EndTime.Time,
+			EndTime:   windowEnd,
+		}
+		for _, replacement := range sorted {
+			if used[replacement.ID] || replacement.ID == lease.ID || replacement.CreateTime.Before(lease.EndTime.Time) {
+				continue
+			}
+			used[replacement.ID] = true
+			outage.ReplacementLeaseID = replacement.ID
+			outage.EndTime = replacement.CreateTime
+			break
+		}
+
+		// only count the part of the outage inside the window, the order can't be down once it ended
+		if outage.StartTime.Before(windowStart) {
+			outage.StartTime = windowStart
+		}
+		if outage.EndTime.After(windowEnd) {
+			outage.EndTime = windowEnd
+		}
+		if !outage.StartTime.Before(outage.EndTime) {
+			continue
+		}
+
+		res.Outages = append(res.Outages, outage)
+		res.DowntimeSeconds += int64(outage.EndTime.Sub(outage.StartTime).Seconds())
+	}
+
+	if res.DowntimeSeconds > res.ExpectedSeconds {
+		res.DowntimeSeconds = res.ExpectedSeconds
+	}
+	return res
+}
+
+// CreditTier returns the tier that applies to the availability, tiers must be sorted by availability_below ascending.
+// The most severe tier the availability falls under wins, nil is returned when the SLA has been met.
+func CreditTier(tiers []store.SlaTier, availability *apd.Decimal) *store.SlaTier {
+	for i := range tiers {
+		if availability.Cmp(&tiers[i].AvailabilityBelow) < 0 {
+			return &tiers[i]
+		}
+	}
+	return nil
+}
+
+type creditDetails struct {
+	Availability
+	AvailabilityPercent string `json:"availability_percent"`
+	AvailabilityBelow   string `json:"availability_below"`
+	CreditPercent       string `json:"credit_percent"`
+	OrderSpend          string `json:"order_spend"`
+}
+
+// Measure the availability of the orders of billingAccounts between startTime and endTime and store a credit,
+// applied to the billing period that starts at endTime, for each order that missed its SLA
+func (c *Calculator) issueCredits(ctx context.Context, billingAccounts []store.BillingAccount, startTime time.Time, endTime time.Time) error {
+	applyEndTime := endTime.AddDate(0, 1, 0)
+
+	slaTiers, err := c.querier.ListSLATiers(ctx)
+	if err != nil {
+		return fmt.Errorf("list sla tiers failed: %w", err)
+	}
+	tiers := make(map[store.InfrastructureType][]store.SlaTier)
+	for _, tier := range slaTiers {
+		tiers[tier.InfraType] = append(tiers[tier.InfraType], tier)
+	}
+
+	for _, billingAccount := range billingAccounts {
+		orders, err := c.querier.ListOrdersByBillingAccountId(ctx, billingAccount.ID)
+		if err != nil {
+			return fmt.Errorf("error when listing orders for billing account: %w", err)
+		}
+
+		for _, order := range orders {
+			// orders that weren't active in the window have no availability to measure
+			if !order.CreateTime.Before(endTime) || order.EndTime.Valid && !order.EndTime.Time.After(startTime) {
+				continue
+			}
+			// leases that failed before the window are needed too, they're down until they were replaced
+			leases, err := c.querier.ListLeasesCreatedBeforeByOrderId(ctx, store.ListLeasesCreatedBeforeByOrderIdParams{
+				OrderID: order.ID,
+				EndTime: endTime,
+			})
+			if err != nil {
+				return fmt.Errorf("list leases created before by order id failed: %w", err)
+			}
+
+			availability := CalculateAvailability(order, leases, startTime, endTime)
+			if availability.DowntimeSeconds == 0 {
+				continue
+			}
+
+			percent, err := availability.Percent()
+			if err != nil {
+				return err
+			}
+			tier := CreditTier(tiers[order.InfraType], &percent)
+			if tier == nil {
+				continue
+			}
+
+			orderSpend, err := c.querier.FindOrderSpendForPeriod(ctx, store.FindOrderSpendForPeriodParams{
+				OrderID:          order.ID,
+				BillingAccountID: billingAccount.ID,
+				StartTime:        startTime,
+				EndTime:          endTime,
+			})
+			if errors.Is(err, pgx.ErrNoRows) {
+				// the order was never billed for the window so there is nothing to credit
+				orderSpend = store.OrderSpend{Spend: *apd.New(0, 0)}
+			} else if err != nil {
+				return fmt.Errorf("find order spend for period failed: %w", err)
+			}
+
+			var credit apd.Decimal
+			_, err = apdContext.Mul(&credit, &orderSpend.Spend, &tier.CreditPercent)
+			if err != nil {
+				return fmt.Errorf("error calculating sla credit: %w", err)
+			}
+			_, err = apdContext.Quo(&credit, &credit, apd.New(100, 0))
+			if err != nil {
+				return fmt.Errorf("error calculating sla credit: %w", err)
+			}
+
+			details, err := json.Marshal(creditDetails{
+				Availability:        availability,
+				AvailabilityPercent: percent.String(),
+				AvailabilityBelow:   tier.AvailabilityBelow.String(),
+				CreditPercent:       tier.CreditPercent.String(),
+				OrderSpend:          orderSpend.Spend.String(),
+			})
+			if err != nil {
+				return fmt.Errorf("error marshalling sla credit details: %w", err)
+			}
+
+			_, err = c.querier.CreateSLACredit(ctx, store.CreateSLACreditParams{
+				OrderID:          order.ID,
+				BillingAccountID: billingAccount.ID,
+				StartTime:        startTime,
+				EndTime:          endTime,
+				Availability:     percent,
+				CreditPercent:    tier.CreditPercent,
+				Credit:           credit,
+				ApplyStartTime:   endTime,
+				ApplyEndTime:     applyEndTime,
+				Details:          pgtype.JSONB{Bytes: details, Status: pgtype.Present},
+			})
+			if err != nil {
+				return fmt.Errorf("create sla credit failed: %w", err)
+			}
+
+			logger.FromContext(ctx, c.log).Info(
+				"issued sla credit",
+				zap.String("billingAccountId", billingAccount.ID),
+				zap.String("orderId", order.ID),
+				zap.String("availability", percent.String()),
+				zap.String("credit", credit.String()),
+			)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,401 @@
+package sla
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"biller/svc/compute/store"
+
+	"github.com/cockroachdb/apd/v2"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap/zaptest"
+)
+
+type FakeTxQuerier struct {
+	store.TxQuerier
+	billingAccounts      []store.BillingAccount
+	createSLACredit      store.SlaCredit
+	createSLACreditError error
+	leases               []store.Lease
+	orders               []store.Order
+	orderSpend           store.OrderSpend
+	orderSpendError      error
+	orderSpendParams     *store.FindOrderSpendForPeriodParams
+	slaCredits           []store.CreateSLACreditParams
+	slaTiers             []store.SlaTier
+	slaTiersError        error
+}
+
+func (txq FakeTxQuerier) ListSLATiers(ctx context.Context) ([]store.SlaTier, error) {
+	return txq.slaTiers, txq.slaTiersError
+}
+
+func (txq FakeTxQuerier) ListAllBillingAccounts(ctx context.Context) ([]store.BillingAccount, error) {
+	return txq.billingAccounts, nil
+}
+
+func (txq FakeTxQuerier) ListOrdersByBillingAccountId(ctx context.Context, billingAccountID string) ([]store.Order, error) {
+	return txq.orders, nil
+}
+
+func (txq FakeTxQuerier) ListLeasesCreatedBeforeByOrderId(ctx context.Context, arg store.ListLeasesCreatedBeforeByOrderIdParams) ([]store.Lease, error) {
+	var leases []store.Lease
+	for _, lease := range txq.leases {
+		if lease.CreateTime.Before(arg.EndTime) {
+			leases = append(leases, lease)
+		}
+	}
+	return leases, nil
+}
+
+func (txq *FakeTxQuerier) FindOrderSpendForPeriod(ctx context.Context, arg store.FindOrderSpendForPeriodParams) (store.OrderSpend, error) {
+	txq.orderSpendParams = &arg
+	return txq.orderSpend, txq.orderSpendError
+}
+
+func (txq *FakeTxQuerier) CreateSLACredit(ctx context.Context, arg store.CreateSLACreditParams) (store.SlaCredit, error) {
+	txq.slaCredits = append(txq.slaCredits, arg)
+	return txq.createSLACredit, txq.createSLACreditError
+}
+
+var (
+	januaryStart  = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	februaryStart = time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func dedicatedTiers() []store.SlaTier {
+	return []store.SlaTier{
+		{InfraType: store.InfrastructureTypeDedicated, AvailabilityBelow: *apd.New(95, 0), CreditPercent: *apd.New(100, 0)},
+		{InfraType: store.InfrastructureTypeDedicated, AvailabilityBelow: *apd.New(99, 0), CreditPercent: *apd.New(25, 0)},
+		{InfraType: store.InfrastructureTypeDedicated, AvailabilityBelow: *apd.New(9995, -2), CreditPercent: *apd.New(10, 0)},
+	}
+}
+
+func failedLease(id string, createTime time.Time, endTime time.Time) store.Lease {
+	return store.Lease{
+		ID:         id,
+		OrderID:    "1",
+		CreateTime: createTime,
+		EndTime: sql.NullTime{
+			Time:  endTime,
+			Valid: true,
+		},
+		Status: store.LeaseStatusFailed,
+	}
+}
+
+func Test_CalculateAvailability(t *testing.T) {
+	order := store.Order{
+		ID:         "1",
+		Quantity:   1,
+		CreateTime: time.Date(2019, time.December, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("should be fully available when no leases failed", func(t *testing.T) {
+		leases := []store.Lease{
+			{ID: "1", OrderID: "1", CreateTime: januaryStart, Status: store.LeaseStatusActive},
+		}
+		availability := CalculateAvailability(order, leases, januaryStart, februaryStart)
+		if availability.DowntimeSeconds != 0 {
+			t.Errorf("expected no downtime, got %d", availability.DowntimeSeconds)
+		}
+		percent, err := availability.Percent()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if percent.String() != "100" {
+			t.Errorf("expected availability to be %s, got %s", "100", percent.String())
+		}
+	})
+	t.Run("should count downtime until the replacement lease was created", func(t *testing.T) {
+		leases := []store.Lease{
+			failedLease("1", januaryStart, time.Date(2020, time.January, 10, 0, 0, 0, 0, time.UTC)),
+			{ID: "2", OrderID: "1", CreateTime: time.Date(2020, time.January, 10, 6, 0, 0, 0, time.UTC), Status: store.LeaseStatusActive},
+		}
+		availability := CalculateAvailability(order, leases, januaryStart, februaryStart)
+		if availability.DowntimeSeconds != 6*60*60 {
+			t.Errorf("expected downtime to be %d, got %d", 6*60*60, availability.DowntimeSeconds)
+		}
+		if len(availability.Outages) != 1 || availability.Outages[0].ReplacementLeaseID != "2" {
+			t.Errorf("expected one outage replaced by lease 2, got %+v", availability.Outages)
+		}
+		percent, err := availability.Percent()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if percent.String() != "99.1935" {
+			t.Errorf("expected availability to be %s, got %s", "99.1935", percent.String())
+		}
+	})
+	t.Run("should count downtime until the end of the window when the lease was never replaced", func(t *testing.T) {
+		leases := []store.Lease{
+			failedLease("1", januaryStart, time.Date(2020, time.January, 31, 0, 0, 0, 0, time.UTC)),
+		}
+		availability := CalculateAvailability(order, leases, januaryStart, februaryStart)
+		if availability.DowntimeSeconds != 24*60*60 {
+			t.Errorf("expected downtime to be %d, got %d", 24*60*60, availability.DowntimeSeconds)
+		}
+		if availability.Outages[0].ReplacementLeaseID != "" {
+			t.Errorf("expected no replacement lease, got %s", availability.Outages[0].ReplacementLeaseID)
+		}
+	})
+	t.Run("should weight downtime by the quantity of the order", func(t *testing.T) {
+		order := order
+		order.Quantity = 2
+		leases := []store.Lease{
+			failedLease("1", januaryStart, time.Date(2020, time.January, 31, 0, 0, 0, 0, time.UTC)),
+			{ID: "2", OrderID: "1", CreateTime: januaryStart, Status: store.LeaseStatusActive},
+		}
+		availability := CalculateAvailability(order, leases, januaryStart, februaryStart)
+		percent, err := availability.Percent()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if percent.String() != "98.3871" {
+			t.Errorf("expected availability to be %s, got %s", "98.3871", percent.String())
+		}
+	})
+	t.Run("should only measure orders until they ended", func(t *testing.T) {
+		canceled := time.Date(2020, time.January, 16, 0, 0, 0, 0, time.UTC)
+		tests := []struct {
+			name     string
+			endTime  time.Time
+			leases   []store.Lease
+			expected int64
+			downtime int64
+		}{
+			{
+				name:    "order canceled mid-window with a lease that was never replaced",
+				endTime: canceled,
+				leases: []store.Lease{
+					failedLease("1", januaryStart, time.Date(2020, time.January, 15, 0, 0, 0, 0, time.UTC)),
+				},
+				expected: 15 * 24 * 60 * 60,
+				downtime: 24 * 60 * 60,
+			},
+			{
+				name:    "order canceled mid-window without outages",
+				endTime: canceled,
+				leases: []store.Lease{
+					{ID: "1", OrderID: "1", CreateTime: januaryStart, EndTime: sql.NullTime{Time: canceled, Valid: true}, Status: store.LeaseStatusComplete},
+				},
+				expected: 15 * 24 * 60 * 60,
+			},
+			{
+				name:    "order canceled mid-window when its lease failed",
+				endTime: canceled,
+				leases: []store.Lease{
+					failedLease("1", januaryStart, canceled),
+				},
+				expected: 15 * 24 * 60 * 60,
+			},
+			{
+				name:    "order completed before the window",
+				endTime: time.Date(2019, time.December, 20, 0, 0, 0, 0, time.UTC),
+				leases: []store.Lease{
+					failedLease("1", time.Date(2019, time.December, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, time.December, 10, 0, 0, 0, 0, time.UTC)),
+				},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				order := order
+				order.Status = store.OrderStatusCanceled
+				order.EndTime = sql.NullTime{Time: tt.endTime, Valid: true}
+				availability := CalculateAvailability(order, tt.leases, januaryStart, februaryStart)
+				if availability.ExpectedSeconds != tt.expected {
+					t.Errorf("expected expected seconds to be %d, got %d", tt.expected, availability.ExpectedSeconds)
+				}
+				if availability.DowntimeSeconds != tt.downtime {
+					t.Errorf("expected downtime to be %d, got %d", tt.downtime, availability.DowntimeSeconds)
+				}
+			})
+		}
+	})
+	t.Run("should ignore outages outside of the window", func(t *testing.T) {
+		leases := []store.Lease{
+			failedLease("1", time.Date(2019, time.December, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, time.December, 10, 0, 0, 0, 0, time.UTC)),
+			{ID: "2", OrderID: "1", CreateTime: time.Date(2019, time.December, 11, 0, 0, 0, 0, time.UTC), Status: store.LeaseStatusActive},
+		}
+		availability := CalculateAvailability(order, leases, januaryStart, februaryStart)
+		if availability.DowntimeSeconds != 0 {
+			t.Errorf("expected no downtime, got %d", availability.DowntimeSeconds)
+		}
+	})
+}
+
+func Test_CreditTier(t *testing.T) {
+	t.Run("should return nil when the sla was met", func(t *testing.T) {
+		tier := CreditTier(dedicatedTiers(), apd.New(9999, -2))
+		if tier != nil {
+			t.Errorf("expected no tier, got %+v", tier)
+		}
+	})
+	t.Run("should return the most severe tier the availability falls under", func(t *testing.T) {
+		tier := CreditTier(dedicatedTiers(), apd.New(985, -1))
+		if tier == nil {
+			t.Fatal("expected tier, got nil")
+		}
+		if tier.CreditPercent.String() != "25" {
+			t.Errorf("expected credit percent to be %s, got %s", "25", tier.CreditPercent.String())
+		}
+	})
+}
+
+func Test_issueCredits(t *testing.T) {
+	newQuerier := func() *FakeTxQuerier {
+		return &FakeTxQuerier{
+			billingAccounts: []store.BillingAccount{{ID: "1"}},
+			orders: []store.Order{
+				{
+					ID:               "1",
+					BillingAccountID: "1",
+					InfraType:        store.InfrastructureTypeDedicated,
+					Quantity:         1,
+					CreateTime:       januaryStart,
+				},
+			},
+			leases: []store.Lease{
+				failedLease("1", januaryStart, time.Date(2020, time.January, 10, 0, 0, 0, 0, time.UTC)),
+				{ID: "2", OrderID: "1", CreateTime: time.Date(2020, time.January, 10, 6, 0, 0, 0, time.UTC), Status: store.LeaseStatusActive},
+			},
+			orderSpend: store.OrderSpend{Spend: *apd.New(200, 0)},
+			slaTiers:   dedicatedTiers(),
+		}
+	}
+
+	t.Run("should fail when ListSLATiers returns an error", func(t *testing.T) {
+		querier := newQuerier()
+		querier.slaTiersError = errors.New("list sla tiers error")
+		calculator := NewCalculator(querier, zaptest.NewLogger(t))
+		err := calculator.issueCredits(context.Background(), querier.billingAccounts, januaryStart, februaryStart)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if err.Error() != "list sla tiers failed: list sla tiers error" {
+			t.Errorf("expected error message to be '%s', got %v", "list sla tiers failed: list sla tiers error", err.Error())
+		}
+	})
+	t.Run("should fail when CreateSLACredit returns an error", func(t *testing.T) {
+		querier := newQuerier()
+		querier.createSLACreditError = errors.New("create sla credit error")
+		calculator := NewCalculator(querier, zaptest.NewLogger(t))
+		err := calculator.issueCredits(context.Background(), querier.billingAccounts, januaryStart, februaryStart)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if err.Error() != "create sla credit failed: create sla credit error" {
+			t.Errorf("expected error message to be '%s', got %v", "create sla credit failed: create sla credit error", err.Error())
+		}
+	})
+	t.Run("should not issue a credit when the sla was met", func(t *testing.T) {
+		querier := newQuerier()
+		querier.leases = querier.leases[1:]
+		calculator := NewCalculator(querier, zaptest.NewLogger(t))
+		err := calculator.issueCredits(context.Background(), querier.billingAccounts, januaryStart, februaryStart)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if len(querier.slaCredits) != 0 {
+			t.Errorf("expected no credits, got %d", len(querier.slaCredits))
+		}
+	})
+	t.Run("should skip orders that ended before the window", func(t *testing.T) {
+		querier := newQuerier()
+		querier.orders[0].CreateTime = time.Date(2019, time.December, 1, 0, 0, 0, 0, time.UTC)
+		querier.orders[0].Status = store.OrderStatusCanceled
+		querier.orders[0].EndTime = sql.NullTime{Time: time.Date(2019, time.December, 20, 0, 0, 0, 0, time.UTC), Valid: true}
+		calculator := NewCalculator(querier, zaptest.NewLogger(t))
+		err := calculator.issueCredits(context.Background(), querier.billingAccounts, januaryStart, februaryStart)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if len(querier.slaCredits) != 0 {
+			t.Errorf("expected no credits, got %d", len(querier.slaCredits))
+		}
+	})
+	t.Run("should issue a credit against the next period when the sla was missed", func(t *testing.T) {
+		querier := newQuerier()
+		calculator := NewCalculator(querier, zaptest.NewLogger(t))
+		err := calculator.issueCredits(context.Background(), querier.billingAccounts, januaryStart, februaryStart)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(querier.slaCredits) != 1 {
+			t.Fatalf("expected one credit, got %d", len(querier.slaCredits))
+		}
+		credit := querier.slaCredits[0]
+		if credit.Credit.String() != "20" {
+			t.Errorf("expected credit to be %s, got %s", "20", credit.Credit.String())
+		}
+		if credit.Availability.String() != "99.1935" {
+			t.Errorf("expected availability to be %s, got %s", "99.1935", credit.Availability.String())
+		}
+		if !credit.ApplyStartTime.Equal(februaryStart) {
+			t.Errorf("expected apply start time to be %v, got %v", februaryStart, credit.ApplyStartTime)
+		}
+		if !credit.ApplyEndTime.Equal(time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("expected apply end time to be %v, got %v", time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC), credit.ApplyEndTime)
+		}
+
+		var details map[string]interface{}
+		err = json.Unmarshal(credit.Details.Bytes, &details)
+		if err != nil {
+			t.Fatalf("expected details to be valid json, got %v", err)
+		}
+		if details["downtime_seconds"] != float64(6*60*60) {
+			t.Errorf("expected downtime in details to be %d, got %v", 6*60*60, details["downtime_seconds"])
+		}
+	})
+	t.Run("should credit the spend of exactly the measured period", func(t *testing.T) {
+		querier := newQuerier()
+		calculator := NewCalculator(querier, zaptest.NewLogger(t))
+		err := calculator.issueCredits(context.Background(), querier.billingAccounts, januaryStart, februaryStart)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if querier.orderSpendParams == nil || !querier.orderSpendParams.StartTime.Equal(januaryStart) || !querier.orderSpendParams.EndTime.Equal(februaryStart) {
+			t.Errorf("expected the order spend of %v to %v, got %v", januaryStart, februaryStart, querier.orderSpendParams)
+		}
+	})
+	t.Run("should count downtime of a lease that failed before the window and was never replaced", func(t *testing.T) {
+		querier := newQuerier()
+		querier.orders[0].CreateTime = time.Date(2019, time.December, 1, 0, 0, 0, 0, time.UTC)
+		querier.leases = []store.Lease{
+			failedLease("1", time.Date(2019, time.December, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, time.December, 20, 0, 0, 0, 0, time.UTC)),
+		}
+		calculator := NewCalculator(querier, zaptest.NewLogger(t))
+		err := calculator.issueCredits(context.Background(), querier.billingAccounts, januaryStart, februaryStart)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(querier.slaCredits) != 1 {
+			t.Fatalf("expected one credit, got %d", len(querier.slaCredits))
+		}
+		if querier.slaCredits[0].Availability.String() != "0.0000" {
+			t.Errorf("expected availability to be %s, got %s", "0.0000", querier.slaCredits[0].Availability.String())
+		}
+		if querier.slaCredits[0].Credit.String() != "200" {
+			t.Errorf("expected credit to be %s, got %s", "200", querier.slaCredits[0].Credit.String())
+		}
+	})
+	t.Run("should issue a zero credit when the order has no spend", func(t *testing.T) {
+		querier := newQuerier()
+		querier.orderSpendError = pgx.ErrNoRows
+		calculator := NewCalculator(querier, zaptest.NewLogger(t))
+		err := calculator.issueCredits(context.Background(), querier.billingAccounts, januaryStart, februaryStart)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(querier.slaCredits) != 1 {
+			t.Fatalf("expected one credit, got %d", len(querier.slaCredits))
+		}
+		if !querier.slaCredits[0].Credit.IsZero() {
+			t.Errorf("expected credit to be zero, got %s", querier.slaCredits[0].Credit.String())
+		}
+	})
+}
@@ -0,0 +1,209 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: api_key.sql
+
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+const createApiKey = `-- name: CreateApiKey :one
+INSERT INTO "api_key" (billing_account_id, display_name, prefix, secret_hash, role, created_by, expire_time)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING uid, billing_account_id, display_name, prefix, secret_hash, role, created_by, create_time, expire_time, last_used_time, revoked_by, revoke_time
+`
+
+type CreateApiKeyParams struct {
+	BillingAccountID string
+	DisplayName      string
+	Prefix           string
+	SecretHash       []byte
+	Role             BillingAccountRole
+	CreatedBy        string
+	ExpireTime       sql.NullTime
+}
+
+func (q *Queries) CreateApiKey(ctx context.Context, arg CreateApiKeyParams) (ApiKey, error) {
+	row := q.db.QueryRow(ctx, createApiKey,
+		arg.BillingAccountID,
+		arg.DisplayName,
+		arg.Prefix,
+		arg.SecretHash,
+		arg.Role,
+		arg.CreatedBy,
+		arg.ExpireTime,
+	)
+	var i ApiKey
+	err := row.Scan(
+		&i.Uid,
+		&i.BillingAccountID,
+		&i.DisplayName,
+		&i.Prefix,
+		&i.SecretHash,
+		&i.Role,
+		&i.CreatedBy,
+		&i.CreateTime,
+		&i.ExpireTime,
+		&i.LastUsedTime,
+		&i.RevokedBy,
+		&i.RevokeTime,
+	)
+	return i, err
+}
+
+const findApiKeyById = `-- name: FindApiKeyById :one
+SELECT uid, billing_account_id, display_name, prefix, secret_hash, role, created_by, create_time, expire_time, last_used_time, revoked_by, revoke_time
+FROM "api_key"
+WHERE uid = $1
+  AND billing_account_id = $2
+`
+
+type FindApiKeyByIdParams struct {
+	Uid              uuid.UUID
+	BillingAccountID string
+}
+
+func (q *Queries) FindApiKeyById(ctx context.Context, arg FindApiKeyByIdParams) (ApiKey, error) {
+	row := q.db.QueryRow(ctx, findApiKeyById, arg.Uid, arg.BillingAccountID)
+	var i ApiKey
+	err := row.Scan(
+		&i.Uid,
+		&i.BillingAccountID,
+		&i.DisplayName,
+		&i.Prefix,
+		&i.SecretHash,
+		&i.Role,
+		&i.CreatedBy,
+		&i.CreateTime,
+		&i.ExpireTime,
+		&i.LastUsedTime,
+		&i.RevokedBy,
+		&i.RevokeTime,
+	)
+	return i, err
+}
+
+const findApiKeyByPrefix = `-- name: FindApiKeyByPrefix :one
+SELECT uid, billing_account_id, display_name, prefix, secret_hash, role, created_by, create_time, expire_time, last_used_time, revoked_by, revoke_time
+FROM "api_key"
+WHERE prefix = $1
+`
+
+func (q *Queries) FindApiKeyByPrefix(ctx context.Context, prefix string) (ApiKey, error) {
+	row := q.db.QueryRow(ctx, findApiKeyByPrefix, prefix)
+	var i ApiKey
+	err := row.Scan(
+		&i.Uid,
+		&i.BillingAccountID,
+		&i.DisplayName,
+		&i.Prefix,
+		&i.SecretHash,
+		&i.Role,
+		&i.CreatedBy,
+		&i.CreateTime,
+		&i.ExpireTime,
+		&i.LastUsedTime,
+		&i.RevokedBy,
+		&i.RevokeTime,
+	)
+	return i, err
+}
+
+const listApiKeysByBillingAccountId = `-- name: ListApiKeysByBillingAccountId :many
+SELECT uid, billing_account_id, display_name, prefix, secret_hash, role, created_by, create_time, expire_time, last_used_time, revoked_by, revoke_time
+FROM "api_key"
+WHERE billing_account_id = $1
+ORDER BY create_time DESC, uid
+LIMIT $3 OFFSET $2
+`
+
+type ListApiKeysByBillingAccountIdParams struct {
+	BillingAccountID string
+	RowOffset        int32
+	RowLimit         int32
+}
+
+func (q *Queries) ListApiKeysByBillingAccountId(ctx context.Context, arg ListApiKeysByBillingAccountIdParams) ([]ApiKey, error) {
+	rows, err := q.db.Query(ctx, listApiKeysByBillingAccountId, arg.BillingAccountID, arg.RowOffset, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ApiKey
+	for rows.Next() {
+		var i ApiKey
+		if err := rows.Scan(
+			&i.Uid,
+			&i.BillingAccountID,
+			&i.DisplayName,
+			&i.Prefix,
+			&i.SecretHash,
+			&i.Role,
+			&i.CreatedBy,
+			&i.CreateTime,
+			&i.ExpireTime,
+			&i.LastUsedTime,
+			&i.RevokedBy,
+			&i.RevokeTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const revokeApiKey = `-- name: RevokeApiKey :one
+UPDATE "api_key"
+SET revoked_by = $1,
+    revoke_time = NOW()
+WHERE uid = $2
+  AND billing_account_id = $3
+  AND revoke_time IS NULL
+RETURNING uid, billing_account_id, display_name, prefix, secret_hash, role, created_by, create_time, expire_time, last_used_time, revoked_by, revoke_time
+`
+
+type RevokeApiKeyParams struct {
+	RevokedBy        string
+	Uid              uuid.UUID
+	BillingAccountID string
+}
+
+func (q *Queries) RevokeApiKey(ctx context.Context, arg RevokeApiKeyParams) (ApiKey, error) {
+	row := q.db.QueryRow(ctx, revokeApiKey, arg.RevokedBy, arg.Uid, arg.BillingAccountID)
+	var i ApiKey
+	err := row.Scan(
+		&i.Uid,
+		&i.BillingAccountID,
+		&i.DisplayName,
+		&i.Prefix,
+		&i.SecretHash,
+		&i.Role,
+		&i.CreatedBy,
+		&i.CreateTime,
+		&i.ExpireTime,
+		&i.LastUsedTime,
+		&i.RevokedBy,
+		&i.RevokeTime,
+	)
+	return i, err
+}
+
+const touchApiKey = `-- name: TouchApiKey :exec
+UPDATE "api_key"
+SET last_used_time = NOW()
+WHERE uid = $1
+  AND (last_used_time IS NULL OR last_used_time < NOW() - INTERVAL '1 minute')
+`
+
+// last_used_time is only updated once a minute so busy keys don't write on every request
+func (q *Queries) TouchApiKey(ctx context.Context, uid uuid.UUID) error {
+	_, err := q.db.Exec(ctx, touchApiKey, uid)
+	return err
+}
@@ -0,0 +1,36 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: audit_log.sql
+
+package store
+
+import (
+	"context"
+
+	"github.com/jackc/pgtype"
+)
+
+const createAuditEvent = `-- name: CreateAuditEvent :exec
+INSERT INTO "audit_log" (method, principal, resource_name, request_id, diff, result_code)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type CreateAuditEventParams struct {
+	Method       string
+	Principal    string
+	ResourceName string
+	RequestID    string
+	Diff         pgtype.JSONB
+	ResultCode   string
+}
+
+func (q *Queries) CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) error {
+	_, err := q.db.Exec(ctx, createAuditEvent,
+		arg.Method,
+		arg.Principal,
+		arg.ResourceName,
+		arg.RequestID,
+		arg.Diff,
+		arg.ResultCode,
+	)
+	return err
+}
@@ -8,22 +8,189 @@ import (
 )
 
 const createBillingAccount = `-- name: CreateBillingAccount :one
-INSERT INTO "billing_account" (id)
+INSERT INTO "billing_account" (
+    id,
+    display_name,
+    contact_emails,
+    address_line1,
+    address_line2,
+    address_city,
+    address_region,
+    address_postal_code,
+    address_country_code,
+    tax_id,
+    currency,
+    timezone
+)
 VALUES (
-    $1
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9,
+    $10,
+    $11,
+    $12
 )
 ON CONFLICT DO NOTHING
-RETURNING id, create_time, supply_enabled, demand_enabled
+RETURNING id, create_time, supply_enabled, demand_enabled, display_name, contact_emails, address_line1, address_line2, address_city, address_region, address_postal_code, address_country_code, tax_id, currency, timezone, update_time, state, close_time
 `
 
-func (q *Queries) CreateBillingAccount(ctx context.Context, id string) (BillingAccount, error) {
-	row := q.db.QueryRow(ctx, createBillingAccount, id)
+type CreateBillingAccountParams struct {
+	ID                 string
+	DisplayName        string
+	ContactEmails      []string
+	AddressLine1       string
+	AddressLine2       string
+	AddressCity        string
+	AddressRegion      string
+	AddressPostalCode  string
+	AddressCountryCode string
+	TaxID              string
+	Currency           string
+	Timezone           string
+}
+
+func (q *Queries) CreateBillingAccount(ctx context.Context, arg CreateBillingAccountParams) (BillingAccount, error) {
+	row := q.db.QueryRow(ctx, createBillingAccount,
+		arg.ID,
+		arg.DisplayName,
+		arg.ContactEmails,
+		arg.AddressLine1,
+		arg.AddressLine2,
+		arg.AddressCity,
+		arg.AddressRegion,
+		arg.AddressPostalCode,
+		arg.AddressCountryCode,
+		arg.TaxID,
+		arg.Currency,
+		arg.Timezone,
+	)
 	var i BillingAccount
 	err := row.Scan(
 		&i.ID,
 		&i.CreateTime,
 		&i.SupplyEnabled,
 		&i.DemandEnabled,
+		&i.DisplayName,
+		&i.ContactEmails,
+		&i.AddressLine1,
+		&i.AddressLine2,
+		&i.AddressCity,
+		&i.AddressRegion,
+		&i.AddressPostalCode,
+		&i.AddressCountryCode,
+		&i.TaxID,
+		&i.Currency,
+		&i.Timezone,
+		&i.UpdateTime,
+		&i.State,
+		&i.CloseTime,
+	)
+	return i, err
+}
+
+const createBillingAccountStateChange = `-- name: CreateBillingAccountStateChange :one
+INSERT INTO "billing_account_state_change" (billing_account_id, from_state, to_state, reason, changed_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING uid, billing_account_id, from_state, to_state, reason, changed_by, change_time
+`
+
+type CreateBillingAccountStateChangeParams struct {
+	BillingAccountID string
+	FromState        BillingAccountState
+	ToState          BillingAccountState
+	Reason           string
+	ChangedBy        string
+}
+
+func (q *Queries) CreateBillingAccountStateChange(ctx context.Context, arg CreateBillingAccountStateChangeParams) (BillingAccountStateChange, error) {
+	row := q.db.QueryRow(ctx, createBillingAccountStateChange,
+		arg.BillingAccountID,
+		arg.FromState,
+		arg.ToState,
+		arg.Reason,
+		arg.ChangedBy,
+	)
+	var i BillingAccountStateChange
+	err := row.Scan(
+		&i.Uid,
+		&i.BillingAccountID,
+		&i.FromState,
+		&i.ToState,
+		&i.Reason,
+		&i.ChangedBy,
+		&i.ChangeTime,
+	)
+	return i, err
+}
+
+const disableBillingAccountDemand = `-- name: DisableBillingAccountDemand :one
+UPDATE "billing_account"
+SET demand_enabled = false
+WHERE id = $1
+RETURNING id, create_time, supply_enabled, demand_enabled, display_name, contact_emails, address_line1, address_line2, address_city, address_region, address_postal_code, address_country_code, tax_id, currency, timezone, update_time, state, close_time
+`
+
+func (q *Queries) DisableBillingAccountDemand(ctx context.Context, id string) (BillingAccount, error) {
+	row := q.db.QueryRow(ctx, disableBillingAccountDemand, id)
+	var i BillingAccount
+	err := row.Scan(
+		&i.ID,
+		&i.CreateTime,
+		&i.SupplyEnabled,
+		&i.DemandEnabled,
+		&i.DisplayName,
+		&i.ContactEmails,
+		&i.AddressLine1,
+		&i.AddressLine2,
+		&i.AddressCity,
+		&i.AddressRegion,
+		&i.AddressPostalCode,
+		&i.AddressCountryCode,
+		&i.TaxID,
+		&i.Currency,
+		&i.Timezone,
+		&i.UpdateTime,
+		&i.State,
+		&i.CloseTime,
+	)
+	return i, err
+}
+
+const disableBillingAccountSupply = `-- name: DisableBillingAccountSupply :one
+UPDATE "billing_account"
+SET supply_enabled = false
+WHERE id = $1
+RETURNING id, create_time, supply_enabled, demand_enabled, display_name, contact_emails, address_line1, address_line2, address_city, address_region, address_postal_code, address_country_code, tax_id, currency, timezone, update_time, state, close_time
+`
+
+func (q *Queries) DisableBillingAccountSupply(ctx context.Context, id string) (BillingAccount, error) {
+	row := q.db.QueryRow(ctx, disableBillingAccountSupply, id)
+	var i BillingAccount
+	err := row.Scan(
+		&i.ID,
+		&i.CreateTime,
+		&i.SupplyEnabled,
+		&i.DemandEnabled,
+		&i.DisplayName,
+		&i.ContactEmails,
+		&i.AddressLine1,
+		&i.AddressLine2,
+		&i.AddressCity,
+		&i.AddressRegion,
+		&i.AddressPostalCode,
+		&i.AddressCountryCode,
+		&i.TaxID,
+		&i.Currency,
+		&i.Timezone,
+		&i.UpdateTime,
+		&i.State,
+		&i.CloseTime,
 	)
 	return i, err
 }
@@ -32,7 +199,7 @@ const enableBillingAccountDemand = `-- name: EnableBillingAccountDemand :one
 UPDATE "billing_account"
 SET demand_enabled = true
 WHERE id = $1
-RETURNING id, create_time, supply_enabled, demand_enabled
+RETURNING id, create_time, supply_enabled, demand_enabled, display_name, contact_emails, address_line1, address_line2, address_city, address_region, address_postal_code, address_country_code, tax_id, currency, timezone, update_time, state, close_time
 `
 
 func (q *Queries) EnableBillingAccountDemand(ctx context.Context, id string) (BillingAccount, error) {
@@ -43,6 +210,20 @@ func (q *Queries) EnableBillingAccountDemand(ctx context.Context, id string) (Bi
 		&i.CreateTime,
 		&i.SupplyEnabled,
 		&i.DemandEnabled,
+		&i.DisplayName,
+		&i.ContactEmails,
+		&i.AddressLine1,
+		&i.AddressLine2,
+		&i.AddressCity,
+		&i.AddressRegion,
+		&i.AddressPostalCode,
+		&i.AddressCountryCode,
+		&i.TaxID,
+		&i.Currency,
+		&i.Timezone,
+		&i.UpdateTime,
+		&i.State,
+		&i.CloseTime,
 	)
 	return i, err
 }
@@ -51,7 +232,7 @@ const enableBillingAccountSupply = `-- name: EnableBillingAccountSupply :one
 UPDATE "billing_account"
 SET supply_enabled = true
 WHERE id = $1
-RETURNING id, create_time, supply_enabled, demand_enabled
+RETURNING id, create_time, supply_enabled, demand_enabled, display_name, contact_emails, address_line1, address_line2, address_city, address_region, address_postal_code, address_country_code, tax_id, currency, timezone, update_time, state, close_time
 `
 
 func (q *Queries) EnableBillingAccountSupply(ctx context.Context, id string) (BillingAccount, error) {
@@ -62,12 +243,26 @@ func (q *Queries) EnableBillingAccountSupply(ctx context.Context, id string) (Bi
 		&i.CreateTime,
 		&i.SupplyEnabled,
 		&i.DemandEnabled,
+		&i.DisplayName,
+		&i.ContactEmails,
+		&i.AddressLine1,
+		&i.AddressLine2,
+		&i.AddressCity,
+		&i.AddressRegion,
+		&i.AddressPostalCode,
+		&i.AddressCountryCode,
+		&i.TaxID,
+		&i.Currency,
+		&i.Timezone,
+		&i.UpdateTime,
+		&i.State,
+		&i.CloseTime,
 	)
 	return i, err
 }
 
 const findBillingAccountById = `-- name: FindBillingAccountById :one
-SELECT id, create_time, supply_enabled, demand_enabled
+SELECT id, create_time, supply_enabled, demand_enabled, display_name, contact_emails, address_line1, address_line2, address_city, address_region, address_postal_code, address_country_code, tax_id, currency, timezone, update_time, state, close_time
 FROM "billing_account"
 WHERE
     id = $1
@@ -81,12 +276,26 @@ func (q *Queries) FindBillingAccountById(ctx context.Context, id string) (Billin
 		&i.CreateTime,
 		&i.SupplyEnabled,
 		&i.DemandEnabled,
+		&i.DisplayName,
+		&i.ContactEmails,
+		&i.AddressLine1,
+		&i.AddressLine2,
+		&i.AddressCity,
+		&i.AddressRegion,
+		&i.AddressPostalCode,
+		&i.AddressCountryCode,
+		&i.TaxID,
+		&i.Currency,
+		&i.Timezone,
+		&i.UpdateTime,
+		&i.State,
+		&i.CloseTime,
 	)
 	return i, err
 }
 
 const listAllBillingAccounts = `-- name: ListAllBillingAccounts :many
-SELECT id, create_time, supply_enabled, demand_enabled
+SELECT id, create_time, supply_enabled, demand_enabled, display_name, contact_emails, address_line1, address_line2, address_city, address_region, address_postal_code, address_country_code, tax_id, currency, timezone, update_time, state, close_time
 FROM "billing_account"
 ORDER BY create_time
 `
@@ -105,6 +314,20 @@ func (q *Queries) ListAllBillingAccounts(ctx context.Context) ([]BillingAccount,
 			&i.CreateTime,
 			&i.SupplyEnabled,
 			&i.DemandEnabled,
+			&i.DisplayName,
+			&i.ContactEmails,
+			&i.AddressLine1,
+			&i.AddressLine2,
+			&i.AddressCity,
+			&i.AddressRegion,
+			&i.AddressPostalCode,
+			&i.AddressCountryCode,
+			&i.TaxID,
+			&i.Currency,
+			&i.Timezone,
+			&i.UpdateTime,
+			&i.State,
+			&i.CloseTime,
 		); err != nil {
 			return nil, err
 		}
@@ -116,33 +339,37 @@ func (q *Queries) ListAllBillingAccounts(ctx context.Context) ([]BillingAccount,
 	return items, nil
 }
 
-const listBillingAccounts = `-- name: ListBillingAccounts :many
-SELECT id, create_time, supply_enabled, demand_enabled
-FROM "billing_account"
-WHERE id = ANY($2::varchar[])
-ORDER BY create_time
-LIMIT $1
+const listBillingAccountStateChanges = `-- name: ListBillingAccountStateChanges :many
+SELECT uid, billing_account_id, from_state, to_state, reason, changed_by, change_time
+FROM "billing_account_state_change"
+WHERE billing_account_id = $1
+ORDER BY change_time DESC, uid
+LIMIT $3 OFFSET $2
 `
 
-type ListBillingAccountsParams struct {
-	Limit int32
-	Ids   []string
+type ListBillingAccountStateChangesParams struct {
+	BillingAccountID string
+	RowOffset        int32
+	RowLimit         int32
 }
 
-func (q *Queries) ListBillingAccounts(ctx context.Context, arg ListBillingAccountsParams) ([]BillingAccount, error) {
-	rows, err := q.db.Query(ctx, listBillingAccounts, arg.Limit, arg.Ids)
+func (q *Queries) ListBillingAccountStateChanges(ctx context.Context, arg ListBillingAccountStateChangesParams) ([]BillingAccountStateChange, error) {
+	rows, err := q.db.Query(ctx, listBillingAccountStateChanges, arg.BillingAccountID, arg.RowOffset, arg.RowLimit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []BillingAccount
+	var items []BillingAccountStateChange
 	for rows.Next() {
-		var i BillingAccount
+		var i BillingAccountStateChange
 		if err := rows.Scan(
-			&i.ID,
-			&i.CreateTime,
-			&i.SupplyEnabled,
-			&i.DemandEnabled,
+			&i.Uid,
+			&i.BillingAccountID,
+			&i.FromState,
+			&i.ToState,
+			&i.Reason,
+			&i.ChangedBy,
+			&i.ChangeTime,
 		); err != nil {
 			return nil, err
 		}
@@ -155,7 +382,7 @@ func (q *Queries) ListBillingAccounts(ctx context.Context, arg ListBillingAccoun
 }
 
 const selectBillingAccountForUpdate = `-- name: SelectBillingAccountForUpdate :one
-SELECT id, create_time, supply_enabled, demand_enabled
+SELECT id, create_time, supply_enabled, demand_enabled, display_name, contact_emails, address_line1, address_line2, address_city, address_region, address_postal_code, address_country_code, tax_id, currency, timezone, update_time, state, close_time
 FROM "billing_account"
 WHERE id = $1
 FOR UPDATE
@@ -169,6 +396,132 @@ func (q *Queries) SelectBillingAccountForUpdate(ctx context.Context, id string)
 		&i.CreateTime,
 		&i.SupplyEnabled,
 		&i.DemandEnabled,
+		&i.DisplayName,
+		&i.ContactEmails,
+		&i.AddressLine1,
+		&i.AddressLine2,
+		&i.AddressCity,
+		&i.AddressRegion,
+		&i.AddressPostalCode,
+		&i.AddressCountryCode,
+		&i.TaxID,
+		&i.Currency,
+		&i.Timezone,
+		&i.UpdateTime,
+		&i.State,
+		&i.CloseTime,
+	)
+	return i, err
+}
+
+const updateBillingAccountProfile = `-- name: UpdateBillingAccountProfile :one
+UPDATE "billing_account"
+SET display_name = $1,
+    contact_emails = $2,
+    address_line1 = $3,
+    address_line2 = $4,
+    address_city = $5,
+    address_region = $6,
+    address_postal_code = $7,
+    address_country_code = $8,
+    tax_id = $9,
+    currency = $10,
+    timezone = $11,
+    update_time = NOW()
+WHERE id = $12
+RETURNING id, create_time, supply_enabled, demand_enabled, display_name, contact_emails, address_line1, address_line2, address_city, address_region, address_postal_code, address_country_code, tax_id, currency, timezone, update_time, state, close_time
+`
+
+type UpdateBillingAccountProfileParams struct {
+	DisplayName        string
+	ContactEmails      []string
+	AddressLine1       string
+	AddressLine2       string
+	AddressCity        string
+	AddressRegion      string
+	AddressPostalCode  string
+	AddressCountryCode string
+	TaxID              string
+	Currency           string
+	Timezone           string
+	ID                 string
+}
+
+func (q *Queries) UpdateBillingAccountProfile(ctx context.Context, arg UpdateBillingAccountProfileParams) (BillingAccount, error) {
+	row := q.db.QueryRow(ctx, updateBillingAccountProfile,
+		arg.DisplayName,
+		arg.ContactEmails,
+		arg.AddressLine1,
+		arg.AddressLine2,
+		arg.AddressCity,
+		arg.AddressRegion,
+		arg.AddressPostalCode,
+		arg.AddressCountryCode,
+		arg.TaxID,
+		arg.Currency,
+		arg.Timezone,
+		arg.ID,
+	)
+	var i BillingAccount
+	err := row.Scan(
+		&i.ID,
+		&i.CreateTime,
+		&i.SupplyEnabled,
+		&i.DemandEnabled,
+		&i.DisplayName,
+		&i.ContactEmails,
+		&i.AddressLine1,
+		&i.AddressLine2,
+		&i.AddressCity,
+		&i.AddressRegion,
+		&i.AddressPostalCode,
+		&i.AddressCountryCode,
+		&i.TaxID,
+		&i.Currency,
+		&i.Timezone,
+		&i.UpdateTime,
+		&i.State,
+		&i.CloseTime,
+	)
+	return i, err
+}
+
+const updateBillingAccountState = `-- name: UpdateBillingAccountState :one
+UPDATE "billing_account"
+SET state = $1,
+    close_time = CASE WHEN $1 = 'closed' THEN NOW() ELSE close_time END,
+    update_time = NOW()
+WHERE id = $2
+RETURNING id, create_time, supply_enabled, demand_enabled, display_name, contact_emails, address_line1, address_line2, address_city, address_region, address_postal_code, address_country_code, tax_id, currency, timezone, update_time, state, close_time
+`
+
+type UpdateBillingAccountStateParams struct {
+	State BillingAccountState
+	ID    string
+}
+
+func (q *Queries) UpdateBillingAccountState(ctx context.Context, arg UpdateBillingAccountStateParams) (BillingAccount, error) {
+	row := q.db.QueryRow(ctx, updateBillingAccountState, arg.State, arg.ID)
+	var i BillingAccount
+	err := row.Scan(
+		&i.ID,
+		&i.CreateTime,
+		&i.SupplyEnabled,
+		&i.DemandEnabled,
+		&i.DisplayName,
+		&i.ContactEmails,
+		&i.AddressLine1,
+		&i.AddressLine2,
+		&i.AddressCity,
+		&i.AddressRegion,
+		&i.AddressPostalCode,
+		&i.AddressCountryCode,
+		&i.TaxID,
+		&i.Currency,
+		&i.Timezone,
+		&i.UpdateTime,
+		&i.State,
+		&i.CloseTime,
 	)
 	return i, err
 }
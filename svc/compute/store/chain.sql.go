@@ -0,0 +1,292 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: chain.sql
+
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const findLastAuditEventLink = `-- name: FindLastAuditEventLink :one
+SELECT chain_seq::bigint AS chain_seq, hash
+FROM "audit_log"
+WHERE chain_seq IS NOT NULL
+ORDER BY chain_seq DESC
+LIMIT 1
+`
+
+type FindLastAuditEventLinkRow struct {
+	ChainSeq int64
+	Hash     []byte
+}
+
+func (q *Queries) FindLastAuditEventLink(ctx context.Context) (FindLastAuditEventLinkRow, error) {
+	row := q.db.QueryRow(ctx, findLastAuditEventLink)
+	var i FindLastAuditEventLinkRow
+	err := row.Scan(&i.ChainSeq, &i.Hash)
+	return i, err
+}
+
+const findLastBillingAccountSpendLink = `-- name: FindLastBillingAccountSpendLink :one
+SELECT chain_seq::bigint AS chain_seq, hash
+FROM "billing_account_spend"
+WHERE chain_seq IS NOT NULL
+ORDER BY chain_seq DESC
+LIMIT 1
+`
+
+type FindLastBillingAccountSpendLinkRow struct {
+	ChainSeq int64
+	Hash     []byte
+}
+
+func (q *Queries) FindLastBillingAccountSpendLink(ctx context.Context) (FindLastBillingAccountSpendLinkRow, error) {
+	row := q.db.QueryRow(ctx, findLastBillingAccountSpendLink)
+	var i FindLastBillingAccountSpendLinkRow
+	err := row.Scan(&i.ChainSeq, &i.Hash)
+	return i, err
+}
+
+const listAuditEventChain = `-- name: ListAuditEventChain :many
+SELECT id, create_time, method, principal, resource_name, request_id, diff, result_code, chain_seq, prev_hash, hash
+FROM "audit_log"
+WHERE chain_seq > $1::bigint
+ORDER BY chain_seq
+LIMIT $2
+`
+
+type ListAuditEventChainParams struct {
+	AfterSeq int64
+	RowLimit int32
+}
+
+func (q *Queries) ListAuditEventChain(ctx context.Context, arg ListAuditEventChainParams) ([]AuditLog, error) {
+	rows, err := q.db.Query(ctx, listAuditEventChain, arg.AfterSeq, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditLog
+	for rows.Next() {
+		var i AuditLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreateTime,
+			&i.Method,
+			&i.Principal,
+			&i.ResourceName,
+			&i.RequestID,
+			&i.Diff,
+			&i.ResultCode,
+			&i.ChainSeq,
+			&i.PrevHash,
+			&i.Hash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBillingAccountSpendChain = `-- name: ListBillingAccountSpendChain :many
+SELECT uid, billing_account_id, spend, start_time, end_time, credit, final, chain_seq, prev_hash, hash
+FROM "billing_account_spend"
+WHERE chain_seq > $1::bigint
+ORDER BY chain_seq
+LIMIT $2
+`
+
+type ListBillingAccountSpendChainParams struct {
+	AfterSeq int64
+	RowLimit int32
+}
+
+func (q *Queries) ListBillingAccountSpendChain(ctx context.Context, arg ListBillingAccountSpendChainParams) ([]BillingAccountSpend, error) {
+	rows, err := q.db.Query(ctx, listBillingAccountSpendChain, arg.AfterSeq, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BillingAccountSpend
+	for rows.Next() {
+		var i BillingAccountSpend
+		if err := rows.Scan(
+			&i.Uid,
+			&i.BillingAccountID,
+			&i.Spend,
+			&i.StartTime,
+			&i.EndTime,
+			&i.Credit,
+			&i.Final,
+			&i.ChainSeq,
+			&i.PrevHash,
+			&i.Hash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUnsealedAuditEvents = `-- name: ListUnsealedAuditEvents :many
+SELECT id, create_time, method, principal, resource_name, request_id, diff, result_code, chain_seq, prev_hash, hash
+FROM "audit_log"
+WHERE hash IS NULL
+ORDER BY create_time, id
+LIMIT $1
+`
+
+func (q *Queries) ListUnsealedAuditEvents(ctx context.Context, rowLimit int32) ([]AuditLog, error) {
+	rows, err := q.db.Query(ctx, listUnsealedAuditEvents, rowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditLog
+	for rows.Next() {
+		var i AuditLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreateTime,
+			&i.Method,
+			&i.Principal,
+			&i.ResourceName,
+			&i.RequestID,
+			&i.Diff,
+			&i.ResultCode,
+			&i.ChainSeq,
+			&i.PrevHash,
+			&i.Hash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUnsealedBillingAccountSpend = `-- name: ListUnsealedBillingAccountSpend :many
+SELECT uid, billing_account_id, spend, start_time, end_time, credit, final, chain_seq, prev_hash, hash
+FROM "billing_account_spend"
+WHERE hash IS NULL
+  AND (final OR end_time <= $1::timestamptz)
+ORDER BY end_time, billing_account_id
+LIMIT $2
+`
+
+type ListUnsealedBillingAccountSpendParams struct {
+	EndTime  time.Time
+	RowLimit int32
+}
+
+func (q *Queries) ListUnsealedBillingAccountSpend(ctx context.Context, arg ListUnsealedBillingAccountSpendParams) ([]BillingAccountSpend, error) {
+	rows, err := q.db.Query(ctx, listUnsealedBillingAccountSpend, arg.EndTime, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BillingAccountSpend
+	for rows.Next() {
+		var i BillingAccountSpend
+		if err := rows.Scan(
+			&i.Uid,
+			&i.BillingAccountID,
+			&i.Spend,
+			&i.StartTime,
+			&i.EndTime,
+			&i.Credit,
+			&i.Final,
+			&i.ChainSeq,
+			&i.PrevHash,
+			&i.Hash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockChain = `-- name: LockChain :exec
+SELECT pg_advisory_xact_lock(hashtext($1::varchar))
+`
+
+func (q *Queries) LockChain(ctx context.Context, chain string) error {
+	_, err := q.db.Exec(ctx, lockChain, chain)
+	return err
+}
+
+const sealAuditEvent = `-- name: SealAuditEvent :execrows
+UPDATE "audit_log"
+SET chain_seq = $1::bigint,
+    prev_hash = $2,
+    hash      = $3
+WHERE id = $4
+  AND hash IS NULL
+`
+
+type SealAuditEventParams struct {
+	ChainSeq int64
+	PrevHash []byte
+	Hash     []byte
+	ID       string
+}
+
+func (q *Queries) SealAuditEvent(ctx context.Context, arg SealAuditEventParams) (int64, error) {
+	result, err := q.db.Exec(ctx, sealAuditEvent,
+		arg.ChainSeq,
+		arg.PrevHash,
+		arg.Hash,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const sealBillingAccountSpend = `-- name: SealBillingAccountSpend :execrows
+UPDATE "billing_account_spend"
+SET chain_seq = $1::bigint,
+    prev_hash = $2,
+    hash      = $3
+WHERE uid = $4
+  AND hash IS NULL
+`
+
+type SealBillingAccountSpendParams struct {
+	ChainSeq int64
+	PrevHash []byte
+	Hash     []byte
+	Uid      uuid.UUID
+}
+
+func (q *Queries) SealBillingAccountSpend(ctx context.Context, arg SealBillingAccountSpendParams) (int64, error) {
+	result, err := q.db.Exec(ctx, sealBillingAccountSpend,
+		arg.ChainSeq,
+		arg.PrevHash,
+		arg.Hash,
+		arg.Uid,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,29 @@
+package store
+
+import (
+	"encoding/json"
+
+	"github.com/jackc/pgtype"
+)
+
+// Labels are stored as a JSON object of strings so they can be grouped on in queries
+
+func NewLabels(labels map[string]string) (pgtype.JSONB, error) {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	data, err := json.Marshal(labels)
+	if err != nil {
+		return pgtype.JSONB{}, err
+	}
+	return pgtype.JSONB{Bytes: data, Status: pgtype.Present}, nil
+}
+
+func LabelsMap(labels pgtype.JSONB) (map[string]string, error) {
+	if labels.Status != pgtype.Present {
+		return nil, nil
+	}
+	var out map[string]string
+	err := json.Unmarshal(labels.Bytes, &out)
+	return out, err
+}
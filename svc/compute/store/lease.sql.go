@@ -7,6 +7,8 @@ import (
 	"context"
 	"database/sql"
 	"time"
+
+	"github.com/jackc/pgtype"
 )
 
 const createLease = `-- name: CreateLease :one
@@ -61,40 +63,47 @@ INSERT INTO "order" (
   project_id,
   quantity,
   description,
-  price_hr
-)
-VALUES (
-  $1,
-  $2,
-  $3,
-  $4,
-  $5,
-  $6,
-  $7
+  price_hr,
+  labels
 )
+SELECT $1,
+       $2,
+       ba.id,
+       $3,
+       $4,
+       $5,
+       $6,
+       $7
+FROM "billing_account" ba
+WHERE ba.id = $8
+  AND ba.demand_enabled
+  AND ba.state = 'active'
 ON CONFLICT DO NOTHING
-RETURNING id, infra_type, project_id, quantity, description, status, create_time, price_hr, billing_account_id
+RETURNING id, infra_type, project_id, quantity, description, status, create_time, price_hr, billing_account_id, labels, end_time
 `
 
 type CreateOrderParams struct {
 	ID               string
 	InfraType        InfrastructureType
-	BillingAccountID string
 	ProjectID        string
 	Quantity         int32
 	Description      string
 	PriceHr          float64
+	Labels           pgtype.JSONB
+	BillingAccountID string
 }
 
+// orders can only be created for active billing accounts enabled for demand, no row is returned otherwise
 func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error) {
 	row := q.db.QueryRow(ctx, createOrder,
 		arg.ID,
 		arg.InfraType,
-		arg.BillingAccountID,
 		arg.ProjectID,
 		arg.Quantity,
 		arg.Description,
 		arg.PriceHr,
+		arg.Labels,
+		arg.BillingAccountID,
 	)
 	var i Order
 	err := row.Scan(
@@ -107,25 +116,28 @@ func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (Order
 		&i.CreateTime,
 		&i.PriceHr,
 		&i.BillingAccountID,
+		&i.Labels,
+		&i.EndTime,
 	)
 	return i, err
 }
 
 const endLease = `-- name: EndLease :one
 UPDATE "lease"
-SET end_time = NOW(),
-    status = $1
-WHERE id = $2
+SET end_time = $1::timestamptz,
+    status = $2
+WHERE id = $3
 RETURNING id, infra_type, order_id, create_time, end_time, price_hr, status
 `
 
 type EndLeaseParams struct {
-	Status LeaseStatus
-	ID     string
+	EndTime time.Time
+	Status  LeaseStatus
+	ID      string
 }
 
 func (q *Queries) EndLease(ctx context.Context, arg EndLeaseParams) (Lease, error) {
-	row := q.db.QueryRow(ctx, endLease, arg.Status, arg.ID)
+	row := q.db.QueryRow(ctx, endLease, arg.EndTime, arg.Status, arg.ID)
 	var i Lease
 	err := row.Scan(
 		&i.ID,
@@ -141,18 +153,20 @@ func (q *Queries) EndLease(ctx context.Context, arg EndLeaseParams) (Lease, erro
 
 const endOrder = `-- name: EndOrder :one
 UPDATE "order"
-SET status = $1
-WHERE id = $2
-RETURNING id, infra_type, project_id, quantity, description, status, create_time, price_hr, billing_account_id
+SET status   = $1,
+    end_time = $2
+WHERE id = $3
+RETURNING id, infra_type, project_id, quantity, description, status, create_time, price_hr, billing_account_id, labels, end_time
 `
 
 type EndOrderParams struct {
-	Status OrderStatus
-	ID     string
+	Status  OrderStatus
+	EndTime sql.NullTime
+	ID      string
 }
 
 func (q *Queries) EndOrder(ctx context.Context, arg EndOrderParams) (Order, error) {
-	row := q.db.QueryRow(ctx, endOrder, arg.Status, arg.ID)
+	row := q.db.QueryRow(ctx, endOrder, arg.Status, arg.EndTime, arg.ID)
 	var i Order
 	err := row.Scan(
 		&i.ID,
@@ -164,12 +178,14 @@ func (q *Queries) EndOrder(ctx context.Context, arg EndOrderParams) (Order, erro
 		&i.CreateTime,
 		&i.PriceHr,
 		&i.BillingAccountID,
+		&i.Labels,
+		&i.EndTime,
 	)
 	return i, err
 }
 
 const findLeaseInfoByLeaseId = `-- name: FindLeaseInfoByLeaseId :one
-SELECT lease.id, lease.infra_type, order_id, lease.create_time, end_time, lease.price_hr, lease.status, o.id, o.infra_type, project_id, quantity, description, o.status, o.create_time, o.price_hr, billing_account_id
+SELECT lease.id, lease.infra_type, order_id, lease.create_time, lease.end_time, lease.price_hr, lease.status, o.id, o.infra_type, project_id, quantity, description, o.status, o.create_time, o.price_hr, billing_account_id, labels, o.end_time
 FROM "lease" lease
          INNER JOIN "order" o ON lease.order_id = o.id
 WHERE lease.id = $1
@@ -193,6 +209,8 @@ type FindLeaseInfoByLeaseIdRow struct {
 	CreateTime_2     time.Time
 	PriceHr_2        float64
 	BillingAccountID string
+	Labels           pgtype.JSONB
+	EndTime_2        sql.NullTime
 }
 
 func (q *Queries) FindLeaseInfoByLeaseId(ctx context.Context, id string) (FindLeaseInfoByLeaseIdRow, error) {
@@ -215,6 +233,8 @@ func (q *Queries) FindLeaseInfoByLeaseId(ctx context.Context, id string) (FindLe
 		&i.CreateTime_2,
 		&i.PriceHr_2,
 		&i.BillingAccountID,
+		&i.Labels,
+		&i.EndTime_2,
 	)
 	return i, err
 }
@@ -254,6 +274,48 @@ func (q *Queries) ListActiveLeasesByOrderId(ctx context.Context, orderID string)
 	return items, nil
 }
 
+const listLeasesCreatedBeforeByOrderId = `-- name: ListLeasesCreatedBeforeByOrderId :many
+SELECT id, infra_type, order_id, create_time, end_time, price_hr, status
+FROM "lease" l
+WHERE l.order_id = $1
+  AND l.create_time < $2
+ORDER BY l.create_time
+`
+
+type ListLeasesCreatedBeforeByOrderIdParams struct {
+	OrderID string
+	EndTime time.Time
+}
+
+// a lease that failed before a window and was replaced within it, or never, is down for part of the window too
+func (q *Queries) ListLeasesCreatedBeforeByOrderId(ctx context.Context, arg ListLeasesCreatedBeforeByOrderIdParams) ([]Lease, error) {
+	rows, err := q.db.Query(ctx, listLeasesCreatedBeforeByOrderId, arg.OrderID, arg.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Lease
+	for rows.Next() {
+		var i Lease
+		if err := rows.Scan(
+			&i.ID,
+			&i.InfraType,
+			&i.OrderID,
+			&i.CreateTime,
+			&i.EndTime,
+			&i.PriceHr,
+			&i.Status,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listLeasesForTimeRangeByOrderId = `-- name: ListLeasesForTimeRangeByOrderId :many
 SELECT id, infra_type, order_id, create_time, end_time, price_hr, status
 FROM "lease" l
@@ -297,8 +359,47 @@ func (q *Queries) ListLeasesForTimeRangeByOrderId(ctx context.Context, arg ListL
 	return items, nil
 }
 
+const listOrderTransfers = `-- name: ListOrderTransfers :many
+SELECT t.from_billing_account_id, t.to_billing_account_id, t.transfer_time
+FROM "project_transfer" t
+WHERE $1::varchar = ANY(t.order_ids)
+  AND t.transfer_time >= $2::timestamptz
+ORDER BY t.transfer_time
+`
+
+type ListOrderTransfersParams struct {
+	OrderID   string
+	StartTime time.Time
+}
+
+type ListOrderTransfersRow struct {
+	FromBillingAccountID string
+	ToBillingAccountID   string
+	TransferTime         time.Time
+}
+
+func (q *Queries) ListOrderTransfers(ctx context.Context, arg ListOrderTransfersParams) ([]ListOrderTransfersRow, error) {
+	rows, err := q.db.Query(ctx, listOrderTransfers, arg.OrderID, arg.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOrderTransfersRow
+	for rows.Next() {
+		var i ListOrderTransfersRow
+		if err := rows.Scan(&i.FromBillingAccountID, &i.ToBillingAccountID, &i.TransferTime); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listOrdersByBillingAccountId = `-- name: ListOrdersByBillingAccountId :many
-SELECT id, infra_type, project_id, quantity, description, status, create_time, price_hr, billing_account_id
+SELECT id, infra_type, project_id, quantity, description, status, create_time, price_hr, billing_account_id, labels, end_time
 FROM "order" o
 WHERE o.billing_account_id = $1
 `
@@ -322,6 +423,8 @@ func (q *Queries) ListOrdersByBillingAccountId(ctx context.Context, billingAccou
 			&i.CreateTime,
 			&i.PriceHr,
 			&i.BillingAccountID,
+			&i.Labels,
+			&i.EndTime,
 		); err != nil {
 			return nil, err
 		}
@@ -334,7 +437,7 @@ func (q *Queries) ListOrdersByBillingAccountId(ctx context.Context, billingAccou
 }
 
 const listOrdersByProjectId = `-- name: ListOrdersByProjectId :many
-SELECT id, infra_type, project_id, quantity, description, status, create_time, price_hr, billing_account_id
+SELECT id, infra_type, project_id, quantity, description, status, create_time, price_hr, billing_account_id, labels, end_time
 FROM "order" o
 WHERE o.project_id = $1
 `
@@ -358,6 +461,54 @@ func (q *Queries) ListOrdersByProjectId(ctx context.Context, projectID string) (
 			&i.CreateTime,
 			&i.PriceHr,
 			&i.BillingAccountID,
+			&i.Labels,
+			&i.EndTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrdersTransferredFromBillingAccount = `-- name: ListOrdersTransferredFromBillingAccount :many
+SELECT DISTINCT o.id, o.infra_type, o.project_id, o.quantity, o.description, o.status, o.create_time, o.price_hr, o.billing_account_id, o.labels, o.end_time
+FROM "order" o
+         INNER JOIN "project_transfer" t ON o.id = ANY(t.order_ids)
+WHERE t.from_billing_account_id = $1
+  AND t.transfer_time >= $2::timestamptz
+  AND o.billing_account_id <> $1
+`
+
+type ListOrdersTransferredFromBillingAccountParams struct {
+	BillingAccountID string
+	StartTime        time.Time
+}
+
+func (q *Queries) ListOrdersTransferredFromBillingAccount(ctx context.Context, arg ListOrdersTransferredFromBillingAccountParams) ([]Order, error) {
+	rows, err := q.db.Query(ctx, listOrdersTransferredFromBillingAccount, arg.BillingAccountID, arg.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Order
+	for rows.Next() {
+		var i Order
+		if err := rows.Scan(
+			&i.ID,
+			&i.InfraType,
+			&i.ProjectID,
+			&i.Quantity,
+			&i.Description,
+			&i.Status,
+			&i.CreateTime,
+			&i.PriceHr,
+			&i.BillingAccountID,
+			&i.Labels,
+			&i.EndTime,
 		); err != nil {
 			return nil, err
 		}
@@ -0,0 +1,452 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"biller/lib/filter"
+	"biller/lib/pagination"
+
+	"github.com/cockroachdb/apd/v2"
+)
+
+// List queries take a filter and order chosen by the caller so they can't be generated by sqlc. The filter and
+// order have already been checked against the fields the listing allows, every value is passed as an argument.
+// They start with a name comment like the generated queries so they're named in traces.
+
+type ListParams struct {
+	Filter  *filter.Filter
+	OrderBy filter.OrderBy
+	Cursor  pagination.Cursor
+	Limit   int32
+}
+
+// listQuery adds the filter, order and page to a select. Without an order the listing seeks on (create_time, id),
+// otherwise it is ordered by the requested fields with id as a tie breaker and skips the rows already returned.
+func listQuery(query string, where []string, arg ListParams, args *filter.Args) string {
+	if arg.Filter != nil {
+		where = append(where, arg.Filter.SQL(args))
+	}
+
+	orderBy := "create_time, id"
+	if len(arg.OrderBy) == 0 {
+		where = append(where, fmt.Sprintf("(create_time, id) > (%s::timestamptz, %s::varchar)", args.Add(arg.Cursor.CreateTime), args.Add(arg.Cursor.ID)))
+	} else {
+		orderBy = arg.OrderBy.SQL()
+		if !arg.OrderBy.Has("id") {
+			orderBy += ", id"
+		}
+	}
+
+	if len(where) > 0 {
+		query += "\nWHERE " + strings.Join(where, "\n  AND ")
+	}
+	query += "\nORDER BY " + orderBy
+	if arg.Limit > 0 {
+		query += "\nLIMIT " + args.Add(arg.Limit)
+	}
+	if len(arg.OrderBy) > 0 && arg.Cursor.Offset > 0 {
+		query += "\nOFFSET " + args.Add(arg.Cursor.Offset)
+	}
+	return query
+}
+
+const listBillingAccounts = `-- name: ListBillingAccounts :many
+SELECT id, create_time, supply_enabled, demand_enabled, display_name, contact_emails, address_line1,
+       address_line2, address_city, address_region, address_postal_code, address_country_code, tax_id, currency,
+       timezone, update_time, state, close_time
+FROM "billing_account"`
+
+type ListBillingAccountsParams struct {
+	ListParams
+	AllBillingAccounts bool
+	BillingAccountIds  []string
+}
+
+func (q *Queries) ListBillingAccounts(ctx context.Context, arg ListBillingAccountsParams) ([]BillingAccount, error) {
+	var args filter.Args
+	where := []string{
+		fmt.Sprintf("(%s::boolean OR id = ANY(%s::varchar[]))", args.Add(arg.AllBillingAccounts), args.Add(arg.BillingAccountIds)),
+	}
+	rows, err := q.db.Query(ctx, listQuery(listBillingAccounts, where, arg.ListParams, &args), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BillingAccount
+	for rows.Next() {
+		var i BillingAccount
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreateTime,
+			&i.SupplyEnabled,
+			&i.DemandEnabled,
+			&i.DisplayName,
+			&i.ContactEmails,
+			&i.AddressLine1,
+			&i.AddressLine2,
+			&i.AddressCity,
+			&i.AddressRegion,
+			&i.AddressPostalCode,
+			&i.AddressCountryCode,
+			&i.TaxID,
+			&i.Currency,
+			&i.Timezone,
+			&i.UpdateTime,
+			&i.State,
+			&i.CloseTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProjects = `-- name: ListProjects :many
+SELECT id, create_time, billing_account_id, delete_time, purge_time, display_name, description, labels, update_time, version
+FROM "project"`
+
+type ListProjectsParams struct {
+	ListParams
+	AllBillingAccounts bool
+	BillingAccountIds  []string
+	ShowDeleted        bool
+}
+
+func (q *Queries) ListProjects(ctx context.Context, arg ListProjectsParams) ([]Project, error) {
+	var args filter.Args
+	where := []string{
+		fmt.Sprintf("(%s::boolean OR billing_account_id = ANY(%s::varchar[]))", args.Add(arg.AllBillingAccounts), args.Add(arg.BillingAccountIds)),
+	}
+	if !arg.ShowDeleted {
+		where = append(where, "delete_time IS NULL")
+	}
+	rows, err := q.db.Query(ctx, listQuery(listProjects, where, arg.ListParams, &args), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Project
+	for rows.Next() {
+		var i Project
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreateTime,
+			&i.BillingAccountID,
+			&i.DeleteTime,
+			&i.PurgeTime,
+			&i.DisplayName,
+			&i.Description,
+			&i.Labels,
+			&i.UpdateTime,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrders = `-- name: ListOrders :many
+SELECT id, infra_type, project_id, quantity, description, status, create_time, price_hr, billing_account_id, labels,
+       end_time
+FROM "order"`
+
+type ListOrdersParams struct {
+	ListParams
+	ProjectID          string
+	AllBillingAccounts bool
+	BillingAccountIds  []string
+}
+
+func (q *Queries) ListOrders(ctx context.Context, arg ListOrdersParams) ([]Order, error) {
+	var args filter.Args
+	where := []string{
+		"project_id = " + args.Add(arg.ProjectID),
+		fmt.Sprintf("(%s::boolean OR billing_account_id = ANY(%s::varchar[]))", args.Add(arg.AllBillingAccounts), args.Add(arg.BillingAccountIds)),
+	}
+	rows, err := q.db.Query(ctx, listQuery(listOrders, where, arg.ListParams, &args), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Order
+	for rows.Next() {
+		var i Order
+		if err := rows.Scan(
+			&i.ID,
+			&i.InfraType,
+			&i.ProjectID,
+			&i.Quantity,
+			&i.Description,
+			&i.Status,
+			&i.CreateTime,
+			&i.PriceHr,
+			&i.BillingAccountID,
+			&i.Labels,
+			&i.EndTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProjectSpend = `-- name: ListProjectSpend :many
+SELECT uid, project_id, billing_account_id, spend, start_time, end_time
+FROM "project_spend"`
+
+type ListProjectSpendParams struct {
+	ProjectID string
+	// only the billing periods overlapping StartTime to EndTime, unbounded when zero
+	StartTime time.Time
+	EndTime   time.Time
+	Filter    *filter.Filter
+	OrderBy   filter.OrderBy
+	Limit     int32
+	Offset    int32
+}
+
+// ListProjectSpend returns the spend history of a project, most recent first unless another order is requested
+func (q *Queries) ListProjectSpend(ctx context.Context, arg ListProjectSpendParams) ([]ProjectSpend, error) {
+	var args filter.Args
+	query := listProjectSpend + "\nWHERE project_id = " + args.Add(arg.ProjectID)
+	if !arg.StartTime.IsZero() {
+		query += "\n  AND end_time > " + args.Add(arg.StartTime) + "::timestamptz"
+	}
+	if !arg.EndTime.IsZero() {
+		query += "\n  AND start_time < " + args.Add(arg.EndTime) + "::timestamptz"
+	}
+	if arg.Filter != nil {
+		query += "\n  AND " + arg.Filter.SQL(&args)
+	}
+	orderBy := "start_time DESC, uid"
+	if len(arg.OrderBy) > 0 {
+		orderBy = arg.OrderBy.SQL() + ", uid"
+	}
+	query += "\nORDER BY " + orderBy
+	if arg.Limit > 0 {
+		query += "\nLIMIT " + args.Add(arg.Limit)
+	}
+	if arg.Offset > 0 {
+		query += "\nOFFSET " + args.Add(arg.Offset)
+	}
+
+	rows, err := q.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ProjectSpend
+	for rows.Next() {
+		var i ProjectSpend
+		if err := rows.Scan(
+			&i.Uid,
+			&i.ProjectID,
+			&i.BillingAccountID,
+			&i.Spend,
+			&i.StartTime,
+			&i.EndTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBillingAccountSpend = `-- name: ListBillingAccountSpend :many
+SELECT uid, billing_account_id, spend, start_time, end_time, credit, final, chain_seq, prev_hash, hash
+FROM "billing_account_spend"`
+
+type ListBillingAccountSpendParams struct {
+	BillingAccountID string
+	// only the billing periods overlapping StartTime to EndTime
+	StartTime time.Time
+	EndTime   time.Time
+	Filter    *filter.Filter
+	OrderBy   filter.OrderBy
+	Limit     int32
+	Offset    int32
+}
+
+// ListBillingAccountSpend returns the spend history of a billing account, most recent first unless another order is
+// requested
+func (q *Queries) ListBillingAccountSpend(ctx context.Context, arg ListBillingAccountSpendParams) ([]BillingAccountSpend, error) {
+	var args filter.Args
+	query := listBillingAccountSpend + "\nWHERE billing_account_id = " + args.Add(arg.BillingAccountID) +
+		"\n  AND start_time < " + args.Add(arg.EndTime) + "::timestamptz" +
+		"\n  AND end_time > " + args.Add(arg.StartTime) + "::timestamptz"
+	if arg.Filter != nil {
+		query += "\n  AND " + arg.Filter.SQL(&args)
+	}
+	orderBy := "start_time DESC, uid"
+	if len(arg.OrderBy) > 0 {
+		orderBy = arg.OrderBy.SQL() + ", uid"
+	}
+	query += "\nORDER BY " + orderBy
+	if arg.Limit > 0 {
+		query += "\nLIMIT " + args.Add(arg.Limit)
+	}
+	if arg.Offset > 0 {
+		query += "\nOFFSET " + args.Add(arg.Offset)
+	}
+
+	rows, err := q.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BillingAccountSpend
+	for rows.Next() {
+		var i BillingAccountSpend
+		if err := rows.Scan(
+			&i.Uid,
+			&i.BillingAccountID,
+			&i.Spend,
+			&i.StartTime,
+			&i.EndTime,
+			&i.Credit,
+			&i.Final,
+			&i.ChainSeq,
+			&i.PrevHash,
+			&i.Hash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// the order spend is joined to its order in a subquery so the filter and order see plain column names
+const listOrderSpendForBillingAccount = `-- name: ListOrderSpendForBillingAccount :many
+SELECT order_id, project_id, infra_type, spend
+FROM (SELECT os.order_id, o.project_id, o.infra_type, os.spend, os.billing_account_id, os.start_time, os.end_time
+      FROM "order_spend" os
+               INNER JOIN "order" o ON os.order_id = o.id) order_spend`
+
+type ListOrderSpendForBillingAccountParams struct {
+	BillingAccountID string
+	StartTime        time.Time
+	EndTime          time.Time
+	// only the orders of the project when set
+	ProjectID string
+	Filter    *filter.Filter
+	OrderBy   filter.OrderBy
+	Limit     int32
+	Offset    int32
+}
+
+type ListOrderSpendForBillingAccountRow struct {
+	OrderID   string
+	ProjectID string
+	InfraType InfrastructureType
+	Spend     apd.Decimal
+}
+
+// ListOrderSpendForBillingAccount returns the spend of the orders billed to a billing account in a billing period,
+// by project unless another order is requested
+func (q *Queries) ListOrderSpendForBillingAccount(ctx context.Context, arg ListOrderSpendForBillingAccountParams) ([]ListOrderSpendForBillingAccountRow, error) {
+	var args filter.Args
+	query := listOrderSpendForBillingAccount + "\nWHERE billing_account_id = " + args.Add(arg.BillingAccountID) +
+		"\n  AND start_time = " + args.Add(arg.StartTime) + "::timestamptz" +
+		"\n  AND end_time = " + args.Add(arg.EndTime) + "::timestamptz"
+	if arg.ProjectID != "" {
+		query += "\n  AND project_id = " + args.Add(arg.ProjectID)
+	}
+	if arg.Filter != nil {
+		query += "\n  AND " + arg.Filter.SQL(&args)
+	}
+	orderBy := "project_id, order_id"
+	if len(arg.OrderBy) > 0 {
+		orderBy = arg.OrderBy.SQL()
+		if !arg.OrderBy.Has("order_id") {
+			orderBy += ", order_id"
+		}
+	}
+	query += "\nORDER BY " + orderBy
+	if arg.Limit > 0 {
+		query += "\nLIMIT " + args.Add(arg.Limit)
+	}
+	if arg.Offset > 0 {
+		query += "\nOFFSET " + args.Add(arg.Offset)
+	}
+
+	rows, err := q.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOrderSpendForBillingAccountRow
+	for rows.Next() {
+		var i ListOrderSpendForBillingAccountRow
+		if err := rows.Scan(
+			&i.OrderID,
+			&i.ProjectID,
+			&i.InfraType,
+			&i.Spend,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuditEvents = `-- name: ListAuditEvents :many
+SELECT id, create_time, method, principal, resource_name, request_id, diff, result_code
+FROM "audit_log"`
+
+func (q *Queries) ListAuditEvents(ctx context.Context, arg ListParams) ([]AuditLog, error) {
+	var args filter.Args
+	rows, err := q.db.Query(ctx, listQuery(listAuditEvents, nil, arg, &args), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditLog
+	for rows.Next() {
+		var i AuditLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreateTime,
+			&i.Method,
+			&i.Principal,
+			&i.ResourceName,
+			&i.RequestID,
+			&i.Diff,
+			&i.ResultCode,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,168 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: member.sql
+
+package store
+
+import (
+	"context"
+)
+
+const countBillingAccountOwners = `-- name: CountBillingAccountOwners :one
+SELECT COUNT(*)
+FROM "billing_account_member"
+WHERE billing_account_id = $1
+  AND role = 'owner'
+`
+
+func (q *Queries) CountBillingAccountOwners(ctx context.Context, billingAccountID string) (int64, error) {
+	row := q.db.QueryRow(ctx, countBillingAccountOwners, billingAccountID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const deleteBillingAccountMember = `-- name: DeleteBillingAccountMember :execrows
+DELETE
+FROM "billing_account_member"
+WHERE billing_account_id = $1
+  AND principal_id = $2
+`
+
+type DeleteBillingAccountMemberParams struct {
+	BillingAccountID string
+	PrincipalID      string
+}
+
+func (q *Queries) DeleteBillingAccountMember(ctx context.Context, arg DeleteBillingAccountMemberParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteBillingAccountMember, arg.BillingAccountID, arg.PrincipalID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const findBillingAccountMember = `-- name: FindBillingAccountMember :one
+SELECT billing_account_id, principal_id, role, create_time, update_time
+FROM "billing_account_member"
+WHERE billing_account_id = $1
+  AND principal_id = $2
+`
+
+type FindBillingAccountMemberParams struct {
+	BillingAccountID string
+	PrincipalID      string
+}
+
+func (q *Queries) FindBillingAccountMember(ctx context.Context, arg FindBillingAccountMemberParams) (BillingAccountMember, error) {
+	row := q.db.QueryRow(ctx, findBillingAccountMember, arg.BillingAccountID, arg.PrincipalID)
+	var i BillingAccountMember
+	err := row.Scan(
+		&i.BillingAccountID,
+		&i.PrincipalID,
+		&i.Role,
+		&i.CreateTime,
+		&i.UpdateTime,
+	)
+	return i, err
+}
+
+const listBillingAccountMembers = `-- name: ListBillingAccountMembers :many
+SELECT billing_account_id, principal_id, role, create_time, update_time
+FROM "billing_account_member"
+WHERE billing_account_id = $1
+ORDER BY create_time, principal_id
+LIMIT $3 OFFSET $2
+`
+
+type ListBillingAccountMembersParams struct {
+	BillingAccountID string
+	RowOffset        int32
+	RowLimit         int32
+}
+
+func (q *Queries) ListBillingAccountMembers(ctx context.Context, arg ListBillingAccountMembersParams) ([]BillingAccountMember, error) {
+	rows, err := q.db.Query(ctx, listBillingAccountMembers, arg.BillingAccountID, arg.RowOffset, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BillingAccountMember
+	for rows.Next() {
+		var i BillingAccountMember
+		if err := rows.Scan(
+			&i.BillingAccountID,
+			&i.PrincipalID,
+			&i.Role,
+			&i.CreateTime,
+			&i.UpdateTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBillingAccountMembershipsByPrincipalId = `-- name: ListBillingAccountMembershipsByPrincipalId :many
+SELECT billing_account_id, principal_id, role, create_time, update_time
+FROM "billing_account_member"
+WHERE principal_id = $1
+ORDER BY billing_account_id
+`
+
+func (q *Queries) ListBillingAccountMembershipsByPrincipalId(ctx context.Context, principalID string) ([]BillingAccountMember, error) {
+	rows, err := q.db.Query(ctx, listBillingAccountMembershipsByPrincipalId, principalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BillingAccountMember
+	for rows.Next() {
+		var i BillingAccountMember
+		if err := rows.Scan(
+			&i.BillingAccountID,
+			&i.PrincipalID,
+			&i.Role,
+			&i.CreateTime,
+			&i.UpdateTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setBillingAccountMember = `-- name: SetBillingAccountMember :one
+INSERT INTO "billing_account_member" (billing_account_id, principal_id, role)
+VALUES ($1, $2, $3)
+ON CONFLICT (billing_account_id, principal_id)
+  DO UPDATE SET role = $3,
+                update_time = NOW()
+RETURNING billing_account_id, principal_id, role, create_time, update_time
+`
+
+type SetBillingAccountMemberParams struct {
+	BillingAccountID string
+	PrincipalID      string
+	Role             BillingAccountRole
+}
+
+func (q *Queries) SetBillingAccountMember(ctx context.Context, arg SetBillingAccountMemberParams) (BillingAccountMember, error) {
+	row := q.db.QueryRow(ctx, setBillingAccountMember, arg.BillingAccountID, arg.PrincipalID, arg.Role)
+	var i BillingAccountMember
+	err := row.Scan(
+		&i.BillingAccountID,
+		&i.PrincipalID,
+		&i.Role,
+		&i.CreateTime,
+		&i.UpdateTime,
+	)
+	return i, err
+}
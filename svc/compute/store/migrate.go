@@ -0,0 +1,127 @@
+package store
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/pgx"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/jackc/pgx/v4/stdlib"
+	"go.uber.org/zap"
+)
+
+// The migrations are built into the binary so it can bring the database it's given up to the schema its queries are
+// written for. They're applied with golang-migrate, which keeps the version in schema_migrations and holds a postgres
+// advisory lock while it migrates, so instances migrating at the same time wait for each other.
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// SchemaVersion is the version of the last migration, the schema the queries are written for
+var SchemaVersion = lastMigrationVersion(migrations)
+
+// lastMigrationVersion returns the version of the last migration in migrations, they're named {version}_{title}.up.sql
+func lastMigrationVersion(migrations fs.FS) int64 {
+	files, err := fs.Glob(migrations, "migrations/*.up.sql")
+	if err != nil {
+		panic(err)
+	}
+	var last int64
+	for _, file := range files {
+		name := strings.TrimPrefix(file, "migrations/")
+		version, err := strconv.ParseInt(name[:strings.Index(name, "_")], 10, 64)
+		if err != nil {
+			panic(fmt.Sprintf("migration %s isn't named after its version", name))
+		}
+		if version > last {
+			last = version
+		}
+	}
+	return last
+}
+
+type Migrator struct {
+	migrate *migrate.Migrate
+}
+
+// NewMigrator returns a migrator for the database pool connects to, using a connection of its own
+func NewMigrator(pool *pgxpool.Pool, log *zap.Logger) (*Migrator, error) {
+	source, err := iofs.New(migrations, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+	db := stdlib.OpenDB(*pool.Config().ConnConfig)
+	driver, err := pgx.WithInstance(db, &pgx.Config{})
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to connect to postgresql: %w", err)
+	}
+	m, err := migrate.NewWithInstance("iofs", source, "postgresql", driver)
+	if err != nil {
+		_ = driver.Close()
+		return nil, fmt.Errorf("failed to create migrator: %w", err)
+	}
+	m.Log = migrateLogger{log: log}
+	return &Migrator{migrate: m}, nil
+}
+
+// Up applies every migration that hasn't been applied
+func (m *Migrator) Up() error {
+	return ignoreNoChange(m.migrate.Up())
+}
+
+// Down reverts the last n migrations
+func (m *Migrator) Down(n int) error {
+	if n <= 0 {
+		return fmt.Errorf("the number of migrations to revert must be positive, got %d", n)
+	}
+	return ignoreNoChange(m.migrate.Steps(-n))
+}
+
+// Version returns the version of the last migration applied, 0 when none were, and whether it failed part way
+func (m *Migrator) Version() (uint, bool, error) {
+	version, dirty, err := m.migrate.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
+
+// Force sets the version without running any migration, to recover from a migration that failed part way once the
+// database has been fixed by hand
+func (m *Migrator) Force(version int) error {
+	return m.migrate.Force(version)
+}
+
+func (m *Migrator) Close() error {
+	sourceErr, dbErr := m.migrate.Close()
+	if sourceErr != nil {
+		return sourceErr
+	}
+	return dbErr
+}
+
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+	return err
+}
+
+type migrateLogger struct {
+	log *zap.Logger
+}
+
+func (l migrateLogger) Printf(format string, v ...interface{}) {
+	l.log.Info(strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (l migrateLogger) Verbose() bool {
+	return false
+}
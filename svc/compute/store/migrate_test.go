@@ -0,0 +1,29 @@
+package store
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+func Test_lastMigrationVersion(t *testing.T) {
+	if SchemaVersion != 18 {
+		t.Errorf("expected: 18, got: %d", SchemaVersion)
+	}
+}
+
+func Test_migrations(t *testing.T) {
+	ups, err := fs.Glob(migrations, "migrations/*.up.sql")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ups) != int(SchemaVersion) {
+		t.Errorf("expected a migration for every version up to %d, got: %d", SchemaVersion, len(ups))
+	}
+	for _, up := range ups {
+		down := strings.TrimSuffix(up, ".up.sql") + ".down.sql"
+		if _, err := fs.Stat(migrations, down); err != nil {
+			t.Errorf("expected %s to have a down migration: %v", up, err)
+		}
+	}
+}
@@ -0,0 +1,5 @@
+ALTER TABLE billing_account_spend DROP COLUMN credit;
+
+DROP TABLE IF EXISTS sla_credit;
+
+DROP TABLE IF EXISTS sla_tier;
@@ -0,0 +1,42 @@
+CREATE TABLE sla_tier
+(
+    uid                UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+    infra_type         infrastructure_type                        NOT NULL,
+    availability_below NUMERIC(7,4)                               NOT NULL CHECK (availability_below > 0 AND availability_below <= 100),
+    credit_percent     NUMERIC(7,4)                               NOT NULL CHECK (credit_percent >= 0 AND credit_percent <= 100)
+);
+
+CREATE UNIQUE INDEX sla_tier_infra_type_availability_below ON sla_tier(infra_type, availability_below);
+
+-- default tiers, a credit applies when monthly availability drops below availability_below
+INSERT INTO sla_tier (infra_type, availability_below, credit_percent)
+VALUES
+    ('dedicated', 99.95, 10),
+    ('dedicated', 99.0, 25),
+    ('dedicated', 95.0, 100),
+    ('shared', 99.9, 10),
+    ('shared', 99.0, 25),
+    ('shared', 95.0, 100),
+    ('storage', 99.9, 10),
+    ('storage', 99.0, 25),
+    ('storage', 95.0, 100);
+
+CREATE TABLE sla_credit
+(
+    uid                UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+    order_id           VARCHAR REFERENCES "order" (id)            NOT NULL,
+    billing_account_id VARCHAR REFERENCES billing_account (id)    NOT NULL,
+    start_time         TIMESTAMPTZ                                NOT NULL, -- period the availability was measured over
+    end_time           TIMESTAMPTZ                                NOT NULL,
+    availability       NUMERIC(7,4)                               NOT NULL,
+    credit_percent     NUMERIC(7,4)                               NOT NULL,
+    credit             NUMERIC(65,18)                             NOT NULL,
+    apply_start_time   TIMESTAMPTZ                                NOT NULL, -- billing period the credit is issued against
+    apply_end_time     TIMESTAMPTZ                                NOT NULL,
+    details            JSONB                                      NOT NULL,
+    create_time        TIMESTAMPTZ      DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX sla_credit_order_id_start_time_end_time ON sla_credit(order_id, start_time, end_time);
+
+ALTER TABLE billing_account_spend ADD COLUMN credit NUMERIC(65,18) DEFAULT 0 NOT NULL;
@@ -0,0 +1,3 @@
+DROP INDEX project_billing_account_id_create_time_id;
+DROP INDEX project_create_time_id;
+DROP INDEX billing_account_create_time_id;
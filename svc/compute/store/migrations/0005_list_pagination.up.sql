@@ -0,0 +1,3 @@
+CREATE INDEX billing_account_create_time_id ON billing_account(create_time, id);
+CREATE INDEX project_create_time_id ON project(create_time, id);
+CREATE INDEX project_billing_account_id_create_time_id ON project(billing_account_id, create_time, id);
@@ -0,0 +1,8 @@
+DROP TABLE project_spend_archive;
+DROP TABLE project_archive;
+
+DROP INDEX project_purge_time;
+
+ALTER TABLE project
+    DROP COLUMN purge_time,
+    DROP COLUMN delete_time;
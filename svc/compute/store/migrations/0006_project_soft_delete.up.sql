@@ -0,0 +1,29 @@
+ALTER TABLE project
+    ADD COLUMN delete_time TIMESTAMPTZ NULL,
+    ADD COLUMN purge_time  TIMESTAMPTZ NULL;
+
+CREATE INDEX project_purge_time ON project(purge_time) WHERE delete_time IS NOT NULL;
+
+-- purged projects, data holds the project row with its orders, leases, order spend and sla credits
+CREATE TABLE project_archive
+(
+    id                 VARCHAR PRIMARY KEY                        NOT NULL,
+    billing_account_id VARCHAR REFERENCES billing_account (id)    NOT NULL,
+    create_time        TIMESTAMPTZ                                NOT NULL,
+    delete_time        TIMESTAMPTZ                                NOT NULL,
+    purge_time         TIMESTAMPTZ                                NOT NULL,
+    data               JSONB                                      NOT NULL,
+    archive_time       TIMESTAMPTZ      DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE project_spend_archive
+(
+    uid          UUID PRIMARY KEY                           NOT NULL,
+    project_id   VARCHAR REFERENCES project_archive (id)    NOT NULL,
+    spend        NUMERIC(65,18)                             NOT NULL,
+    start_time   TIMESTAMPTZ                                NOT NULL,
+    end_time     TIMESTAMPTZ                                NOT NULL,
+    archive_time TIMESTAMPTZ      DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX project_spend_archive_project_id_start_time ON project_spend_archive(project_id, start_time);
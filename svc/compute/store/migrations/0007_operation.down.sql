@@ -0,0 +1,4 @@
+DROP TABLE operation;
+
+DROP TYPE operation_status;
+DROP TYPE operation_type;
@@ -0,0 +1,17 @@
+CREATE TYPE operation_type AS ENUM ('delete_project');
+
+CREATE TYPE operation_status AS ENUM ('running', 'succeeded', 'failed');
+
+-- long running changes to a project, kept after the project has been purged
+CREATE TABLE operation
+(
+    uid           UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+    type          operation_type                             NOT NULL,
+    project_id    VARCHAR                                    NOT NULL,
+    status        operation_status DEFAULT 'running'         NOT NULL,
+    error_message VARCHAR          DEFAULT ''                NOT NULL,
+    create_time   TIMESTAMPTZ      DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    end_time      TIMESTAMPTZ                                NULL
+);
+
+CREATE INDEX operation_project_id ON operation(project_id);
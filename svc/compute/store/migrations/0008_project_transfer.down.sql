@@ -0,0 +1,15 @@
+DROP TABLE project_transfer;
+
+ALTER TABLE project_spend_archive DROP COLUMN billing_account_id;
+
+DROP INDEX project_id_billing_account_id_start_time_end_time;
+
+ALTER TABLE project_spend DROP COLUMN billing_account_id;
+
+CREATE UNIQUE INDEX project_id_start_time_end_time ON project_spend(project_id, start_time, end_time);
+
+DROP INDEX order_id_billing_account_id_start_time_end_time;
+
+ALTER TABLE order_spend DROP COLUMN billing_account_id;
+
+CREATE UNIQUE INDEX order_id_start_time_end_time ON order_spend(order_id, start_time, end_time);
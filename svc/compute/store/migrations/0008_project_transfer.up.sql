@@ -0,0 +1,49 @@
+-- spend is charged to the billing account that owned the order at the time, an order or project moved to another
+-- billing account during a period has a spend row for each account
+ALTER TABLE order_spend
+    ADD COLUMN billing_account_id VARCHAR REFERENCES billing_account (id) NULL;
+
+UPDATE order_spend SET billing_account_id = o.billing_account_id
+    FROM "order" o
+    WHERE order_id = o.id;
+
+ALTER TABLE order_spend ALTER COLUMN billing_account_id SET NOT NULL;
+
+DROP INDEX order_id_start_time_end_time;
+
+CREATE UNIQUE INDEX order_id_billing_account_id_start_time_end_time ON order_spend(order_id, billing_account_id, start_time, end_time);
+
+ALTER TABLE project_spend
+    ADD COLUMN billing_account_id VARCHAR REFERENCES billing_account (id) NULL;
+
+UPDATE project_spend SET billing_account_id = p.billing_account_id
+    FROM project p
+    WHERE project_id = p.id;
+
+ALTER TABLE project_spend ALTER COLUMN billing_account_id SET NOT NULL;
+
+DROP INDEX project_id_start_time_end_time;
+
+CREATE UNIQUE INDEX project_id_billing_account_id_start_time_end_time ON project_spend(project_id, billing_account_id, start_time, end_time);
+
+ALTER TABLE project_spend_archive
+    ADD COLUMN billing_account_id VARCHAR REFERENCES billing_account (id) NULL;
+
+UPDATE project_spend_archive SET billing_account_id = p.billing_account_id
+    FROM project_archive p
+    WHERE project_id = p.id;
+
+-- projects moved between billing accounts, order_ids are the open orders that moved with the project. The history
+-- is kept after the project has been purged.
+CREATE TABLE project_transfer
+(
+    uid                     UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+    project_id              VARCHAR                                    NOT NULL,
+    from_billing_account_id VARCHAR REFERENCES billing_account (id)    NOT NULL,
+    to_billing_account_id   VARCHAR REFERENCES billing_account (id)    NOT NULL,
+    order_ids               VARCHAR[]                                  NOT NULL,
+    transfer_time           TIMESTAMPTZ                                NOT NULL
+);
+
+CREATE INDEX project_transfer_project_id_transfer_time ON project_transfer(project_id, transfer_time);
+CREATE INDEX project_transfer_order_ids ON project_transfer USING GIN (order_ids);
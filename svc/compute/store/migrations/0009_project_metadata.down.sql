@@ -0,0 +1,8 @@
+ALTER TABLE "order" DROP COLUMN labels;
+
+ALTER TABLE project
+    DROP COLUMN version,
+    DROP COLUMN update_time,
+    DROP COLUMN labels,
+    DROP COLUMN description,
+    DROP COLUMN display_name;
@@ -0,0 +1,12 @@
+ALTER TABLE project
+    ADD COLUMN display_name VARCHAR     DEFAULT ''                NOT NULL,
+    ADD COLUMN description  VARCHAR     DEFAULT ''                NOT NULL,
+    ADD COLUMN labels       JSONB       DEFAULT '{}'              NOT NULL,
+    ADD COLUMN update_time  TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    -- incremented on every update, the etag of the project
+    ADD COLUMN version      BIGINT      DEFAULT 1                 NOT NULL;
+
+UPDATE project SET update_time = create_time;
+
+ALTER TABLE "order"
+    ADD COLUMN labels JSONB DEFAULT '{}' NOT NULL;
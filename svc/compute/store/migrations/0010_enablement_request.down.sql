@@ -0,0 +1,4 @@
+DROP TABLE enablement_request;
+
+DROP TYPE enablement_status;
+DROP TYPE enablement_kind;
@@ -0,0 +1,39 @@
+CREATE TYPE enablement_kind AS ENUM ('demand', 'supply');
+
+CREATE TYPE enablement_status AS ENUM ('pending', 'approved', 'rejected', 'revoked');
+
+-- requests to enable a billing account for demand or supply and the decisions made on them, an account is enabled
+-- for a kind while its request for it is approved
+CREATE TABLE enablement_request
+(
+    uid                UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+    billing_account_id VARCHAR REFERENCES billing_account (id)    NOT NULL,
+    kind               enablement_kind                            NOT NULL,
+    status             enablement_status DEFAULT 'pending'        NOT NULL,
+    reason             VARCHAR           DEFAULT ''               NOT NULL,
+    requested_by       VARCHAR                                    NOT NULL,
+    create_time        TIMESTAMPTZ       DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    decided_by         VARCHAR           DEFAULT ''               NOT NULL,
+    decision_reason    VARCHAR           DEFAULT ''               NOT NULL,
+    decide_time        TIMESTAMPTZ                                NULL,
+    revoked_by         VARCHAR           DEFAULT ''               NOT NULL,
+    revoke_reason      VARCHAR           DEFAULT ''               NOT NULL,
+    revoke_time        TIMESTAMPTZ                                NULL
+);
+
+CREATE INDEX enablement_request_billing_account_id ON enablement_request(billing_account_id);
+
+-- at most one open request and one approval per billing account and kind
+CREATE UNIQUE INDEX enablement_request_pending ON enablement_request(billing_account_id, kind) WHERE status = 'pending';
+CREATE UNIQUE INDEX enablement_request_approved ON enablement_request(billing_account_id, kind) WHERE status = 'approved';
+
+-- accounts enabled before requests existed
+INSERT INTO enablement_request (billing_account_id, kind, status, reason, requested_by, create_time, decided_by, decision_reason, decide_time)
+SELECT id, 'demand', 'approved', 'enabled before enablement requests', 'migration', create_time, 'migration', '', create_time
+FROM billing_account
+WHERE demand_enabled;
+
+INSERT INTO enablement_request (billing_account_id, kind, status, reason, requested_by, create_time, decided_by, decision_reason, decide_time)
+SELECT id, 'supply', 'approved', 'enabled before enablement requests', 'migration', create_time, 'migration', '', create_time
+FROM billing_account
+WHERE supply_enabled;
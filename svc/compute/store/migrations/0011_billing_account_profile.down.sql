@@ -0,0 +1,13 @@
+ALTER TABLE billing_account
+    DROP COLUMN update_time,
+    DROP COLUMN timezone,
+    DROP COLUMN currency,
+    DROP COLUMN tax_id,
+    DROP COLUMN address_country_code,
+    DROP COLUMN address_postal_code,
+    DROP COLUMN address_region,
+    DROP COLUMN address_city,
+    DROP COLUMN address_line2,
+    DROP COLUMN address_line1,
+    DROP COLUMN contact_emails,
+    DROP COLUMN display_name;
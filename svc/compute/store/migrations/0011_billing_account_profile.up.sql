@@ -0,0 +1,18 @@
+ALTER TABLE billing_account
+    ADD COLUMN display_name          VARCHAR     DEFAULT ''                NOT NULL,
+    ADD COLUMN contact_emails        VARCHAR[]   DEFAULT '{}'              NOT NULL,
+    ADD COLUMN address_line1         VARCHAR     DEFAULT ''                NOT NULL,
+    ADD COLUMN address_line2         VARCHAR     DEFAULT ''                NOT NULL,
+    ADD COLUMN address_city          VARCHAR     DEFAULT ''                NOT NULL,
+    ADD COLUMN address_region        VARCHAR     DEFAULT ''                NOT NULL,
+    ADD COLUMN address_postal_code   VARCHAR     DEFAULT ''                NOT NULL,
+    -- ISO 3166-1 alpha-2
+    ADD COLUMN address_country_code  VARCHAR     DEFAULT ''                NOT NULL,
+    ADD COLUMN tax_id                VARCHAR     DEFAULT ''                NOT NULL,
+    -- ISO 4217
+    ADD COLUMN currency              VARCHAR     DEFAULT 'USD'             NOT NULL,
+    -- IANA time zone
+    ADD COLUMN timezone              VARCHAR     DEFAULT 'UTC'             NOT NULL,
+    ADD COLUMN update_time           TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL;
+
+UPDATE billing_account SET update_time = create_time;
@@ -0,0 +1,9 @@
+ALTER TABLE billing_account_spend DROP COLUMN final;
+
+DROP TABLE billing_account_state_change;
+
+ALTER TABLE billing_account
+    DROP COLUMN close_time,
+    DROP COLUMN state;
+
+DROP TYPE billing_account_state;
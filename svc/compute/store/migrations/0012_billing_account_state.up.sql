@@ -0,0 +1,23 @@
+CREATE TYPE billing_account_state AS ENUM ('active', 'suspended', 'closed');
+
+ALTER TABLE billing_account
+    ADD COLUMN state      billing_account_state DEFAULT 'active' NOT NULL,
+    ADD COLUMN close_time TIMESTAMPTZ                            NULL;
+
+-- every change of the state of a billing account
+CREATE TABLE billing_account_state_change
+(
+    uid                UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+    billing_account_id VARCHAR REFERENCES billing_account (id)    NOT NULL,
+    from_state         billing_account_state                      NOT NULL,
+    to_state           billing_account_state                      NOT NULL,
+    reason             VARCHAR DEFAULT ''                         NOT NULL,
+    changed_by         VARCHAR                                    NOT NULL,
+    change_time        TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP      NOT NULL
+);
+
+CREATE INDEX billing_account_state_change_billing_account_id ON billing_account_state_change(billing_account_id);
+
+-- the spend of the period an account was closed in is its final invoice
+ALTER TABLE billing_account_spend
+    ADD COLUMN final BOOLEAN DEFAULT FALSE NOT NULL;
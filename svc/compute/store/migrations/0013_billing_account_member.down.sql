@@ -0,0 +1,3 @@
+DROP TABLE billing_account_member;
+
+DROP TYPE billing_account_role;
@@ -0,0 +1,14 @@
+CREATE TYPE billing_account_role AS ENUM ('owner', 'admin', 'viewer', 'billing_viewer');
+
+-- the principals with access to a billing account, projects inherit the members of their billing account
+CREATE TABLE billing_account_member
+(
+    billing_account_id VARCHAR REFERENCES billing_account (id) NOT NULL,
+    principal_id       VARCHAR                                 NOT NULL,
+    role               billing_account_role                    NOT NULL,
+    create_time        TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP   NOT NULL,
+    update_time        TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP   NOT NULL,
+    PRIMARY KEY (billing_account_id, principal_id)
+);
+
+CREATE INDEX billing_account_member_principal_id ON billing_account_member(principal_id);
@@ -0,0 +1,20 @@
+-- keys for calling the api on behalf of a billing account with the permissions of a role, only a hash of the secret
+-- is kept and keys are found by their prefix
+CREATE TABLE api_key
+(
+    uid                UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+    billing_account_id VARCHAR REFERENCES billing_account (id)    NOT NULL,
+    display_name       VARCHAR           DEFAULT ''               NOT NULL,
+    prefix             VARCHAR                                    NOT NULL,
+    secret_hash        BYTEA                                      NOT NULL,
+    role               billing_account_role                       NOT NULL,
+    created_by         VARCHAR                                    NOT NULL,
+    create_time        TIMESTAMPTZ       DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    expire_time        TIMESTAMPTZ                                NULL,
+    last_used_time     TIMESTAMPTZ                                NULL,
+    revoked_by         VARCHAR           DEFAULT ''               NOT NULL,
+    revoke_time        TIMESTAMPTZ                                NULL
+);
+
+CREATE UNIQUE INDEX api_key_prefix ON api_key(prefix);
+CREATE INDEX api_key_billing_account_id ON api_key(billing_account_id);
@@ -0,0 +1,29 @@
+-- every call of a mutating api method, who made it, what it changed and whether it succeeded. Rows are never updated
+-- or deleted.
+CREATE TABLE audit_log
+(
+    id            VARCHAR     DEFAULT gen_random_uuid()::varchar NOT NULL PRIMARY KEY,
+    create_time   TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP          NOT NULL,
+    method        VARCHAR                                        NOT NULL,
+    principal     VARCHAR                                        NOT NULL,
+    resource_name VARCHAR     DEFAULT ''                         NOT NULL,
+    request_id    VARCHAR     DEFAULT ''                         NOT NULL,
+    diff          JSONB       DEFAULT '{}'                       NOT NULL,
+    result_code   VARCHAR                                        NOT NULL
+);
+
+CREATE INDEX audit_log_create_time ON audit_log(create_time, id);
+CREATE INDEX audit_log_resource_name ON audit_log(resource_name);
+
+CREATE FUNCTION audit_log_append_only() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'the audit log is append only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only
+    BEFORE UPDATE OR DELETE
+    ON audit_log
+    FOR EACH ROW
+EXECUTE FUNCTION audit_log_append_only();
@@ -0,0 +1,19 @@
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'the audit log is append only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER billing_account_spend_sealed ON billing_account_spend;
+DROP FUNCTION billing_account_spend_sealed();
+
+ALTER TABLE audit_log
+    DROP COLUMN chain_seq,
+    DROP COLUMN prev_hash,
+    DROP COLUMN hash;
+
+ALTER TABLE billing_account_spend
+    DROP COLUMN chain_seq,
+    DROP COLUMN prev_hash,
+    DROP COLUMN hash;
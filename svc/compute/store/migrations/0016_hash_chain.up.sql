@@ -0,0 +1,50 @@
+-- billing account spend, which is what accounts are invoiced, and audit events are hash chained once they are final
+-- so changes made after the fact can be found. Each sealed row stores its position in its chain, the hash of the row
+-- before it and its own hash, which covers its content and the hash before it. Order and project spend break the
+-- account spend down and are purged with their project so they aren't chained, the biller doesn't write them once
+-- the account spend of their period is sealed.
+ALTER TABLE billing_account_spend
+    ADD COLUMN chain_seq BIGINT,
+    ADD COLUMN prev_hash BYTEA,
+    ADD COLUMN hash      BYTEA;
+
+CREATE UNIQUE INDEX billing_account_spend_chain_seq ON billing_account_spend(chain_seq);
+
+ALTER TABLE audit_log
+    ADD COLUMN chain_seq BIGINT,
+    ADD COLUMN prev_hash BYTEA,
+    ADD COLUMN hash      BYTEA;
+
+CREATE UNIQUE INDEX audit_log_chain_seq ON audit_log(chain_seq);
+
+-- the spend of a period is recalculated until it is sealed, after that it can't be changed
+CREATE FUNCTION billing_account_spend_sealed() RETURNS TRIGGER AS
+$$
+BEGIN
+    IF OLD.hash IS NOT NULL THEN
+        RAISE EXCEPTION 'billing account spend % is sealed', OLD.uid;
+    END IF;
+    IF TG_OP = 'DELETE' THEN
+        RETURN OLD;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER billing_account_spend_sealed
+    BEFORE UPDATE OR DELETE
+    ON billing_account_spend
+    FOR EACH ROW
+EXECUTE FUNCTION billing_account_spend_sealed();
+
+-- audit events can only be updated to seal them
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS TRIGGER AS
+$$
+BEGIN
+    IF TG_OP = 'UPDATE' AND OLD.hash IS NULL AND
+       to_jsonb(NEW) - 'chain_seq' - 'prev_hash' - 'hash' = to_jsonb(OLD) - 'chain_seq' - 'prev_hash' - 'hash' THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'the audit log is append only';
+END;
+$$ LANGUAGE plpgsql;
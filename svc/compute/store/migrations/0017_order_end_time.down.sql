@@ -0,0 +1,2 @@
+ALTER TABLE "order"
+    DROP COLUMN end_time;
@@ -0,0 +1,8 @@
+-- the time an order was canceled, completed or failed, its availability is only measured until then
+ALTER TABLE "order"
+    ADD COLUMN end_time TIMESTAMPTZ NULL;
+
+-- orders that already ended did so with their last lease
+UPDATE "order" o
+SET end_time = COALESCE((SELECT max(l.end_time) FROM "lease" l WHERE l.order_id = o.id), o.create_time)
+WHERE o.status <> 'active';
@@ -0,0 +1,16 @@
+DROP INDEX project_spend_archive_project_archive_uid;
+DROP INDEX project_archive_id;
+
+ALTER TABLE project_spend_archive
+    DROP CONSTRAINT project_spend_archive_project_archive_uid_fkey,
+    DROP COLUMN project_archive_uid;
+
+ALTER TABLE project_archive
+    DROP CONSTRAINT project_archive_pkey,
+    ADD PRIMARY KEY (id);
+
+ALTER TABLE project_spend_archive
+    ADD CONSTRAINT project_spend_archive_project_id_fkey FOREIGN KEY (project_id) REFERENCES project_archive (id);
+
+ALTER TABLE project_archive
+    DROP COLUMN uid;
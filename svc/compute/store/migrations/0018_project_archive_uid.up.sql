@@ -0,0 +1,24 @@
+-- project ids can be reused, so a project can be purged more than once. Each purge is archived under a uid of its own.
+ALTER TABLE project_archive
+    ADD COLUMN uid UUID DEFAULT gen_random_uuid() NOT NULL;
+
+ALTER TABLE project_spend_archive
+    ADD COLUMN project_archive_uid UUID NULL;
+
+UPDATE project_spend_archive SET project_archive_uid = a.uid
+    FROM project_archive a
+    WHERE project_id = a.id;
+
+ALTER TABLE project_spend_archive
+    DROP CONSTRAINT project_spend_archive_project_id_fkey;
+
+ALTER TABLE project_archive
+    DROP CONSTRAINT project_archive_pkey,
+    ADD PRIMARY KEY (uid);
+
+ALTER TABLE project_spend_archive
+    ALTER COLUMN project_archive_uid SET NOT NULL,
+    ADD CONSTRAINT project_spend_archive_project_archive_uid_fkey FOREIGN KEY (project_archive_uid) REFERENCES project_archive (uid);
+
+CREATE INDEX project_archive_id ON project_archive(id);
+CREATE INDEX project_spend_archive_project_archive_uid ON project_spend_archive(project_archive_uid);
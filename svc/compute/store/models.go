@@ -9,8 +9,90 @@ import (
 
 	apd "github.com/cockroachdb/apd/v2"
 	"github.com/google/uuid"
+	"github.com/jackc/pgtype"
 )
 
+type BillingAccountRole string
+
+const (
+	BillingAccountRoleOwner         BillingAccountRole = "owner"
+	BillingAccountRoleAdmin         BillingAccountRole = "admin"
+	BillingAccountRoleViewer        BillingAccountRole = "viewer"
+	BillingAccountRoleBillingViewer BillingAccountRole = "billing_viewer"
+)
+
+func (e *BillingAccountRole) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = BillingAccountRole(s)
+	case string:
+		*e = BillingAccountRole(s)
+	default:
+		return fmt.Errorf("unsupported scan type for BillingAccountRole: %T", src)
+	}
+	return nil
+}
+
+type BillingAccountState string
+
+const (
+	BillingAccountStateActive    BillingAccountState = "active"
+	BillingAccountStateSuspended BillingAccountState = "suspended"
+	BillingAccountStateClosed    BillingAccountState = "closed"
+)
+
+func (e *BillingAccountState) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = BillingAccountState(s)
+	case string:
+		*e = BillingAccountState(s)
+	default:
+		return fmt.Errorf("unsupported scan type for BillingAccountState: %T", src)
+	}
+	return nil
+}
+
+type EnablementKind string
+
+const (
+	EnablementKindDemand EnablementKind = "demand"
+	EnablementKindSupply EnablementKind = "supply"
+)
+
+func (e *EnablementKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EnablementKind(s)
+	case string:
+		*e = EnablementKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EnablementKind: %T", src)
+	}
+	return nil
+}
+
+type EnablementStatus string
+
+const (
+	EnablementStatusPending  EnablementStatus = "pending"
+	EnablementStatusApproved EnablementStatus = "approved"
+	EnablementStatusRejected EnablementStatus = "rejected"
+	EnablementStatusRevoked  EnablementStatus = "revoked"
+)
+
+func (e *EnablementStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EnablementStatus(s)
+	case string:
+		*e = EnablementStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EnablementStatus: %T", src)
+	}
+	return nil
+}
+
 type InfrastructureType string
 
 const (
@@ -51,6 +133,44 @@ func (e *LeaseStatus) Scan(src interface{}) error {
 	return nil
 }
 
+type OperationStatus string
+
+const (
+	OperationStatusRunning   OperationStatus = "running"
+	OperationStatusSucceeded OperationStatus = "succeeded"
+	OperationStatusFailed    OperationStatus = "failed"
+)
+
+func (e *OperationStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = OperationStatus(s)
+	case string:
+		*e = OperationStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for OperationStatus: %T", src)
+	}
+	return nil
+}
+
+type OperationType string
+
+const (
+	OperationTypeDeleteProject OperationType = "delete_project"
+)
+
+func (e *OperationType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = OperationType(s)
+	case string:
+		*e = OperationType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for OperationType: %T", src)
+	}
+	return nil
+}
+
 type OrderStatus string
 
 const (
@@ -72,11 +192,62 @@ func (e *OrderStatus) Scan(src interface{}) error {
 	return nil
 }
 
+type ApiKey struct {
+	Uid              uuid.UUID
+	BillingAccountID string
+	DisplayName      string
+	Prefix           string
+	SecretHash       []byte
+	Role             BillingAccountRole
+	CreatedBy        string
+	CreateTime       time.Time
+	ExpireTime       sql.NullTime
+	LastUsedTime     sql.NullTime
+	RevokedBy        string
+	RevokeTime       sql.NullTime
+}
+
+type AuditLog struct {
+	ID           string
+	CreateTime   time.Time
+	Method       string
+	Principal    string
+	ResourceName string
+	RequestID    string
+	Diff         pgtype.JSONB
+	ResultCode   string
+	ChainSeq     sql.NullInt64
+	PrevHash     []byte
+	Hash         []byte
+}
+
 type BillingAccount struct {
-	ID            string
-	CreateTime    time.Time
-	SupplyEnabled bool
-	DemandEnabled bool
+	ID                 string
+	CreateTime         time.Time
+	SupplyEnabled      bool
+	DemandEnabled      bool
+	DisplayName        string
+	ContactEmails      []string
+	AddressLine1       string
+	AddressLine2       string
+	AddressCity        string
+	AddressRegion      string
+	AddressPostalCode  string
+	AddressCountryCode string
+	TaxID              string
+	Currency           string
+	Timezone           string
+	UpdateTime         time.Time
+	State              BillingAccountState
+	CloseTime          sql.NullTime
+}
+
+type BillingAccountMember struct {
+	BillingAccountID string
+	PrincipalID      string
+	Role             BillingAccountRole
+	CreateTime       time.Time
+	UpdateTime       time.Time
 }
 
 type BillingAccountSpend struct {
@@ -85,6 +256,37 @@ type BillingAccountSpend struct {
 	Spend            apd.Decimal
 	StartTime        time.Time
 	EndTime          time.Time
+	Credit           apd.Decimal
+	Final            bool
+	ChainSeq         sql.NullInt64
+	PrevHash         []byte
+	Hash             []byte
+}
+
+type BillingAccountStateChange struct {
+	Uid              uuid.UUID
+	BillingAccountID string
+	FromState        BillingAccountState
+	ToState          BillingAccountState
+	Reason           string
+	ChangedBy        string
+	ChangeTime       time.Time
+}
+
+type EnablementRequest struct {
+	Uid              uuid.UUID
+	BillingAccountID string
+	Kind             EnablementKind
+	Status           EnablementStatus
+	Reason           string
+	RequestedBy      string
+	CreateTime       time.Time
+	DecidedBy        string
+	DecisionReason   string
+	DecideTime       sql.NullTime
+	RevokedBy        string
+	RevokeReason     string
+	RevokeTime       sql.NullTime
 }
 
 type Lease struct {
@@ -97,6 +299,16 @@ type Lease struct {
 	Status     LeaseStatus
 }
 
+type Operation struct {
+	Uid          uuid.UUID
+	Type         OperationType
+	ProjectID    string
+	Status       OperationStatus
+	ErrorMessage string
+	CreateTime   time.Time
+	EndTime      sql.NullTime
+}
+
 type Order struct {
 	ID               string
 	InfraType        InfrastructureType
@@ -107,26 +319,90 @@ type Order struct {
 	CreateTime       time.Time
 	PriceHr          float64
 	BillingAccountID string
+	Labels           pgtype.JSONB
+	EndTime          sql.NullTime
 }
 
 type OrderSpend struct {
-	Uid       uuid.UUID
-	OrderID   string
-	Spend     apd.Decimal
-	StartTime time.Time
-	EndTime   time.Time
+	Uid              uuid.UUID
+	OrderID          string
+	Spend            apd.Decimal
+	StartTime        time.Time
+	EndTime          time.Time
+	BillingAccountID string
 }
 
 type Project struct {
 	ID               string
 	CreateTime       time.Time
 	BillingAccountID string
+	DeleteTime       sql.NullTime
+	PurgeTime        sql.NullTime
+	DisplayName      string
+	Description      string
+	Labels           pgtype.JSONB
+	UpdateTime       time.Time
+	Version          int64
+}
+
+type ProjectArchive struct {
+	ID               string
+	BillingAccountID string
+	CreateTime       time.Time
+	DeleteTime       time.Time
+	PurgeTime        time.Time
+	Data             pgtype.JSONB
+	ArchiveTime      time.Time
+	Uid              uuid.UUID
 }
 
 type ProjectSpend struct {
-	Uid       uuid.UUID
-	ProjectID string
-	Spend     apd.Decimal
-	StartTime time.Time
-	EndTime   time.Time
+	Uid              uuid.UUID
+	ProjectID        string
+	Spend            apd.Decimal
+	StartTime        time.Time
+	EndTime          time.Time
+	BillingAccountID string
+}
+
+type ProjectSpendArchive struct {
+	Uid               uuid.UUID
+	ProjectID         string
+	Spend             apd.Decimal
+	StartTime         time.Time
+	EndTime           time.Time
+	ArchiveTime       time.Time
+	BillingAccountID  sql.NullString
+	ProjectArchiveUid uuid.UUID
+}
+
+type ProjectTransfer struct {
+	Uid                  uuid.UUID
+	ProjectID            string
+	FromBillingAccountID string
+	ToBillingAccountID   string
+	OrderIds             []string
+	TransferTime         time.Time
+}
+
+type SlaCredit struct {
+	Uid              uuid.UUID
+	OrderID          string
+	BillingAccountID string
+	StartTime        time.Time
+	EndTime          time.Time
+	Availability     apd.Decimal
+	CreditPercent    apd.Decimal
+	Credit           apd.Decimal
+	ApplyStartTime   time.Time
+	ApplyEndTime     time.Time
+	Details          pgtype.JSONB
+	CreateTime       time.Time
+}
+
+type SlaTier struct {
+	Uid               uuid.UUID
+	InfraType         InfrastructureType
+	AvailabilityBelow apd.Decimal
+	CreditPercent     apd.Decimal
 }
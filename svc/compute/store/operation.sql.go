@@ -0,0 +1,94 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: operation.sql
+
+package store
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const createOperation = `-- name: CreateOperation :one
+INSERT INTO "operation" (type, project_id)
+VALUES ($1, $2)
+RETURNING uid, type, project_id, status, error_message, create_time, end_time
+`
+
+type CreateOperationParams struct {
+	Type      OperationType
+	ProjectID string
+}
+
+func (q *Queries) CreateOperation(ctx context.Context, arg CreateOperationParams) (Operation, error) {
+	row := q.db.QueryRow(ctx, createOperation, arg.Type, arg.ProjectID)
+	var i Operation
+	err := row.Scan(
+		&i.Uid,
+		&i.Type,
+		&i.ProjectID,
+		&i.Status,
+		&i.ErrorMessage,
+		&i.CreateTime,
+		&i.EndTime,
+	)
+	return i, err
+}
+
+const endOperation = `-- name: EndOperation :one
+UPDATE "operation"
+SET status = $1,
+    error_message = $2,
+    end_time = NOW()
+WHERE uid = $3
+RETURNING uid, type, project_id, status, error_message, create_time, end_time
+`
+
+type EndOperationParams struct {
+	Status       OperationStatus
+	ErrorMessage string
+	Uid          uuid.UUID
+}
+
+func (q *Queries) EndOperation(ctx context.Context, arg EndOperationParams) (Operation, error) {
+	row := q.db.QueryRow(ctx, endOperation, arg.Status, arg.ErrorMessage, arg.Uid)
+	var i Operation
+	err := row.Scan(
+		&i.Uid,
+		&i.Type,
+		&i.ProjectID,
+		&i.Status,
+		&i.ErrorMessage,
+		&i.CreateTime,
+		&i.EndTime,
+	)
+	return i, err
+}
+
+const findOperationById = `-- name: FindOperationById :one
+SELECT uid, type, project_id, status, error_message, create_time, end_time
+FROM "operation"
+WHERE uid = $1
+  AND project_id = $2
+LIMIT 1
+`
+
+type FindOperationByIdParams struct {
+	Uid       uuid.UUID
+	ProjectID string
+}
+
+func (q *Queries) FindOperationById(ctx context.Context, arg FindOperationByIdParams) (Operation, error) {
+	row := q.db.QueryRow(ctx, findOperationById, arg.Uid, arg.ProjectID)
+	var i Operation
+	err := row.Scan(
+		&i.Uid,
+		&i.Type,
+		&i.ProjectID,
+		&i.Status,
+		&i.ErrorMessage,
+		&i.CreateTime,
+		&i.EndTime,
+	)
+	return i, err
+}
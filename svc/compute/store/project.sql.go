@@ -5,38 +5,172 @@ package store
 
 import (
 	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgtype"
 )
 
+const archiveProject = `-- name: ArchiveProject :one
+INSERT INTO "project_archive" (id, billing_account_id, create_time, delete_time, purge_time, data)
+SELECT p.id,
+       p.billing_account_id,
+       p.create_time,
+       p.delete_time,
+       p.purge_time,
+       jsonb_build_object(
+         'project', to_jsonb(p),
+         'orders', COALESCE((SELECT jsonb_agg(to_jsonb(o)) FROM "order" o WHERE o.project_id = p.id), '[]'::jsonb),
+         'leases', COALESCE((SELECT jsonb_agg(to_jsonb(l)) FROM "lease" l INNER JOIN "order" o ON l.order_id = o.id WHERE o.project_id = p.id), '[]'::jsonb),
+         'order_spend', COALESCE((SELECT jsonb_agg(to_jsonb(os)) FROM "order_spend" os INNER JOIN "order" o ON os.order_id = o.id WHERE o.project_id = p.id), '[]'::jsonb),
+         'sla_credits', COALESCE((SELECT jsonb_agg(to_jsonb(sc)) FROM "sla_credit" sc INNER JOIN "order" o ON sc.order_id = o.id WHERE o.project_id = p.id), '[]'::jsonb)
+       )
+FROM "project" p
+WHERE p.id = $1
+  AND p.delete_time IS NOT NULL
+RETURNING uid
+`
+
+func (q *Queries) ArchiveProject(ctx context.Context, id string) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, archiveProject, id)
+	var uid uuid.UUID
+	err := row.Scan(&uid)
+	return uid, err
+}
+
+const archiveProjectSpend = `-- name: ArchiveProjectSpend :exec
+INSERT INTO "project_spend_archive" (uid, project_id, project_archive_uid, billing_account_id, spend, start_time, end_time)
+SELECT ps.uid, ps.project_id, $1, ps.billing_account_id, ps.spend, ps.start_time, ps.end_time
+FROM "project_spend" ps
+WHERE ps.project_id = $2
+`
+
+type ArchiveProjectSpendParams struct {
+	ProjectArchiveUid uuid.UUID
+	ProjectID         string
+}
+
+func (q *Queries) ArchiveProjectSpend(ctx context.Context, arg ArchiveProjectSpendParams) error {
+	_, err := q.db.Exec(ctx, archiveProjectSpend, arg.ProjectArchiveUid, arg.ProjectID)
+	return err
+}
+
+const countProjectsByBillingAccountId = `-- name: CountProjectsByBillingAccountId :one
+SELECT COUNT(*)
+FROM "project"
+WHERE billing_account_id = $1
+  AND delete_time IS NULL
+`
+
+func (q *Queries) CountProjectsByBillingAccountId(ctx context.Context, billingAccountID string) (int64, error) {
+	row := q.db.QueryRow(ctx, countProjectsByBillingAccountId, billingAccountID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createProject = `-- name: CreateProject :one
-INSERT INTO "project" (id, billing_account_id)
+INSERT INTO "project" (id, billing_account_id, display_name, description, labels)
 VALUES (
     $1,
-    $2
+    $2,
+    $3,
+    $4,
+    $5
 )
 ON CONFLICT DO NOTHING
-RETURNING id, create_time, billing_account_id
+RETURNING id, create_time, billing_account_id, delete_time, purge_time, display_name, description, labels, update_time, version
 `
 
 type CreateProjectParams struct {
 	ID               string
 	BillingAccountID string
+	DisplayName      string
+	Description      string
+	Labels           pgtype.JSONB
 }
 
 func (q *Queries) CreateProject(ctx context.Context, arg CreateProjectParams) (Project, error) {
-	row := q.db.QueryRow(ctx, createProject, arg.ID, arg.BillingAccountID)
+	row := q.db.QueryRow(ctx, createProject,
+		arg.ID,
+		arg.BillingAccountID,
+		arg.DisplayName,
+		arg.Description,
+		arg.Labels,
+	)
 	var i Project
-	err := row.Scan(&i.ID, &i.CreateTime, &i.BillingAccountID)
+	err := row.Scan(
+		&i.ID,
+		&i.CreateTime,
+		&i.BillingAccountID,
+		&i.DeleteTime,
+		&i.PurgeTime,
+		&i.DisplayName,
+		&i.Description,
+		&i.Labels,
+		&i.UpdateTime,
+		&i.Version,
+	)
+	return i, err
+}
+
+const createProjectTransfer = `-- name: CreateProjectTransfer :one
+INSERT INTO "project_transfer" (project_id, from_billing_account_id, to_billing_account_id, order_ids, transfer_time)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4::varchar[],
+    $5
+)
+RETURNING uid, project_id, from_billing_account_id, to_billing_account_id, order_ids, transfer_time
+`
+
+type CreateProjectTransferParams struct {
+	ProjectID            string
+	FromBillingAccountID string
+	ToBillingAccountID   string
+	OrderIds             []string
+	TransferTime         time.Time
+}
+
+func (q *Queries) CreateProjectTransfer(ctx context.Context, arg CreateProjectTransferParams) (ProjectTransfer, error) {
+	row := q.db.QueryRow(ctx, createProjectTransfer,
+		arg.ProjectID,
+		arg.FromBillingAccountID,
+		arg.ToBillingAccountID,
+		arg.OrderIds,
+		arg.TransferTime,
+	)
+	var i ProjectTransfer
+	err := row.Scan(
+		&i.Uid,
+		&i.ProjectID,
+		&i.FromBillingAccountID,
+		&i.ToBillingAccountID,
+		&i.OrderIds,
+		&i.TransferTime,
+	)
 	return i, err
 }
 
 const deleteProject = `-- name: DeleteProject :execrows
-DELETE FROM "project"
-WHERE
-    id = $1
+UPDATE "project"
+SET delete_time = $1,
+    purge_time = $2
+WHERE id = $3
+  AND delete_time IS NULL
 `
 
-func (q *Queries) DeleteProject(ctx context.Context, id string) (int64, error) {
-	result, err := q.db.Exec(ctx, deleteProject, id)
+type DeleteProjectParams struct {
+	DeleteTime sql.NullTime
+	PurgeTime  sql.NullTime
+	ID         string
+}
+
+func (q *Queries) DeleteProject(ctx context.Context, arg DeleteProjectParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteProject, arg.DeleteTime, arg.PurgeTime, arg.ID)
 	if err != nil {
 		return 0, err
 	}
@@ -44,7 +178,7 @@ func (q *Queries) DeleteProject(ctx context.Context, id string) (int64, error) {
 }
 
 const findProjectById = `-- name: FindProjectById :one
-SELECT id, create_time, billing_account_id
+SELECT id, create_time, billing_account_id, delete_time, purge_time, display_name, description, labels, update_time, version
 FROM "project"
 WHERE
     id = $1
@@ -53,13 +187,24 @@ WHERE
 func (q *Queries) FindProjectById(ctx context.Context, id string) (Project, error) {
 	row := q.db.QueryRow(ctx, findProjectById, id)
 	var i Project
-	err := row.Scan(&i.ID, &i.CreateTime, &i.BillingAccountID)
+	err := row.Scan(
+		&i.ID,
+		&i.CreateTime,
+		&i.BillingAccountID,
+		&i.DeleteTime,
+		&i.PurgeTime,
+		&i.DisplayName,
+		&i.Description,
+		&i.Labels,
+		&i.UpdateTime,
+		&i.Version,
+	)
 	return i, err
 }
 
 const findProjectExistsById = `-- name: FindProjectExistsById :one
 SELECT EXISTS (
-    SELECT id, create_time, billing_account_id
+    SELECT id, create_time, billing_account_id, delete_time, purge_time, display_name, description, labels, update_time, version
     FROM "project"
     WHERE
         id = $1
@@ -74,15 +219,21 @@ func (q *Queries) FindProjectExistsById(ctx context.Context, id string) (bool, e
 }
 
 const getProjectCurrentSpend = `-- name: GetProjectCurrentSpend :one
-SELECT uid, project_id, spend, start_time, end_time
+SELECT uid, project_id, spend, start_time, end_time, billing_account_id
 FROM "project_spend"
 WHERE project_id = $1
+  AND billing_account_id = $2
 ORDER BY start_time DESC
 LIMIT 1
 `
 
-func (q *Queries) GetProjectCurrentSpend(ctx context.Context, projectID string) (ProjectSpend, error) {
-	row := q.db.QueryRow(ctx, getProjectCurrentSpend, projectID)
+type GetProjectCurrentSpendParams struct {
+	ProjectID        string
+	BillingAccountID string
+}
+
+func (q *Queries) GetProjectCurrentSpend(ctx context.Context, arg GetProjectCurrentSpendParams) (ProjectSpend, error) {
+	row := q.db.QueryRow(ctx, getProjectCurrentSpend, arg.ProjectID, arg.BillingAccountID)
 	var i ProjectSpend
 	err := row.Scan(
 		&i.Uid,
@@ -90,32 +241,39 @@ func (q *Queries) GetProjectCurrentSpend(ctx context.Context, projectID string)
 		&i.Spend,
 		&i.StartTime,
 		&i.EndTime,
+		&i.BillingAccountID,
 	)
 	return i, err
 }
 
-const getProjectSpendHistory = `-- name: GetProjectSpendHistory :many
-SELECT uid, project_id, spend, start_time, end_time
-FROM "project_spend"
-WHERE project_id = $1
-ORDER BY start_time DESC
+const listProjectsToPurge = `-- name: ListProjectsToPurge :many
+SELECT id, create_time, billing_account_id, delete_time, purge_time, display_name, description, labels, update_time, version
+FROM "project"
+WHERE delete_time IS NOT NULL
+  AND purge_time <= $1::timestamptz
+ORDER BY purge_time
 `
 
-func (q *Queries) GetProjectSpendHistory(ctx context.Context, projectID string) ([]ProjectSpend, error) {
-	rows, err := q.db.Query(ctx, getProjectSpendHistory, projectID)
+func (q *Queries) ListProjectsToPurge(ctx context.Context, purgeTime time.Time) ([]Project, error) {
+	rows, err := q.db.Query(ctx, listProjectsToPurge, purgeTime)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ProjectSpend
+	var items []Project
 	for rows.Next() {
-		var i ProjectSpend
+		var i Project
 		if err := rows.Scan(
-			&i.Uid,
-			&i.ProjectID,
-			&i.Spend,
-			&i.StartTime,
-			&i.EndTime,
+			&i.ID,
+			&i.CreateTime,
+			&i.BillingAccountID,
+			&i.DeleteTime,
+			&i.PurgeTime,
+			&i.DisplayName,
+			&i.Description,
+			&i.Labels,
+			&i.UpdateTime,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -127,32 +285,121 @@ func (q *Queries) GetProjectSpendHistory(ctx context.Context, projectID string)
 	return items, nil
 }
 
-const listProjects = `-- name: ListProjects :many
-SELECT id, create_time, billing_account_id
+const purgeProject = `-- name: PurgeProject :execrows
+DELETE FROM "project"
+WHERE id = $1
+  AND delete_time IS NOT NULL
+`
+
+func (q *Queries) PurgeProject(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeProject, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const purgeProjectLeases = `-- name: PurgeProjectLeases :exec
+DELETE FROM "lease"
+WHERE order_id IN (SELECT id FROM "order" WHERE project_id = $1)
+`
+
+func (q *Queries) PurgeProjectLeases(ctx context.Context, projectID string) error {
+	_, err := q.db.Exec(ctx, purgeProjectLeases, projectID)
+	return err
+}
+
+const purgeProjectOrderSpend = `-- name: PurgeProjectOrderSpend :exec
+DELETE FROM "order_spend"
+WHERE order_id IN (SELECT id FROM "order" WHERE project_id = $1)
+`
+
+func (q *Queries) PurgeProjectOrderSpend(ctx context.Context, projectID string) error {
+	_, err := q.db.Exec(ctx, purgeProjectOrderSpend, projectID)
+	return err
+}
+
+const purgeProjectOrders = `-- name: PurgeProjectOrders :exec
+DELETE FROM "order"
+WHERE project_id = $1
+`
+
+func (q *Queries) PurgeProjectOrders(ctx context.Context, projectID string) error {
+	_, err := q.db.Exec(ctx, purgeProjectOrders, projectID)
+	return err
+}
+
+const purgeProjectSLACredits = `-- name: PurgeProjectSLACredits :exec
+DELETE FROM "sla_credit"
+WHERE order_id IN (SELECT id FROM "order" WHERE project_id = $1)
+`
+
+func (q *Queries) PurgeProjectSLACredits(ctx context.Context, projectID string) error {
+	_, err := q.db.Exec(ctx, purgeProjectSLACredits, projectID)
+	return err
+}
+
+const purgeProjectSpend = `-- name: PurgeProjectSpend :exec
+DELETE FROM "project_spend"
+WHERE project_id = $1
+`
+
+func (q *Queries) PurgeProjectSpend(ctx context.Context, projectID string) error {
+	_, err := q.db.Exec(ctx, purgeProjectSpend, projectID)
+	return err
+}
+
+const selectProjectForUpdate = `-- name: SelectProjectForUpdate :one
+SELECT id, create_time, billing_account_id, delete_time, purge_time, display_name, description, labels, update_time, version
 FROM "project"
-WHERE id = ANY($2::varchar[])
-ORDER BY id
-LIMIT $1
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) SelectProjectForUpdate(ctx context.Context, id string) (Project, error) {
+	row := q.db.QueryRow(ctx, selectProjectForUpdate, id)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.CreateTime,
+		&i.BillingAccountID,
+		&i.DeleteTime,
+		&i.PurgeTime,
+		&i.DisplayName,
+		&i.Description,
+		&i.Labels,
+		&i.UpdateTime,
+		&i.Version,
+	)
+	return i, err
+}
+
+const transferProjectOrders = `-- name: TransferProjectOrders :many
+UPDATE "order"
+SET billing_account_id = $1
+WHERE project_id = $2
+  AND status = 'active'
+RETURNING id
 `
 
-type ListProjectsParams struct {
-	Limit int32
-	Ids   []string
+type TransferProjectOrdersParams struct {
+	BillingAccountID string
+	ProjectID        string
 }
 
-func (q *Queries) ListProjects(ctx context.Context, arg ListProjectsParams) ([]Project, error) {
-	rows, err := q.db.Query(ctx, listProjects, arg.Limit, arg.Ids)
+func (q *Queries) TransferProjectOrders(ctx context.Context, arg TransferProjectOrdersParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, transferProjectOrders, arg.BillingAccountID, arg.ProjectID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Project
+	var items []string
 	for rows.Next() {
-		var i Project
-		if err := rows.Scan(&i.ID, &i.CreateTime, &i.BillingAccountID); err != nil {
+		var id string
+		if err := rows.Scan(&id); err != nil {
 			return nil, err
 		}
-		items = append(items, i)
+		items = append(items, id)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
@@ -160,35 +407,73 @@ func (q *Queries) ListProjects(ctx context.Context, arg ListProjectsParams) ([]P
 	return items, nil
 }
 
-const selectProjectForUpdate = `-- name: SelectProjectForUpdate :one
-SELECT id, create_time, billing_account_id
-FROM "project"
+const undeleteProject = `-- name: UndeleteProject :one
+UPDATE "project"
+SET delete_time = NULL,
+    purge_time = NULL
 WHERE id = $1
-FOR UPDATE
+  AND delete_time IS NOT NULL
+RETURNING id, create_time, billing_account_id, delete_time, purge_time, display_name, description, labels, update_time, version
 `
 
-func (q *Queries) SelectProjectForUpdate(ctx context.Context, id string) (Project, error) {
-	row := q.db.QueryRow(ctx, selectProjectForUpdate, id)
+func (q *Queries) UndeleteProject(ctx context.Context, id string) (Project, error) {
+	row := q.db.QueryRow(ctx, undeleteProject, id)
 	var i Project
-	err := row.Scan(&i.ID, &i.CreateTime, &i.BillingAccountID)
+	err := row.Scan(
+		&i.ID,
+		&i.CreateTime,
+		&i.BillingAccountID,
+		&i.DeleteTime,
+		&i.PurgeTime,
+		&i.DisplayName,
+		&i.Description,
+		&i.Labels,
+		&i.UpdateTime,
+		&i.Version,
+	)
 	return i, err
 }
 
 const updateProject = `-- name: UpdateProject :one
-UPDATE "project" 
-SET billing_account_id = $1
-WHERE id = $2
-RETURNING id, create_time, billing_account_id
+UPDATE "project"
+SET billing_account_id = $1,
+    display_name = $2,
+    description = $3,
+    labels = $4,
+    update_time = NOW(),
+    version = version + 1
+WHERE id = $5
+RETURNING id, create_time, billing_account_id, delete_time, purge_time, display_name, description, labels, update_time, version
 `
 
 type UpdateProjectParams struct {
 	BillingAccountID string
+	DisplayName      string
+	Description      string
+	Labels           pgtype.JSONB
 	ID               string
 }
 
 func (q *Queries) UpdateProject(ctx context.Context, arg UpdateProjectParams) (Project, error) {
-	row := q.db.QueryRow(ctx, updateProject, arg.BillingAccountID, arg.ID)
+	row := q.db.QueryRow(ctx, updateProject,
+		arg.BillingAccountID,
+		arg.DisplayName,
+		arg.Description,
+		arg.Labels,
+		arg.ID,
+	)
 	var i Project
-	err := row.Scan(&i.ID, &i.CreateTime, &i.BillingAccountID)
+	err := row.Scan(
+		&i.ID,
+		&i.CreateTime,
+		&i.BillingAccountID,
+		&i.DeleteTime,
+		&i.PurgeTime,
+		&i.DisplayName,
+		&i.Description,
+		&i.Labels,
+		&i.UpdateTime,
+		&i.Version,
+	)
 	return i, err
 }
@@ -4,39 +4,108 @@ package store
 
 import (
 	"context"
+	"time"
+
+	apd "github.com/cockroachdb/apd/v2"
+	"github.com/google/uuid"
 )
 
 type Querier interface {
-	CreateBillingAccount(ctx context.Context, id string) (BillingAccount, error)
+	ArchiveProject(ctx context.Context, id string) (uuid.UUID, error)
+	ArchiveProjectSpend(ctx context.Context, arg ArchiveProjectSpendParams) error
+	CountBillingAccountOwners(ctx context.Context, billingAccountID string) (int64, error)
+	CountProjectsByBillingAccountId(ctx context.Context, billingAccountID string) (int64, error)
+	CreateApiKey(ctx context.Context, arg CreateApiKeyParams) (ApiKey, error)
+	CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) error
+	CreateBillingAccount(ctx context.Context, arg CreateBillingAccountParams) (BillingAccount, error)
 	CreateBillingAccountSpend(ctx context.Context, arg CreateBillingAccountSpendParams) (BillingAccountSpend, error)
+	CreateBillingAccountStateChange(ctx context.Context, arg CreateBillingAccountStateChangeParams) (BillingAccountStateChange, error)
+	CreateEnablementRequest(ctx context.Context, arg CreateEnablementRequestParams) (EnablementRequest, error)
 	CreateLease(ctx context.Context, arg CreateLeaseParams) (Lease, error)
+	CreateOperation(ctx context.Context, arg CreateOperationParams) (Operation, error)
+	// orders can only be created for active billing accounts enabled for demand, no row is returned otherwise
 	CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error)
 	CreateOrderSpend(ctx context.Context, arg CreateOrderSpendParams) (OrderSpend, error)
 	CreateProject(ctx context.Context, arg CreateProjectParams) (Project, error)
 	CreateProjectSpend(ctx context.Context, arg CreateProjectSpendParams) (ProjectSpend, error)
-	DeleteProject(ctx context.Context, id string) (int64, error)
+	CreateProjectTransfer(ctx context.Context, arg CreateProjectTransferParams) (ProjectTransfer, error)
+	CreateSLACredit(ctx context.Context, arg CreateSLACreditParams) (SlaCredit, error)
+	DecideEnablementRequest(ctx context.Context, arg DecideEnablementRequestParams) (EnablementRequest, error)
+	DeleteBillingAccountMember(ctx context.Context, arg DeleteBillingAccountMemberParams) (int64, error)
+	DeleteProject(ctx context.Context, arg DeleteProjectParams) (int64, error)
+	DisableBillingAccountDemand(ctx context.Context, id string) (BillingAccount, error)
+	DisableBillingAccountSupply(ctx context.Context, id string) (BillingAccount, error)
 	EnableBillingAccountDemand(ctx context.Context, id string) (BillingAccount, error)
 	EnableBillingAccountSupply(ctx context.Context, id string) (BillingAccount, error)
 	EndLease(ctx context.Context, arg EndLeaseParams) (Lease, error)
+	EndOperation(ctx context.Context, arg EndOperationParams) (Operation, error)
 	EndOrder(ctx context.Context, arg EndOrderParams) (Order, error)
+	FindApiKeyById(ctx context.Context, arg FindApiKeyByIdParams) (ApiKey, error)
+	FindApiKeyByPrefix(ctx context.Context, prefix string) (ApiKey, error)
 	FindBillingAccountById(ctx context.Context, id string) (BillingAccount, error)
+	FindBillingAccountMember(ctx context.Context, arg FindBillingAccountMemberParams) (BillingAccountMember, error)
+	// periods are contiguous, the one before ends when this one starts, so only an exact match is the period
+	FindBillingAccountSpendForPeriod(ctx context.Context, arg FindBillingAccountSpendForPeriodParams) (BillingAccountSpend, error)
 	FindBillingAccountSpendForTimeRange(ctx context.Context, arg FindBillingAccountSpendForTimeRangeParams) (BillingAccountSpend, error)
+	FindEnablementRequestById(ctx context.Context, arg FindEnablementRequestByIdParams) (EnablementRequest, error)
+	FindLastAuditEventLink(ctx context.Context) (FindLastAuditEventLinkRow, error)
+	FindLastBillingAccountSpendLink(ctx context.Context) (FindLastBillingAccountSpendLinkRow, error)
 	FindLeaseInfoByLeaseId(ctx context.Context, id string) (FindLeaseInfoByLeaseIdRow, error)
+	FindOperationById(ctx context.Context, arg FindOperationByIdParams) (Operation, error)
+	FindOrderSpendForPeriod(ctx context.Context, arg FindOrderSpendForPeriodParams) (OrderSpend, error)
 	FindOrderSpendForTimeRange(ctx context.Context, arg FindOrderSpendForTimeRangeParams) (OrderSpend, error)
 	FindProjectById(ctx context.Context, id string) (Project, error)
 	FindProjectExistsById(ctx context.Context, id string) (bool, error)
 	FindProjectSpendForTimeRange(ctx context.Context, arg FindProjectSpendForTimeRangeParams) (ProjectSpend, error)
-	GetProjectCurrentSpend(ctx context.Context, projectID string) (ProjectSpend, error)
-	GetProjectSpendHistory(ctx context.Context, projectID string) ([]ProjectSpend, error)
+	GetProjectCurrentSpend(ctx context.Context, arg GetProjectCurrentSpendParams) (ProjectSpend, error)
+	IsBillingAccountSpendSealed(ctx context.Context, arg IsBillingAccountSpendSealedParams) (bool, error)
 	ListActiveLeasesByOrderId(ctx context.Context, orderID string) ([]Lease, error)
 	ListAllBillingAccounts(ctx context.Context) ([]BillingAccount, error)
-	ListBillingAccounts(ctx context.Context, arg ListBillingAccountsParams) ([]BillingAccount, error)
+	ListApiKeysByBillingAccountId(ctx context.Context, arg ListApiKeysByBillingAccountIdParams) ([]ApiKey, error)
+	ListAuditEventChain(ctx context.Context, arg ListAuditEventChainParams) ([]AuditLog, error)
+	ListBillingAccountMembers(ctx context.Context, arg ListBillingAccountMembersParams) ([]BillingAccountMember, error)
+	ListBillingAccountMembershipsByPrincipalId(ctx context.Context, principalID string) ([]BillingAccountMember, error)
+	ListBillingAccountSpendChain(ctx context.Context, arg ListBillingAccountSpendChainParams) ([]BillingAccountSpend, error)
+	ListBillingAccountStateChanges(ctx context.Context, arg ListBillingAccountStateChangesParams) ([]BillingAccountStateChange, error)
+	ListEnablementRequests(ctx context.Context, arg ListEnablementRequestsParams) ([]EnablementRequest, error)
+	ListEnablementRequestsByBillingAccountId(ctx context.Context, billingAccountID string) ([]EnablementRequest, error)
+	// a lease that failed before a window and was replaced within it, or never, is down for part of the window too
+	ListLeasesCreatedBeforeByOrderId(ctx context.Context, arg ListLeasesCreatedBeforeByOrderIdParams) ([]Lease, error)
 	ListLeasesForTimeRangeByOrderId(ctx context.Context, arg ListLeasesForTimeRangeByOrderIdParams) ([]Lease, error)
+	ListOrderSpendForProjectSpend(ctx context.Context, arg ListOrderSpendForProjectSpendParams) ([]ListOrderSpendForProjectSpendRow, error)
+	ListOrderTransfers(ctx context.Context, arg ListOrderTransfersParams) ([]ListOrderTransfersRow, error)
 	ListOrdersByBillingAccountId(ctx context.Context, billingAccountID string) ([]Order, error)
 	ListOrdersByProjectId(ctx context.Context, projectID string) ([]Order, error)
-	ListProjects(ctx context.Context, arg ListProjectsParams) ([]Project, error)
+	ListOrdersTransferredFromBillingAccount(ctx context.Context, arg ListOrdersTransferredFromBillingAccountParams) ([]Order, error)
+	ListProjectSpendByLabel(ctx context.Context, arg ListProjectSpendByLabelParams) ([]ListProjectSpendByLabelRow, error)
+	ListProjectSpendForBillingAccount(ctx context.Context, arg ListProjectSpendForBillingAccountParams) ([]ProjectSpend, error)
+	ListProjectsToPurge(ctx context.Context, purgeTime time.Time) ([]Project, error)
+	ListSLATiers(ctx context.Context) ([]SlaTier, error)
+	ListUnsealedAuditEvents(ctx context.Context, rowLimit int32) ([]AuditLog, error)
+	ListUnsealedBillingAccountSpend(ctx context.Context, arg ListUnsealedBillingAccountSpendParams) ([]BillingAccountSpend, error)
+	LockChain(ctx context.Context, chain string) error
+	MarkBillingAccountSpendFinal(ctx context.Context, uid uuid.UUID) error
+	PurgeProject(ctx context.Context, id string) (int64, error)
+	PurgeProjectLeases(ctx context.Context, projectID string) error
+	PurgeProjectOrderSpend(ctx context.Context, projectID string) error
+	PurgeProjectOrders(ctx context.Context, projectID string) error
+	PurgeProjectSLACredits(ctx context.Context, projectID string) error
+	PurgeProjectSpend(ctx context.Context, projectID string) error
+	RevokeApiKey(ctx context.Context, arg RevokeApiKeyParams) (ApiKey, error)
+	RevokeEnablementRequest(ctx context.Context, arg RevokeEnablementRequestParams) (EnablementRequest, error)
+	SealAuditEvent(ctx context.Context, arg SealAuditEventParams) (int64, error)
+	SealBillingAccountSpend(ctx context.Context, arg SealBillingAccountSpendParams) (int64, error)
 	SelectBillingAccountForUpdate(ctx context.Context, id string) (BillingAccount, error)
+	SelectEnablementRequestForUpdate(ctx context.Context, arg SelectEnablementRequestForUpdateParams) (EnablementRequest, error)
 	SelectProjectForUpdate(ctx context.Context, id string) (Project, error)
+	SetBillingAccountMember(ctx context.Context, arg SetBillingAccountMemberParams) (BillingAccountMember, error)
+	SumSLACreditsForBillingAccount(ctx context.Context, arg SumSLACreditsForBillingAccountParams) (apd.Decimal, error)
+	// last_used_time is only updated once a minute so busy keys don't write on every request
+	TouchApiKey(ctx context.Context, uid uuid.UUID) error
+	TransferProjectOrders(ctx context.Context, arg TransferProjectOrdersParams) ([]string, error)
+	UndeleteProject(ctx context.Context, id string) (Project, error)
+	UpdateBillingAccountProfile(ctx context.Context, arg UpdateBillingAccountProfileParams) (BillingAccount, error)
+	UpdateBillingAccountState(ctx context.Context, arg UpdateBillingAccountStateParams) (BillingAccount, error)
 	UpdateProject(ctx context.Context, arg UpdateProjectParams) (Project, error)
 }
 
@@ -0,0 +1,38 @@
+-- name: CreateApiKey :one
+INSERT INTO "api_key" (billing_account_id, display_name, prefix, secret_hash, role, created_by, expire_time)
+VALUES (@billing_account_id, @display_name, @prefix, @secret_hash, @role, @created_by, sqlc.narg('expire_time'))
+RETURNING *;
+
+-- name: FindApiKeyById :one
+SELECT *
+FROM "api_key"
+WHERE uid = @uid
+  AND billing_account_id = @billing_account_id;
+
+-- name: FindApiKeyByPrefix :one
+SELECT *
+FROM "api_key"
+WHERE prefix = @prefix;
+
+-- name: ListApiKeysByBillingAccountId :many
+SELECT *
+FROM "api_key"
+WHERE billing_account_id = @billing_account_id
+ORDER BY create_time DESC, uid
+LIMIT @row_limit OFFSET @row_offset;
+
+-- name: RevokeApiKey :one
+UPDATE "api_key"
+SET revoked_by = @revoked_by,
+    revoke_time = NOW()
+WHERE uid = @uid
+  AND billing_account_id = @billing_account_id
+  AND revoke_time IS NULL
+RETURNING *;
+
+-- name: TouchApiKey :exec
+-- last_used_time is only updated once a minute so busy keys don't write on every request
+UPDATE "api_key"
+SET last_used_time = NOW()
+WHERE uid = @uid
+  AND (last_used_time IS NULL OR last_used_time < NOW() - INTERVAL '1 minute');
@@ -0,0 +1,3 @@
+-- name: CreateAuditEvent :exec
+INSERT INTO "audit_log" (method, principal, resource_name, request_id, diff, result_code)
+VALUES (@method, @principal, @resource_name, @request_id, @diff, @result_code);
@@ -1,7 +1,31 @@
 -- name: CreateBillingAccount :one
-INSERT INTO "billing_account" (id)
+INSERT INTO "billing_account" (
+    id,
+    display_name,
+    contact_emails,
+    address_line1,
+    address_line2,
+    address_city,
+    address_region,
+    address_postal_code,
+    address_country_code,
+    tax_id,
+    currency,
+    timezone
+)
 VALUES (
-    @id
+    @id,
+    @display_name,
+    @contact_emails,
+    @address_line1,
+    @address_line2,
+    @address_city,
+    @address_region,
+    @address_postal_code,
+    @address_country_code,
+    @tax_id,
+    @currency,
+    @timezone
 )
 ON CONFLICT DO NOTHING
 RETURNING *;
@@ -12,13 +36,6 @@ FROM "billing_account"
 WHERE
     id = @id;
 
--- name: ListBillingAccounts :many
-SELECT *
-FROM "billing_account"
-WHERE id = ANY(@ids::varchar[])
-ORDER BY create_time
-LIMIT $1;
-
 -- name: ListAllBillingAccounts :many
 SELECT *
 FROM "billing_account"
@@ -41,3 +58,52 @@ UPDATE "billing_account"
 SET supply_enabled = true
 WHERE id = @id
 RETURNING *;
+
+-- name: DisableBillingAccountDemand :one
+UPDATE "billing_account"
+SET demand_enabled = false
+WHERE id = @id
+RETURNING *;
+
+-- name: DisableBillingAccountSupply :one
+UPDATE "billing_account"
+SET supply_enabled = false
+WHERE id = @id
+RETURNING *;
+
+-- name: UpdateBillingAccountProfile :one
+UPDATE "billing_account"
+SET display_name = @display_name,
+    contact_emails = @contact_emails,
+    address_line1 = @address_line1,
+    address_line2 = @address_line2,
+    address_city = @address_city,
+    address_region = @address_region,
+    address_postal_code = @address_postal_code,
+    address_country_code = @address_country_code,
+    tax_id = @tax_id,
+    currency = @currency,
+    timezone = @timezone,
+    update_time = NOW()
+WHERE id = @id
+RETURNING *;
+
+-- name: UpdateBillingAccountState :one
+UPDATE "billing_account"
+SET state = @state,
+    close_time = CASE WHEN @state = 'closed' THEN NOW() ELSE close_time END,
+    update_time = NOW()
+WHERE id = @id
+RETURNING *;
+
+-- name: CreateBillingAccountStateChange :one
+INSERT INTO "billing_account_state_change" (billing_account_id, from_state, to_state, reason, changed_by)
+VALUES (@billing_account_id, @from_state, @to_state, @reason, @changed_by)
+RETURNING *;
+
+-- name: ListBillingAccountStateChanges :many
+SELECT *
+FROM "billing_account_state_change"
+WHERE billing_account_id = @billing_account_id
+ORDER BY change_time DESC, uid
+LIMIT @row_limit OFFSET @row_offset;
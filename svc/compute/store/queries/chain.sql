@@ -0,0 +1,61 @@
+-- name: LockChain :exec
+SELECT pg_advisory_xact_lock(hashtext(@chain::varchar));
+
+-- name: FindLastBillingAccountSpendLink :one
+SELECT chain_seq::bigint AS chain_seq, hash
+FROM "billing_account_spend"
+WHERE chain_seq IS NOT NULL
+ORDER BY chain_seq DESC
+LIMIT 1;
+
+-- name: ListUnsealedBillingAccountSpend :many
+SELECT *
+FROM "billing_account_spend"
+WHERE hash IS NULL
+  AND (final OR end_time <= @end_time::timestamptz)
+ORDER BY end_time, billing_account_id
+LIMIT @row_limit;
+
+-- name: SealBillingAccountSpend :execrows
+UPDATE "billing_account_spend"
+SET chain_seq = @chain_seq::bigint,
+    prev_hash = @prev_hash,
+    hash      = @hash
+WHERE uid = @uid
+  AND hash IS NULL;
+
+-- name: ListBillingAccountSpendChain :many
+SELECT *
+FROM "billing_account_spend"
+WHERE chain_seq > @after_seq::bigint
+ORDER BY chain_seq
+LIMIT @row_limit;
+
+-- name: FindLastAuditEventLink :one
+SELECT chain_seq::bigint AS chain_seq, hash
+FROM "audit_log"
+WHERE chain_seq IS NOT NULL
+ORDER BY chain_seq DESC
+LIMIT 1;
+
+-- name: ListUnsealedAuditEvents :many
+SELECT *
+FROM "audit_log"
+WHERE hash IS NULL
+ORDER BY create_time, id
+LIMIT @row_limit;
+
+-- name: SealAuditEvent :execrows
+UPDATE "audit_log"
+SET chain_seq = @chain_seq::bigint,
+    prev_hash = @prev_hash,
+    hash      = @hash
+WHERE id = @id
+  AND hash IS NULL;
+
+-- name: ListAuditEventChain :many
+SELECT *
+FROM "audit_log"
+WHERE chain_seq > @after_seq::bigint
+ORDER BY chain_seq
+LIMIT @row_limit;
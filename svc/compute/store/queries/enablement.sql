@@ -0,0 +1,51 @@
+-- name: CreateEnablementRequest :one
+INSERT INTO "enablement_request" (billing_account_id, kind, reason, requested_by)
+VALUES (@billing_account_id, @kind, @reason, @requested_by)
+RETURNING *;
+
+-- name: FindEnablementRequestById :one
+SELECT *
+FROM "enablement_request"
+WHERE uid = @uid
+  AND billing_account_id = @billing_account_id;
+
+-- name: SelectEnablementRequestForUpdate :one
+SELECT *
+FROM "enablement_request"
+WHERE uid = @uid
+  AND billing_account_id = @billing_account_id
+FOR UPDATE;
+
+-- name: ListEnablementRequestsByBillingAccountId :many
+SELECT *
+FROM "enablement_request"
+WHERE billing_account_id = @billing_account_id
+ORDER BY create_time DESC, uid;
+
+-- name: ListEnablementRequests :many
+SELECT *
+FROM "enablement_request"
+WHERE billing_account_id = @billing_account_id
+ORDER BY create_time DESC, uid
+LIMIT @row_limit OFFSET @row_offset;
+
+-- name: DecideEnablementRequest :one
+UPDATE "enablement_request"
+SET status = @status,
+    decided_by = @decided_by,
+    decision_reason = @decision_reason,
+    decide_time = NOW()
+WHERE uid = @uid
+  AND status = 'pending'
+RETURNING *;
+
+-- name: RevokeEnablementRequest :one
+UPDATE "enablement_request"
+SET status = 'revoked',
+    revoked_by = @revoked_by,
+    revoke_reason = @revoke_reason,
+    revoke_time = NOW()
+WHERE billing_account_id = @billing_account_id
+  AND kind = @kind
+  AND status = 'approved'
+RETURNING *;
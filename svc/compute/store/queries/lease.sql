@@ -13,6 +13,14 @@ WHERE
   l.create_time < @end_time AND
   (l.end_time IS NULL OR l.end_time >= @start_time);
 
+-- name: ListLeasesCreatedBeforeByOrderId :many
+-- a lease that failed before a window and was replaced within it, or never, is down for part of the window too
+SELECT *
+FROM "lease" l
+WHERE l.order_id = @order_id
+  AND l.create_time < @end_time
+ORDER BY l.create_time;
+
 -- name: ListActiveLeasesByOrderId :many
 SELECT *
 FROM "lease"
@@ -29,7 +37,23 @@ SELECT *
 FROM "order" o
 WHERE o.billing_account_id = @billing_account_id;
 
+-- name: ListOrdersTransferredFromBillingAccount :many
+SELECT DISTINCT o.*
+FROM "order" o
+         INNER JOIN "project_transfer" t ON o.id = ANY(t.order_ids)
+WHERE t.from_billing_account_id = @billing_account_id
+  AND t.transfer_time >= @start_time::timestamptz
+  AND o.billing_account_id <> @billing_account_id;
+
+-- name: ListOrderTransfers :many
+SELECT t.from_billing_account_id, t.to_billing_account_id, t.transfer_time
+FROM "project_transfer" t
+WHERE @order_id::varchar = ANY(t.order_ids)
+  AND t.transfer_time >= @start_time::timestamptz
+ORDER BY t.transfer_time;
+
 -- name: CreateOrder :one
+-- orders can only be created for active billing accounts enabled for demand, no row is returned otherwise
 INSERT INTO "order" (
   id,
   infra_type,
@@ -37,17 +61,21 @@ INSERT INTO "order" (
   project_id,
   quantity,
   description,
-  price_hr
-)
-VALUES (
-  @id,
-  @infra_type,
-  @billing_account_id,
-  @project_id,
-  @quantity,
-  @description,
-  @price_hr
+  price_hr,
+  labels
 )
+SELECT @id,
+       @infra_type,
+       ba.id,
+       @project_id,
+       @quantity,
+       @description,
+       @price_hr,
+       @labels
+FROM "billing_account" ba
+WHERE ba.id = @billing_account_id
+  AND ba.demand_enabled
+  AND ba.state = 'active'
 ON CONFLICT DO NOTHING
 RETURNING *;
 
@@ -69,13 +97,14 @@ RETURNING *;
 
 -- name: EndLease :one
 UPDATE "lease"
-SET end_time = NOW(),
+SET end_time = @end_time::timestamptz,
     status = @status
 WHERE id = @id
 RETURNING *;
 
 -- name: EndOrder :one
 UPDATE "order"
-SET status = @status
+SET status   = @status,
+    end_time = @end_time
 WHERE id = @id
 RETURNING *;
@@ -0,0 +1,38 @@
+-- name: SetBillingAccountMember :one
+INSERT INTO "billing_account_member" (billing_account_id, principal_id, role)
+VALUES (@billing_account_id, @principal_id, @role)
+ON CONFLICT (billing_account_id, principal_id)
+  DO UPDATE SET role = @role,
+                update_time = NOW()
+RETURNING *;
+
+-- name: FindBillingAccountMember :one
+SELECT *
+FROM "billing_account_member"
+WHERE billing_account_id = @billing_account_id
+  AND principal_id = @principal_id;
+
+-- name: ListBillingAccountMembers :many
+SELECT *
+FROM "billing_account_member"
+WHERE billing_account_id = @billing_account_id
+ORDER BY create_time, principal_id
+LIMIT @row_limit OFFSET @row_offset;
+
+-- name: ListBillingAccountMembershipsByPrincipalId :many
+SELECT *
+FROM "billing_account_member"
+WHERE principal_id = @principal_id
+ORDER BY billing_account_id;
+
+-- name: DeleteBillingAccountMember :execrows
+DELETE
+FROM "billing_account_member"
+WHERE billing_account_id = @billing_account_id
+  AND principal_id = @principal_id;
+
+-- name: CountBillingAccountOwners :one
+SELECT COUNT(*)
+FROM "billing_account_member"
+WHERE billing_account_id = @billing_account_id
+  AND role = 'owner';
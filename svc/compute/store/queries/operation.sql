@@ -0,0 +1,19 @@
+-- name: CreateOperation :one
+INSERT INTO "operation" (type, project_id)
+VALUES (@type, @project_id)
+RETURNING *;
+
+-- name: EndOperation :one
+UPDATE "operation"
+SET status = @status,
+    error_message = @error_message,
+    end_time = NOW()
+WHERE uid = @uid
+RETURNING *;
+
+-- name: FindOperationById :one
+SELECT *
+FROM "operation"
+WHERE uid = @uid
+  AND project_id = @project_id
+LIMIT 1;
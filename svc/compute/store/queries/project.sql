@@ -1,16 +1,86 @@
 -- name: CreateProject :one
-INSERT INTO "project" (id, billing_account_id)
+INSERT INTO "project" (id, billing_account_id, display_name, description, labels)
 VALUES (
     @id,
-    @billing_account_id
+    @billing_account_id,
+    @display_name,
+    @description,
+    @labels
 )
 ON CONFLICT DO NOTHING
 RETURNING *;
 
 -- name: DeleteProject :execrows
+UPDATE "project"
+SET delete_time = @delete_time,
+    purge_time = @purge_time
+WHERE id = @id
+  AND delete_time IS NULL;
+
+-- name: UndeleteProject :one
+UPDATE "project"
+SET delete_time = NULL,
+    purge_time = NULL
+WHERE id = @id
+  AND delete_time IS NOT NULL
+RETURNING *;
+
+-- name: ListProjectsToPurge :many
+SELECT *
+FROM "project"
+WHERE delete_time IS NOT NULL
+  AND purge_time <= @purge_time::timestamptz
+ORDER BY purge_time;
+
+-- name: ArchiveProject :one
+INSERT INTO "project_archive" (id, billing_account_id, create_time, delete_time, purge_time, data)
+SELECT p.id,
+       p.billing_account_id,
+       p.create_time,
+       p.delete_time,
+       p.purge_time,
+       jsonb_build_object(
+         'project', to_jsonb(p),
+         'orders', COALESCE((SELECT jsonb_agg(to_jsonb(o)) FROM "order" o WHERE o.project_id = p.id), '[]'::jsonb),
+         'leases', COALESCE((SELECT jsonb_agg(to_jsonb(l)) FROM "lease" l INNER JOIN "order" o ON l.order_id = o.id WHERE o.project_id = p.id), '[]'::jsonb),
+         'order_spend', COALESCE((SELECT jsonb_agg(to_jsonb(os)) FROM "order_spend" os INNER JOIN "order" o ON os.order_id = o.id WHERE o.project_id = p.id), '[]'::jsonb),
+         'sla_credits', COALESCE((SELECT jsonb_agg(to_jsonb(sc)) FROM "sla_credit" sc INNER JOIN "order" o ON sc.order_id = o.id WHERE o.project_id = p.id), '[]'::jsonb)
+       )
+FROM "project" p
+WHERE p.id = @id
+  AND p.delete_time IS NOT NULL
+RETURNING uid;
+
+-- name: ArchiveProjectSpend :exec
+INSERT INTO "project_spend_archive" (uid, project_id, project_archive_uid, billing_account_id, spend, start_time, end_time)
+SELECT ps.uid, ps.project_id, @project_archive_uid, ps.billing_account_id, ps.spend, ps.start_time, ps.end_time
+FROM "project_spend" ps
+WHERE ps.project_id = @project_id;
+
+-- name: PurgeProjectLeases :exec
+DELETE FROM "lease"
+WHERE order_id IN (SELECT id FROM "order" WHERE project_id = @project_id);
+
+-- name: PurgeProjectOrderSpend :exec
+DELETE FROM "order_spend"
+WHERE order_id IN (SELECT id FROM "order" WHERE project_id = @project_id);
+
+-- name: PurgeProjectSLACredits :exec
+DELETE FROM "sla_credit"
+WHERE order_id IN (SELECT id FROM "order" WHERE project_id = @project_id);
+
+-- name: PurgeProjectOrders :exec
+DELETE FROM "order"
+WHERE project_id = @project_id;
+
+-- name: PurgeProjectSpend :exec
+DELETE FROM "project_spend"
+WHERE project_id = @project_id;
+
+-- name: PurgeProject :execrows
 DELETE FROM "project"
-WHERE
-    id = @id;
+WHERE id = @id
+  AND delete_time IS NOT NULL;
 
 -- name: FindProjectById :one
 SELECT *
@@ -26,13 +96,6 @@ SELECT EXISTS (
         id = @id
 );
 
--- name: ListProjects :many
-SELECT *
-FROM "project"
-WHERE id = ANY(@ids::varchar[])
-ORDER BY id
-LIMIT $1;
-
 -- name: SelectProjectForUpdate :one
 SELECT *
 FROM "project"
@@ -40,20 +103,44 @@ WHERE id = @id
 FOR UPDATE;
 
 -- name: UpdateProject :one
-UPDATE "project" 
-SET billing_account_id = @billing_account_id
+UPDATE "project"
+SET billing_account_id = @billing_account_id,
+    display_name = @display_name,
+    description = @description,
+    labels = @labels,
+    update_time = NOW(),
+    version = version + 1
 WHERE id = @id
 RETURNING *;
 
+-- name: TransferProjectOrders :many
+UPDATE "order"
+SET billing_account_id = @billing_account_id
+WHERE project_id = @project_id
+  AND status = 'active'
+RETURNING id;
+
+-- name: CreateProjectTransfer :one
+INSERT INTO "project_transfer" (project_id, from_billing_account_id, to_billing_account_id, order_ids, transfer_time)
+VALUES (
+    @project_id,
+    @from_billing_account_id,
+    @to_billing_account_id,
+    @order_ids::varchar[],
+    @transfer_time
+)
+RETURNING *;
+
 -- name: GetProjectCurrentSpend :one
 SELECT *
 FROM "project_spend"
 WHERE project_id = @project_id
+  AND billing_account_id = @billing_account_id
 ORDER BY start_time DESC
 LIMIT 1;
 
--- name: GetProjectSpendHistory :many
-SELECT *
-FROM "project_spend"
-WHERE project_id = @project_id
-ORDER BY start_time DESC;
+-- name: CountProjectsByBillingAccountId :one
+SELECT COUNT(*)
+FROM "project"
+WHERE billing_account_id = @billing_account_id
+  AND delete_time IS NULL;
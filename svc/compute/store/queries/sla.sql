@@ -0,0 +1,43 @@
+-- name: ListSLATiers :many
+SELECT *
+FROM "sla_tier"
+ORDER BY infra_type, availability_below;
+
+-- name: CreateSLACredit :one
+INSERT INTO "sla_credit" (
+  order_id,
+  billing_account_id,
+  start_time,
+  end_time,
+  availability,
+  credit_percent,
+  credit,
+  apply_start_time,
+  apply_end_time,
+  details
+)
+VALUES (
+  @order_id,
+  @billing_account_id,
+  @start_time,
+  @end_time,
+  @availability,
+  @credit_percent,
+  @credit,
+  @apply_start_time,
+  @apply_end_time,
+  @details
+)
+ON CONFLICT (order_id, start_time, end_time)
+  DO UPDATE SET availability = @availability,
+                credit_percent = @credit_percent,
+                credit = @credit,
+                details = @details
+RETURNING *;
+
+-- name: SumSLACreditsForBillingAccount :one
+SELECT COALESCE(SUM(credit), 0)::numeric AS credit
+FROM "sla_credit"
+WHERE billing_account_id = @billing_account_id
+  AND apply_start_time = @apply_start_time
+  AND apply_end_time = @apply_end_time;
@@ -1,39 +1,55 @@
 -- name: CreateBillingAccountSpend :one
-INSERT INTO "billing_account_spend" (uid, billing_account_id, spend, start_time, end_time)
+INSERT INTO "billing_account_spend" (uid, billing_account_id, spend, start_time, end_time, credit)
 VALUES (
     @uid,
     @billing_account_id,
     @spend,
     @start_time,
-    @end_time
+    @end_time,
+    @credit
 )
 ON CONFLICT (billing_account_id, start_time, end_time)
-  DO UPDATE SET spend = @spend
+  DO UPDATE SET spend = @spend,
+                credit = @credit
+  -- sealed spend is left as it is and no row is returned
+  WHERE billing_account_spend.hash IS NULL
 RETURNING *;
 
+-- name: IsBillingAccountSpendSealed :one
+SELECT EXISTS (
+    SELECT 1
+    FROM "billing_account_spend"
+    WHERE billing_account_id = @billing_account_id
+      AND start_time = @start_time
+      AND end_time = @end_time
+      AND hash IS NOT NULL
+)::boolean;
+
 -- name: CreateOrderSpend :one
-INSERT INTO "order_spend" (uid, order_id, spend, start_time, end_time)
+INSERT INTO "order_spend" (uid, order_id, billing_account_id, spend, start_time, end_time)
 VALUES (
     @uid,
     @order_id,
+    @billing_account_id,
     @spend,
     @start_time,
     @end_time
 )
-ON CONFLICT (order_id, start_time, end_time)
+ON CONFLICT (order_id, billing_account_id, start_time, end_time)
   DO UPDATE SET spend = @spend
 RETURNING *;
 
 -- name: CreateProjectSpend :one
-INSERT INTO "project_spend" (uid, project_id, spend, start_time, end_time)
+INSERT INTO "project_spend" (uid, project_id, billing_account_id, spend, start_time, end_time)
 VALUES (
     @uid,
     @project_id,
+    @billing_account_id,
     @spend,
     @start_time,
     @end_time
 )
-ON CONFLICT (project_id, start_time, end_time)
+ON CONFLICT (project_id, billing_account_id, start_time, end_time)
   DO UPDATE SET spend = @spend
 RETURNING *;
 
@@ -43,13 +59,30 @@ FROM "billing_account_spend"
 WHERE billing_account_id = @billing_account_id
   AND start_time < @end_time
   AND end_time >= @start_time;
+
+-- name: FindBillingAccountSpendForPeriod :one
+-- periods are contiguous, the one before ends when this one starts, so only an exact match is the period
+SELECT *
+FROM "billing_account_spend"
+WHERE billing_account_id = @billing_account_id
+  AND start_time = @start_time
+  AND end_time = @end_time;
   
   -- name: FindOrderSpendForTimeRange :one
 SELECT *
 FROM "order_spend"
 WHERE order_id = @order_id
+  AND billing_account_id = @billing_account_id
   AND start_time < @end_time
   AND end_time >= @start_time;
+
+-- name: FindOrderSpendForPeriod :one
+SELECT *
+FROM "order_spend"
+WHERE order_id = @order_id
+  AND billing_account_id = @billing_account_id
+  AND start_time = @start_time
+  AND end_time = @end_time;
   
   -- name: FindProjectSpendForTimeRange :one
 SELECT *
@@ -57,3 +90,37 @@ FROM "project_spend"
 WHERE project_id = @project_id
   AND start_time < @end_time
   AND end_time >= @start_time;
+
+-- name: ListProjectSpendByLabel :many
+SELECT COALESCE(o.labels ->> @label_key::varchar, '')::varchar AS label_value,
+       SUM(os.spend)::numeric AS spend
+FROM "order_spend" os
+         INNER JOIN "order" o ON os.order_id = o.id
+WHERE o.project_id = @project_id
+  AND os.start_time < @end_time::timestamptz
+  AND os.end_time > @start_time::timestamptz
+GROUP BY label_value
+ORDER BY label_value;
+
+-- name: ListOrderSpendForProjectSpend :many
+SELECT os.order_id, o.infra_type, os.spend
+FROM "order_spend" os
+         INNER JOIN "order" o ON os.order_id = o.id
+WHERE o.project_id = @project_id
+  AND os.billing_account_id = @billing_account_id
+  AND os.start_time = @start_time::timestamptz
+  AND os.end_time = @end_time::timestamptz
+ORDER BY os.order_id;
+
+-- name: ListProjectSpendForBillingAccount :many
+SELECT *
+FROM "project_spend"
+WHERE billing_account_id = @billing_account_id
+  AND start_time = @start_time::timestamptz
+  AND end_time = @end_time::timestamptz
+ORDER BY project_id;
+
+-- name: MarkBillingAccountSpendFinal :exec
+UPDATE "billing_account_spend"
+SET final = true
+WHERE uid = @uid;
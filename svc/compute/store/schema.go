@@ -0,0 +1,34 @@
+package store
+
+import (
+	"context"
+	"fmt"
+)
+
+// migrations are applied with golang-migrate, which keeps the version in schema_migrations
+const schemaVersion = `-- name: SchemaVersion :one
+SELECT version, dirty
+FROM "schema_migrations"`
+
+// SchemaVersion returns the version of the last migration applied to the database and whether it failed part way
+func (q *Queries) SchemaVersion(ctx context.Context) (int64, bool, error) {
+	var version int64
+	var dirty bool
+	err := q.db.QueryRow(ctx, schemaVersion).Scan(&version, &dirty)
+	return version, dirty, err
+}
+
+// CheckSchemaVersion returns an error unless the database has the schema the queries are written for
+func (q *Queries) CheckSchemaVersion(ctx context.Context) error {
+	version, dirty, err := q.SchemaVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("could not get the schema version: %w", err)
+	}
+	if dirty {
+		return fmt.Errorf("migration %d failed part way", version)
+	}
+	if version != SchemaVersion {
+		return fmt.Errorf("the schema is at version %d, expected %d", version, SchemaVersion)
+	}
+	return nil
+}
@@ -12,17 +12,21 @@ import (
 )
 
 const createBillingAccountSpend = `-- name: CreateBillingAccountSpend :one
-INSERT INTO "billing_account_spend" (uid, billing_account_id, spend, start_time, end_time)
+INSERT INTO "billing_account_spend" (uid, billing_account_id, spend, start_time, end_time, credit)
 VALUES (
     $1,
     $2,
     $3,
     $4,
-    $5
+    $5,
+    $6
 )
 ON CONFLICT (billing_account_id, start_time, end_time)
-  DO UPDATE SET spend = $3
-RETURNING uid, billing_account_id, spend, start_time, end_time
+  DO UPDATE SET spend = $3,
+                credit = $6
+  -- sealed spend is left as it is and no row is returned
+  WHERE billing_account_spend.hash IS NULL
+RETURNING uid, billing_account_id, spend, start_time, end_time, credit, final, chain_seq, prev_hash, hash
 `
 
 type CreateBillingAccountSpendParams struct {
@@ -31,6 +35,7 @@ type CreateBillingAccountSpendParams struct {
 	Spend            apd.Decimal
 	StartTime        time.Time
 	EndTime          time.Time
+	Credit           apd.Decimal
 }
 
 func (q *Queries) CreateBillingAccountSpend(ctx context.Context, arg CreateBillingAccountSpendParams) (BillingAccountSpend, error) {
@@ -40,6 +45,7 @@ func (q *Queries) CreateBillingAccountSpend(ctx context.Context, arg CreateBilli
 		arg.Spend,
 		arg.StartTime,
 		arg.EndTime,
+		arg.Credit,
 	)
 	var i BillingAccountSpend
 	err := row.Scan(
@@ -48,36 +54,44 @@ func (q *Queries) CreateBillingAccountSpend(ctx context.Context, arg CreateBilli
 		&i.Spend,
 		&i.StartTime,
 		&i.EndTime,
+		&i.Credit,
+		&i.Final,
+		&i.ChainSeq,
+		&i.PrevHash,
+		&i.Hash,
 	)
 	return i, err
 }
 
 const createOrderSpend = `-- name: CreateOrderSpend :one
-INSERT INTO "order_spend" (uid, order_id, spend, start_time, end_time)
+INSERT INTO "order_spend" (uid, order_id, billing_account_id, spend, start_time, end_time)
 VALUES (
     $1,
     $2,
     $3,
     $4,
-    $5
+    $5,
+    $6
 )
-ON CONFLICT (order_id, start_time, end_time)
-  DO UPDATE SET spend = $3
-RETURNING uid, order_id, spend, start_time, end_time
+ON CONFLICT (order_id, billing_account_id, start_time, end_time)
+  DO UPDATE SET spend = $4
+RETURNING uid, order_id, spend, start_time, end_time, billing_account_id
 `
 
 type CreateOrderSpendParams struct {
-	Uid       uuid.UUID
-	OrderID   string
-	Spend     apd.Decimal
-	StartTime time.Time
-	EndTime   time.Time
+	Uid              uuid.UUID
+	OrderID          string
+	BillingAccountID string
+	Spend            apd.Decimal
+	StartTime        time.Time
+	EndTime          time.Time
 }
 
 func (q *Queries) CreateOrderSpend(ctx context.Context, arg CreateOrderSpendParams) (OrderSpend, error) {
 	row := q.db.QueryRow(ctx, createOrderSpend,
 		arg.Uid,
 		arg.OrderID,
+		arg.BillingAccountID,
 		arg.Spend,
 		arg.StartTime,
 		arg.EndTime,
@@ -89,36 +103,40 @@ func (q *Queries) CreateOrderSpend(ctx context.Context, arg CreateOrderSpendPara
 		&i.Spend,
 		&i.StartTime,
 		&i.EndTime,
+		&i.BillingAccountID,
 	)
 	return i, err
 }
 
 const createProjectSpend = `-- name: CreateProjectSpend :one
-INSERT INTO "project_spend" (uid, project_id, spend, start_time, end_time)
+INSERT INTO "project_spend" (uid, project_id, billing_account_id, spend, start_time, end_time)
 VALUES (
     $1,
     $2,
     $3,
     $4,
-    $5
+    $5,
+    $6
 )
-ON CONFLICT (project_id, start_time, end_time)
-  DO UPDATE SET spend = $3
-RETURNING uid, project_id, spend, start_time, end_time
+ON CONFLICT (project_id, billing_account_id, start_time, end_time)
+  DO UPDATE SET spend = $4
+RETURNING uid, project_id, spend, start_time, end_time, billing_account_id
 `
 
 type CreateProjectSpendParams struct {
-	Uid       uuid.UUID
-	ProjectID string
-	Spend     apd.Decimal
-	StartTime time.Time
-	EndTime   time.Time
+	Uid              uuid.UUID
+	ProjectID        string
+	BillingAccountID string
+	Spend            apd.Decimal
+	StartTime        time.Time
+	EndTime          time.Time
 }
 
 func (q *Queries) CreateProjectSpend(ctx context.Context, arg CreateProjectSpendParams) (ProjectSpend, error) {
 	row := q.db.QueryRow(ctx, createProjectSpend,
 		arg.Uid,
 		arg.ProjectID,
+		arg.BillingAccountID,
 		arg.Spend,
 		arg.StartTime,
 		arg.EndTime,
@@ -130,12 +148,46 @@ func (q *Queries) CreateProjectSpend(ctx context.Context, arg CreateProjectSpend
 		&i.Spend,
 		&i.StartTime,
 		&i.EndTime,
+		&i.BillingAccountID,
+	)
+	return i, err
+}
+
+const findBillingAccountSpendForPeriod = `-- name: FindBillingAccountSpendForPeriod :one
+SELECT uid, billing_account_id, spend, start_time, end_time, credit, final, chain_seq, prev_hash, hash
+FROM "billing_account_spend"
+WHERE billing_account_id = $1
+  AND start_time = $2
+  AND end_time = $3
+`
+
+type FindBillingAccountSpendForPeriodParams struct {
+	BillingAccountID string
+	StartTime        time.Time
+	EndTime          time.Time
+}
+
+// periods are contiguous, the one before ends when this one starts, so only an exact match is the period
+func (q *Queries) FindBillingAccountSpendForPeriod(ctx context.Context, arg FindBillingAccountSpendForPeriodParams) (BillingAccountSpend, error) {
+	row := q.db.QueryRow(ctx, findBillingAccountSpendForPeriod, arg.BillingAccountID, arg.StartTime, arg.EndTime)
+	var i BillingAccountSpend
+	err := row.Scan(
+		&i.Uid,
+		&i.BillingAccountID,
+		&i.Spend,
+		&i.StartTime,
+		&i.EndTime,
+		&i.Credit,
+		&i.Final,
+		&i.ChainSeq,
+		&i.PrevHash,
+		&i.Hash,
 	)
 	return i, err
 }
 
 const findBillingAccountSpendForTimeRange = `-- name: FindBillingAccountSpendForTimeRange :one
-SELECT uid, billing_account_id, spend, start_time, end_time
+SELECT uid, billing_account_id, spend, start_time, end_time, credit, final, chain_seq, prev_hash, hash
 FROM "billing_account_spend"
 WHERE billing_account_id = $1
   AND start_time < $2
@@ -157,26 +209,73 @@ func (q *Queries) FindBillingAccountSpendForTimeRange(ctx context.Context, arg F
 		&i.Spend,
 		&i.StartTime,
 		&i.EndTime,
+		&i.Credit,
+		&i.Final,
+		&i.ChainSeq,
+		&i.PrevHash,
+		&i.Hash,
+	)
+	return i, err
+}
+
+const findOrderSpendForPeriod = `-- name: FindOrderSpendForPeriod :one
+SELECT uid, order_id, spend, start_time, end_time, billing_account_id
+FROM "order_spend"
+WHERE order_id = $1
+  AND billing_account_id = $2
+  AND start_time = $3
+  AND end_time = $4
+`
+
+type FindOrderSpendForPeriodParams struct {
+	OrderID          string
+	BillingAccountID string
+	StartTime        time.Time
+	EndTime          time.Time
+}
+
+func (q *Queries) FindOrderSpendForPeriod(ctx context.Context, arg FindOrderSpendForPeriodParams) (OrderSpend, error) {
+	row := q.db.QueryRow(ctx, findOrderSpendForPeriod,
+		arg.OrderID,
+		arg.BillingAccountID,
+		arg.StartTime,
+		arg.EndTime,
+	)
+	var i OrderSpend
+	err := row.Scan(
+		&i.Uid,
+		&i.OrderID,
+		&i.Spend,
+		&i.StartTime,
+		&i.EndTime,
+		&i.BillingAccountID,
 	)
 	return i, err
 }
 
 const findOrderSpendForTimeRange = `-- name: FindOrderSpendForTimeRange :one
-SELECT uid, order_id, spend, start_time, end_time
+SELECT uid, order_id, spend, start_time, end_time, billing_account_id
 FROM "order_spend"
 WHERE order_id = $1
-  AND start_time < $2
-  AND end_time >= $3
+  AND billing_account_id = $2
+  AND start_time < $3
+  AND end_time >= $4
 `
 
 type FindOrderSpendForTimeRangeParams struct {
-	OrderID   string
-	EndTime   time.Time
-	StartTime time.Time
+	OrderID          string
+	BillingAccountID string
+	EndTime          time.Time
+	StartTime        time.Time
 }
 
 func (q *Queries) FindOrderSpendForTimeRange(ctx context.Context, arg FindOrderSpendForTimeRangeParams) (OrderSpend, error) {
-	row := q.db.QueryRow(ctx, findOrderSpendForTimeRange, arg.OrderID, arg.EndTime, arg.StartTime)
+	row := q.db.QueryRow(ctx, findOrderSpendForTimeRange,
+		arg.OrderID,
+		arg.BillingAccountID,
+		arg.EndTime,
+		arg.StartTime,
+	)
 	var i OrderSpend
 	err := row.Scan(
 		&i.Uid,
@@ -184,12 +283,13 @@ func (q *Queries) FindOrderSpendForTimeRange(ctx context.Context, arg FindOrderS
 		&i.Spend,
 		&i.StartTime,
 		&i.EndTime,
+		&i.BillingAccountID,
 	)
 	return i, err
 }
 
 const findProjectSpendForTimeRange = `-- name: FindProjectSpendForTimeRange :one
-SELECT uid, project_id, spend, start_time, end_time
+SELECT uid, project_id, spend, start_time, end_time, billing_account_id
 FROM "project_spend"
 WHERE project_id = $1
   AND start_time < $2
@@ -211,6 +311,182 @@ func (q *Queries) FindProjectSpendForTimeRange(ctx context.Context, arg FindProj
 		&i.Spend,
 		&i.StartTime,
 		&i.EndTime,
+		&i.BillingAccountID,
 	)
 	return i, err
 }
+
+const isBillingAccountSpendSealed = `-- name: IsBillingAccountSpendSealed :one
+SELECT EXISTS (
+    SELECT 1
+    FROM "billing_account_spend"
+    WHERE billing_account_id = $1
+      AND start_time = $2
+      AND end_time = $3
+      AND hash IS NOT NULL
+)::boolean
+`
+
+type IsBillingAccountSpendSealedParams struct {
+	BillingAccountID string
+	StartTime        time.Time
+	EndTime          time.Time
+}
+
+func (q *Queries) IsBillingAccountSpendSealed(ctx context.Context, arg IsBillingAccountSpendSealedParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isBillingAccountSpendSealed, arg.BillingAccountID, arg.StartTime, arg.EndTime)
+	var column_1 bool
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const listOrderSpendForProjectSpend = `-- name: ListOrderSpendForProjectSpend :many
+SELECT os.order_id, o.infra_type, os.spend
+FROM "order_spend" os
+         INNER JOIN "order" o ON os.order_id = o.id
+WHERE o.project_id = $1
+  AND os.billing_account_id = $2
+  AND os.start_time = $3::timestamptz
+  AND os.end_time = $4::timestamptz
+ORDER BY os.order_id
+`
+
+type ListOrderSpendForProjectSpendParams struct {
+	ProjectID        string
+	BillingAccountID string
+	StartTime        time.Time
+	EndTime          time.Time
+}
+
+type ListOrderSpendForProjectSpendRow struct {
+	OrderID   string
+	InfraType InfrastructureType
+	Spend     apd.Decimal
+}
+
+func (q *Queries) ListOrderSpendForProjectSpend(ctx context.Context, arg ListOrderSpendForProjectSpendParams) ([]ListOrderSpendForProjectSpendRow, error) {
+	rows, err := q.db.Query(ctx, listOrderSpendForProjectSpend,
+		arg.ProjectID,
+		arg.BillingAccountID,
+		arg.StartTime,
+		arg.EndTime,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOrderSpendForProjectSpendRow
+	for rows.Next() {
+		var i ListOrderSpendForProjectSpendRow
+		if err := rows.Scan(&i.OrderID, &i.InfraType, &i.Spend); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProjectSpendByLabel = `-- name: ListProjectSpendByLabel :many
+SELECT COALESCE(o.labels ->> $1::varchar, '')::varchar AS label_value,
+       SUM(os.spend)::numeric AS spend
+FROM "order_spend" os
+         INNER JOIN "order" o ON os.order_id = o.id
+WHERE o.project_id = $2
+  AND os.start_time < $3::timestamptz
+  AND os.end_time > $4::timestamptz
+GROUP BY label_value
+ORDER BY label_value
+`
+
+type ListProjectSpendByLabelParams struct {
+	LabelKey  string
+	ProjectID string
+	EndTime   time.Time
+	StartTime time.Time
+}
+
+type ListProjectSpendByLabelRow struct {
+	LabelValue string
+	Spend      apd.Decimal
+}
+
+func (q *Queries) ListProjectSpendByLabel(ctx context.Context, arg ListProjectSpendByLabelParams) ([]ListProjectSpendByLabelRow, error) {
+	rows, err := q.db.Query(ctx, listProjectSpendByLabel,
+		arg.LabelKey,
+		arg.ProjectID,
+		arg.EndTime,
+		arg.StartTime,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListProjectSpendByLabelRow
+	for rows.Next() {
+		var i ListProjectSpendByLabelRow
+		if err := rows.Scan(&i.LabelValue, &i.Spend); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProjectSpendForBillingAccount = `-- name: ListProjectSpendForBillingAccount :many
+SELECT uid, project_id, spend, start_time, end_time, billing_account_id
+FROM "project_spend"
+WHERE billing_account_id = $1
+  AND start_time = $2::timestamptz
+  AND end_time = $3::timestamptz
+ORDER BY project_id
+`
+
+type ListProjectSpendForBillingAccountParams struct {
+	BillingAccountID string
+	StartTime        time.Time
+	EndTime          time.Time
+}
+
+func (q *Queries) ListProjectSpendForBillingAccount(ctx context.Context, arg ListProjectSpendForBillingAccountParams) ([]ProjectSpend, error) {
+	rows, err := q.db.Query(ctx, listProjectSpendForBillingAccount, arg.BillingAccountID, arg.StartTime, arg.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ProjectSpend
+	for rows.Next() {
+		var i ProjectSpend
+		if err := rows.Scan(
+			&i.Uid,
+			&i.ProjectID,
+			&i.Spend,
+			&i.StartTime,
+			&i.EndTime,
+			&i.BillingAccountID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markBillingAccountSpendFinal = `-- name: MarkBillingAccountSpendFinal :exec
+UPDATE "billing_account_spend"
+SET final = true
+WHERE uid = $1
+`
+
+func (q *Queries) MarkBillingAccountSpendFinal(ctx context.Context, uid uuid.UUID) error {
+	_, err := q.db.Exec(ctx, markBillingAccountSpendFinal, uid)
+	return err
+}
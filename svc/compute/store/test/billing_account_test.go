@@ -8,6 +8,8 @@ import (
 	"testing"
 	"time"
 
+	"biller/lib/filter"
+	"biller/lib/pagination"
 	"biller/lib/postgresql"
 	"biller/svc/compute/store"
 
@@ -123,7 +125,13 @@ func TestCreateBillingAccount(t *testing.T) {
 
 	newCtx := context.Background()
 
-	billingaccount, err := postgresqlQueries.CreateBillingAccount(newCtx, "fakeid")
+	billingaccount, err := postgresqlQueries.CreateBillingAccount(newCtx, store.CreateBillingAccountParams{
+		ID:            "fakeid",
+		DisplayName:   "Fake",
+		ContactEmails: []string{"billing@example.com"},
+		Currency:      "EUR",
+		Timezone:      "Europe/London",
+	})
 
 	if err != nil {
 		t.Errorf("CreateBillingAccount() error: %v", err)
@@ -131,7 +139,8 @@ func TestCreateBillingAccount(t *testing.T) {
 
 	id := billingaccount.ID
 	sqlQuery := `
-		SELECT * FROM billing_account WHERE id = $1
+		SELECT id, create_time, supply_enabled, demand_enabled, display_name, contact_emails, currency, timezone
+		FROM billing_account WHERE id = $1
 	`
 
 	res, err := conn.Query(ctx, sqlQuery, id)
@@ -146,6 +155,10 @@ func TestCreateBillingAccount(t *testing.T) {
 			&billingAcc.CreateTime,
 			&billingAcc.SupplyEnabled,
 			&billingAcc.DemandEnabled,
+			&billingAcc.DisplayName,
+			&billingAcc.ContactEmails,
+			&billingAcc.Currency,
+			&billingAcc.Timezone,
 		)
 		if err != nil {
 			t.Fatal(err)
@@ -155,6 +168,9 @@ func TestCreateBillingAccount(t *testing.T) {
 	if billingAcc.ID != billingaccount.ID {
 		t.Errorf("Expected created billing account id to equal %s, but got %s", billingaccount.ID, billingAcc.ID)
 	}
+	if billingAcc.DisplayName != "Fake" || len(billingAcc.ContactEmails) != 1 || billingAcc.Currency != "EUR" || billingAcc.Timezone != "Europe/London" {
+		t.Errorf("Expected created billing account profile to be stored, but got %+v", billingAcc)
+	}
 }
 
 func TestListBillingAccounts(t *testing.T) {
@@ -210,8 +226,8 @@ func TestListBillingAccounts(t *testing.T) {
 	}
 
 	res, err := postgresqlQueries.ListBillingAccounts(newCtx, store.ListBillingAccountsParams{
-		Limit: 5,
-		Ids:   []string{"fakeid", "fakeid1", "fakeid2", "fakeid3", "fakeid4"},
+		ListParams:        store.ListParams{Limit: 5},
+		BillingAccountIds: []string{"fakeid", "fakeid1", "fakeid2", "fakeid3", "fakeid4"},
 	})
 	if err != nil {
 		t.Errorf("ListBillingAccounts() error: %v", err)
@@ -221,8 +237,8 @@ func TestListBillingAccounts(t *testing.T) {
 	}
 
 	res, err = postgresqlQueries.ListBillingAccounts(newCtx, store.ListBillingAccountsParams{
-		Limit: 2,
-		Ids:   []string{"fakeid2", "fakeid3", "fakeid4"},
+		ListParams:        store.ListParams{Limit: 2},
+		BillingAccountIds: []string{"fakeid2", "fakeid3", "fakeid4"},
 	})
 	if err != nil {
 		t.Errorf("ListBillingAccounts() error: %v", err)
@@ -254,6 +270,89 @@ func TestListBillingAccounts(t *testing.T) {
 	if res[1].CreateTime.String() != time.Date(2022, time.January, 22, 0, 0, 0, 0, time.UTC).String() {
 		t.Errorf("ListBillingAccounts() want %v, got: %s", time.Date(2022, time.January, 22, 0, 0, 0, 0, time.UTC), res[0].CreateTime)
 	}
+
+	// the next page starts after the last row of the previous one
+	res, err = postgresqlQueries.ListBillingAccounts(newCtx, store.ListBillingAccountsParams{
+		ListParams: store.ListParams{
+			Cursor: pagination.Cursor{CreateTime: res[1].CreateTime, ID: res[1].ID},
+			Limit:  2,
+		},
+		BillingAccountIds: []string{"fakeid2", "fakeid3", "fakeid4"},
+	})
+	if err != nil {
+		t.Errorf("ListBillingAccounts() error: %v", err)
+	}
+	if len(res) != 1 {
+		t.Fatalf("ListBillingAccounts() want 1, got: %d", len(res))
+	}
+	if res[0].ID != "fakeid4" {
+		t.Errorf("ListBillingAccounts() want 'fakeid4', got: %s", res[0].ID)
+	}
+
+	res, err = postgresqlQueries.ListBillingAccounts(newCtx, store.ListBillingAccountsParams{
+		ListParams:         store.ListParams{Limit: 10},
+		AllBillingAccounts: true,
+	})
+	if err != nil {
+		t.Errorf("ListBillingAccounts() error: %v", err)
+	}
+	if len(res) != 5 {
+		t.Errorf("ListBillingAccounts() want 5, got: %d", len(res))
+	}
+
+	demandFilter, err := filter.Parse(`demand_enabled = true AND create_time > "2022-01-20"`, filter.Schema{
+		"demand_enabled": {Column: "demand_enabled", Type: filter.Bool},
+		"create_time":    {Column: "create_time", Type: filter.Time},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err = postgresqlQueries.ListBillingAccounts(newCtx, store.ListBillingAccountsParams{
+		ListParams: store.ListParams{
+			Filter:  demandFilter,
+			OrderBy: filter.OrderBy{{Column: "create_time", Desc: true}},
+			Limit:   10,
+		},
+		AllBillingAccounts: true,
+	})
+	if err != nil {
+		t.Errorf("ListBillingAccounts() error: %v", err)
+	}
+	if len(res) != 1 {
+		t.Fatalf("ListBillingAccounts() want 1, got: %d", len(res))
+	}
+	if res[0].ID != "fakeid3" {
+		t.Errorf("ListBillingAccounts() want 'fakeid3', got: %s", res[0].ID)
+	}
+
+	// closed accounts are listed with their state and close time
+	closeTime := time.Date(2022, time.February, 1, 0, 0, 0, 0, time.UTC)
+	_, err = conn.Exec(newCtx, `UPDATE billing_account SET state = 'closed', close_time = $1 WHERE id = 'fakeid4'`, closeTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedFilter, err := filter.Parse(`state = "closed"`, filter.Schema{
+		"state": {Column: "state", Type: filter.String},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err = postgresqlQueries.ListBillingAccounts(newCtx, store.ListBillingAccountsParams{
+		ListParams:         store.ListParams{Filter: closedFilter, Limit: 10},
+		AllBillingAccounts: true,
+	})
+	if err != nil {
+		t.Errorf("ListBillingAccounts() error: %v", err)
+	}
+	if len(res) != 1 {
+		t.Fatalf("ListBillingAccounts() want 1, got: %d", len(res))
+	}
+	if res[0].ID != "fakeid4" || res[0].State != store.BillingAccountStateClosed {
+		t.Errorf("ListBillingAccounts() want closed 'fakeid4', got: %s %s", res[0].State, res[0].ID)
+	}
+	if !res[0].CloseTime.Valid || !res[0].CloseTime.Time.Equal(closeTime) {
+		t.Errorf("ListBillingAccounts() want close time %v, got: %v", closeTime, res[0].CloseTime)
+	}
 }
 
 func TestListAllBillingAccounts(t *testing.T) {
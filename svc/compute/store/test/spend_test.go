@@ -141,10 +141,11 @@ func Test_CreateOrderSpend(t *testing.T) {
 
 	spend := *apd.New(1, 0)
 	res, err := postgresqlQueries.CreateOrderSpend(newCtx, store.CreateOrderSpendParams{
-		OrderID:   orderId,
-		Spend:     spend,
-		StartTime: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
-		EndTime:   time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC),
+		OrderID:          orderId,
+		BillingAccountID: billingAccountId,
+		Spend:            spend,
+		StartTime:        time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:          time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC),
 	})
 	if err != nil {
 		t.Fatal(err)
@@ -213,10 +214,11 @@ func Test_CreateProjectSpend(t *testing.T) {
 
 	spend := *apd.New(123445365764743, -15)
 	res, err := postgresqlQueries.CreateProjectSpend(newCtx, store.CreateProjectSpendParams{
-		ProjectID: "project-id",
-		Spend:     spend,
-		StartTime: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
-		EndTime:   time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC),
+		ProjectID:        "project-id",
+		BillingAccountID: "billing-account-id",
+		Spend:            spend,
+		StartTime:        time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:          time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC),
 	})
 	if err != nil {
 		t.Fatal(err)
@@ -309,6 +311,74 @@ func Test_FindBillingAccountSpendForTimeRange(t *testing.T) {
 	}
 }
 
+func Test_FindBillingAccountSpendForPeriod(t *testing.T) {
+	IsEnabled(t)
+	dbTest := "findbillingaccountspendforperiod"
+	err := setUpDatabase(dbTest)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		t.Fatal(err)
+	}
+
+	// create connection
+	clientConfig := &postgresql.ClientConfig{
+		User:     User,
+		Pass:     DbPass,
+		Host:     Host,
+		Port:     PgPort,
+		Database: Dbname + "_" + dbTest,
+	}
+	registry := prometheus.NewRegistry()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(time.Second))
+	defer cancel()
+
+	postgresqlDb, err := postgresql.NewClient(ctx, clientConfig, registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	postgresqlQueries := store.NewTxQueries(postgresqlDb)
+	conn, err := postgresqlDb.Acquire(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Release()
+
+	newCtx := context.Background()
+	_, err = postgresqlDb.Exec(newCtx, `
+		INSERT INTO billing_account(id, create_time, supply_enabled, demand_enabled)
+			VALUES('billing-account-id', '2022-01-20', true, false)
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the period before ends when the requested one starts
+	_, err = postgresqlDb.Exec(newCtx, `
+		INSERT INTO billing_account_spend(billing_account_id, spend, start_time, end_time)
+			VALUES('billing-account-id', 1.0, '2020-01-01', '2020-02-01'),
+			      ('billing-account-id', 2.0, '2020-02-01', '2020-03-01')
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := postgresqlQueries.FindBillingAccountSpendForPeriod(newCtx, store.FindBillingAccountSpendForPeriodParams{
+		BillingAccountID: "billing-account-id",
+		StartTime:        time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:          time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Spend.String() != "2.000000000000000000" {
+		t.Errorf("expected spend to be %s, got %s", "2.000000000000000000", res.Spend.String())
+	}
+	if res.StartTime.String() != time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC).String() {
+		t.Errorf("expected start time to be %s, got %s", time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC), res.StartTime)
+	}
+}
+
 func Test_FindOrderSpendForTimeRange(t *testing.T) {
 	IsEnabled(t)
 	dbTest := "findorderspendfortimerange"
@@ -368,17 +438,18 @@ func Test_FindOrderSpendForTimeRange(t *testing.T) {
 	}
 
 	_, err = postgresqlDb.Exec(newCtx, `
-		INSERT INTO order_spend(order_id, spend, start_time, end_time)
-			VALUES('order-id', 1.0, '2020-01-01', '2020-01-02')
+		INSERT INTO order_spend(order_id, billing_account_id, spend, start_time, end_time)
+			VALUES('order-id', 'billing-account-id', 1.0, '2020-01-01', '2020-01-02')
 	`)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	res, err := postgresqlQueries.FindOrderSpendForTimeRange(newCtx, store.FindOrderSpendForTimeRangeParams{
-		OrderID:   orderId,
-		StartTime: time.Date(2019, time.December, 30, 0, 0, 0, 0, time.UTC),
-		EndTime:   time.Date(2020, time.January, 3, 0, 0, 0, 0, time.UTC),
+		OrderID:          orderId,
+		BillingAccountID: billingAccountId,
+		StartTime:        time.Date(2019, time.December, 30, 0, 0, 0, 0, time.UTC),
+		EndTime:          time.Date(2020, time.January, 3, 0, 0, 0, 0, time.UTC),
 	})
 	if err != nil {
 		t.Fatal(err)
@@ -456,9 +527,9 @@ func Test_FindProjectSpendForTimeRange(t *testing.T) {
 	}
 
 	_, err = postgresqlDb.Exec(newCtx, fmt.Sprintf(`
-		INSERT INTO project_spend(project_id, spend, start_time, end_time)
-			VALUES('%s', 1.0, '2020-01-01', '2020-01-02')
-	`, projectId))
+		INSERT INTO project_spend(project_id, billing_account_id, spend, start_time, end_time)
+			VALUES('%s', '%s', 1.0, '2020-01-01', '2020-01-02')
+	`, projectId, billingAccountId))
 
 	if err != nil {
 		t.Fatal(err)
@@ -3,14 +3,33 @@ package store
 import (
 	"context"
 
+	"biller/lib/postgresql"
+
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
-type TxQuerier interface {
+// Lister holds the hand written list queries in list.go
+type Lister interface {
+	ListAuditEvents(ctx context.Context, arg ListParams) ([]AuditLog, error)
+	ListBillingAccounts(ctx context.Context, arg ListBillingAccountsParams) ([]BillingAccount, error)
+	ListBillingAccountSpend(ctx context.Context, arg ListBillingAccountSpendParams) ([]BillingAccountSpend, error)
+	ListOrders(ctx context.Context, arg ListOrdersParams) ([]Order, error)
+	ListOrderSpendForBillingAccount(ctx context.Context, arg ListOrderSpendForBillingAccountParams) ([]ListOrderSpendForBillingAccountRow, error)
+	ListProjects(ctx context.Context, arg ListProjectsParams) ([]Project, error)
+	ListProjectSpend(ctx context.Context, arg ListProjectSpendParams) ([]ProjectSpend, error)
+}
+
+// QueryLister is every query that can be run, generated or hand written
+type QueryLister interface {
 	Querier
-	BeginTx(context.Context, pgx.TxOptions) (pgx.Tx, Querier, error)
-	ExecWithTx(context.Context, pgx.TxOptions, func(Querier) error) error
+	Lister
+}
+
+type TxQuerier interface {
+	QueryLister
+	BeginTx(context.Context, pgx.TxOptions) (pgx.Tx, QueryLister, error)
+	ExecWithTx(context.Context, pgx.TxOptions, func(QueryLister) error) error
 }
 
 type TxQueries struct {
@@ -18,22 +37,22 @@ type TxQueries struct {
 	pool *pgxpool.Pool
 }
 
-func (txq *TxQueries) BeginTx(ctx context.Context, txOpts pgx.TxOptions) (pgx.Tx, Querier, error) {
+func (txq *TxQueries) BeginTx(ctx context.Context, txOpts pgx.TxOptions) (pgx.Tx, QueryLister, error) {
 	tx, err := txq.pool.BeginTx(ctx, txOpts)
-	queries := txq.Queries.WithTx(tx)
+	queries := New(postgresql.Trace(tx))
 	return tx, queries, err
 }
 
-func (txq *TxQueries) ExecWithTx(ctx context.Context, txOpts pgx.TxOptions, task func(Querier) error) error {
+func (txq *TxQueries) ExecWithTx(ctx context.Context, txOpts pgx.TxOptions, task func(QueryLister) error) error {
 	return txq.pool.BeginTxFunc(ctx, txOpts, func(t pgx.Tx) error {
-		queries := txq.Queries.WithTx(t)
+		queries := New(postgresql.Trace(t))
 		return task(queries)
 	})
 }
 
 func NewTxQueries(pool *pgxpool.Pool) *TxQueries {
 	return &TxQueries{
-		Queries: New(pool),
+		Queries: New(postgresql.Trace(pool)),
 		pool:    pool,
 	}
 }